@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"watchdog/internal/api"
+	"watchdog/internal/config"
+	"watchdog/tasks"
+)
+
+var (
+	listPRsOwner string
+	listPRsRepo  string
+)
+
+// listPRsCmd prints the open PRs watchdog sees and how it classifies them, for debugging
+// the GitHub configuration. It never sends notifications.
+var listPRsCmd = &cobra.Command{
+	Use:   "list-prs",
+	Short: "List open PRs and whether watchdog considers them stale",
+	Long: `List-prs fetches the open pull requests of the configured repositories (or of the one
+given with --owner and --repo) and prints a table with each PR's number, author, age,
+draft flag and status:
+  - stale:   the PR would be alerted on (subject to the notification cooldown)
+  - fresh:   the PR is watched but not idle for stale_days (or stale_duration) yet, or within grace_period
+  - ignored: the PR is a draft (without monitor_drafts), opened by a bot (with ignore_bots),
+             filtered out by the repository's authors, assignees or labels, or not waiting
+             on only_requested_for
+
+The age is measured from the time the repository's stale_metric uses (last update by default).
+No notifications are sent.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if (listPRsOwner == "") != (listPRsRepo == "") {
+			_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "--owner and --repo must be used together")
+			os.Exit(1)
+		}
+
+		githubCfg := appConfig.Tasks.GitHub
+		repos, _ := githubCfg.GetRepositories() // already checked when the config was loaded
+		if listPRsOwner != "" {
+			repos = []config.RepositoryConfig{{Owner: listPRsOwner, Repo: listPRsRepo}}
+		}
+		if len(repos) == 0 {
+			_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "No repositories configured; use --owner and --repo")
+			os.Exit(1)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+		client := tasks.NewGitHubClient(githubCfg)
+		if err := runListPRs(ctx, cmd.OutOrStdout(), client, repos, githubCfg.IncludeArchived, githubCfg.OnlyRequestedFor, tasks.NewClassifyOptions(githubCfg), time.Now()); err != nil {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Failed to list pull requests: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	listPRsCmd.Flags().StringVar(&listPRsOwner, "owner", "", "repository owner (default: the repositories in the config)")
+	listPRsCmd.Flags().StringVar(&listPRsRepo, "repo", "", "repository name (requires --owner)")
+	rootCmd.AddCommand(listPRsCmd)
+}
+
+// runListPRs writes a table of the open PRs of repos to out, classified as of now with opts
+// the same way the PR review check does, after resolving onlyRequestedFor (only_requested_for)
+// into opts.RequestedFor. Organization-wide entries are expanded into the organization's
+// repositories. It stops at the first organization or repository that can't be fetched.
+func runListPRs(ctx context.Context, out io.Writer, client api.GitHubClient, repos []config.RepositoryConfig, includeArchived bool, onlyRequestedFor string, opts tasks.ClassifyOptions, now time.Time) error {
+	requestedFor, err := tasks.ResolveRequestedFor(ctx, client, onlyRequestedFor)
+	if err != nil {
+		return err
+	}
+	opts.RequestedFor = requestedFor
+
+	repos, err = tasks.ExpandRepositories(ctx, client, repos, includeArchived)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "REPO\tPR\tAUTHOR\tAGE\tDRAFT\tSTATUS")
+
+	for _, repoConfig := range repos {
+		prs, err := client.GetOpenPullRequests(ctx, repoConfig.Owner, repoConfig.Repo)
+		if err != nil {
+			_ = w.Flush()
+			return fmt.Errorf("%s/%s: %v", repoConfig.Owner, repoConfig.Repo, err)
+		}
+
+		for _, pr := range prs {
+			draft := "no"
+			if pr.Draft {
+				draft = "yes"
+			}
+			_, _ = fmt.Fprintf(w, "%s/%s\t#%d\t%s\t%s\t%s\t%s\n",
+				repoConfig.Owner, repoConfig.Repo, pr.Number, pr.User.Login,
+				formatAge(now.Sub(tasks.PRStaleSince(pr, repoConfig))), draft,
+				tasks.ClassifyPR(pr, repoConfig, opts, now))
+		}
+	}
+
+	return w.Flush()
+}
+
+// formatAge renders d in whole days, or hours/minutes if it is shorter than a day (e.g. "6d", "3h", "15m").
+func formatAge(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	default:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	}
+}
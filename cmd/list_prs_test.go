@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"watchdog/internal/api"
+	"watchdog/internal/config"
+	"watchdog/tasks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunListPRs_RendersStaleAndFreshPRs(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/acme/api/pulls", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `[
+			{"number": 12, "user": {"login": "alice"}, "updated_at": %q},
+			{"number": 15, "user": {"login": "bob"}, "updated_at": %q},
+			{"number": 17, "user": {"login": "carol"}, "updated_at": %q, "draft": true}
+		]`,
+			now.Add(-6*24*time.Hour).Format(time.RFC3339),
+			now.Add(-3*time.Hour).Format(time.RFC3339),
+			now.Add(-10*24*time.Hour).Format(time.RFC3339))
+	}))
+	defer server.Close()
+
+	client := api.NewGitHubAPI("")
+	client.BaseURL = server.URL
+	repos := []config.RepositoryConfig{{Owner: "acme", Repo: "api"}}
+
+	var out bytes.Buffer
+	err := runListPRs(context.Background(), &out, client, repos, false, "", tasks.ClassifyOptions{StaleThreshold: 4 * 24 * time.Hour}, now)
+
+	require.NoError(t, err)
+	assert.Equal(t, ""+
+		"REPO      PR   AUTHOR  AGE  DRAFT  STATUS\n"+
+		"acme/api  #12  alice   6d   no     stale\n"+
+		"acme/api  #15  bob     3h   no     fresh\n"+
+		"acme/api  #17  carol   10d  yes    ignored\n",
+		out.String())
+}
+
+func TestRunListPRs_AppliesRepositoryFilters(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `[
+			{"number": 1, "user": {"login": "alice"}, "created_at": %[1]q, "updated_at": %[2]q},
+			{"number": 2, "user": {"login": "mallory"}, "created_at": %[1]q, "updated_at": %[2]q}
+		]`,
+			now.Add(-5*24*time.Hour).Format(time.RFC3339),
+			now.Add(-time.Hour).Format(time.RFC3339))
+	}))
+	defer server.Close()
+
+	client := api.NewGitHubAPI("")
+	client.BaseURL = server.URL
+	repos := []config.RepositoryConfig{{
+		Owner:       "acme",
+		Repo:        "api",
+		Authors:     []string{"alice"},
+		StaleMetric: config.StaleMetricCreated,
+	}}
+
+	var out bytes.Buffer
+	require.NoError(t, runListPRs(context.Background(), &out, client, repos, false, "", tasks.ClassifyOptions{StaleThreshold: 4 * 24 * time.Hour}, now))
+
+	assert.Contains(t, out.String(), "#1  alice    5d   no     stale")
+	assert.Contains(t, out.String(), "#2  mallory  5d   no     ignored")
+}
+
+func TestRunListPRs_ReportsFetchError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := api.NewGitHubAPI("")
+	client.BaseURL = server.URL
+	repos := []config.RepositoryConfig{{Owner: "acme", Repo: "missing"}}
+
+	err := runListPRs(context.Background(), &bytes.Buffer{}, client, repos, false, "", tasks.ClassifyOptions{StaleThreshold: 4 * 24 * time.Hour}, time.Now())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "acme/missing")
+}
+
+func TestFormatAge(t *testing.T) {
+	assert.Equal(t, "15m", formatAge(15*time.Minute))
+	assert.Equal(t, "3h", formatAge(3*time.Hour+20*time.Minute))
+	assert.Equal(t, "2d", formatAge(50*time.Hour))
+}
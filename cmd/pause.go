@@ -0,0 +1,38 @@
+package main
+
+import (
+	"os"
+	"os/signal"
+
+	"github.com/rs/zerolog/log"
+
+	"watchdog/internal/scheduler"
+)
+
+// watchPauseSignal toggles sched between paused and running each time the pause signal
+// (SIGUSR1, where supported) is received, so alerts can be silenced during planned
+// maintenance without stopping the process. The returned function stops watching.
+func watchPauseSignal(sched *scheduler.Scheduler) func() {
+	signals := make(chan os.Signal, 1)
+	if !notifyPauseSignal(signals) {
+		return func() {}
+	}
+	go handlePauseSignals(sched, signals)
+	return func() {
+		signal.Stop(signals)
+		close(signals)
+	}
+}
+
+// handlePauseSignals toggles sched for every value received on signals, until it is closed.
+func handlePauseSignals(sched *scheduler.Scheduler, signals <-chan os.Signal) {
+	for range signals {
+		if sched.Paused() {
+			sched.Resume()
+			log.Info().Msg("Monitoring resumed")
+		} else {
+			sched.Pause()
+			log.Warn().Msg("Monitoring paused; send SIGUSR1 again to resume")
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"watchdog/internal/scheduler"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlePauseSignals_Toggles(t *testing.T) {
+	sched := scheduler.NewScheduler()
+	signals := make(chan os.Signal)
+	done := make(chan struct{})
+	go func() {
+		handlePauseSignals(sched, signals)
+		close(done)
+	}()
+
+	signals <- os.Interrupt // any value toggles
+	assert.Eventually(t, sched.Paused, time.Second, time.Millisecond)
+
+	signals <- os.Interrupt // any value toggles
+	assert.Eventually(t, func() bool { return !sched.Paused() }, time.Second, time.Millisecond)
+
+	close(signals)
+	<-done
+}
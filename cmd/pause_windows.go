@@ -0,0 +1,11 @@
+//go:build windows
+
+package main
+
+import "os"
+
+// notifyPauseSignal reports that pausing by signal isn't supported on Windows,
+// which has no SIGUSR1.
+func notifyPauseSignal(signals chan<- os.Signal) bool {
+	return false
+}
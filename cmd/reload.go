@@ -0,0 +1,163 @@
+package main
+
+import (
+	"os"
+	"os/signal"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+
+	"watchdog/internal/config"
+	"watchdog/internal/scheduler"
+)
+
+// taskManager keeps the scheduler's task set in sync with the configuration.
+// On a config reload it adds newly enabled tasks, removes disabled ones,
+// recreates tasks whose settings changed and reschedules tasks whose interval changed.
+// Unchanged tasks keep running untouched, so their notification cooldowns are preserved.
+type taskManager struct {
+	// sched is the scheduler the tasks are registered with
+	sched *scheduler.Scheduler
+
+	// current holds the currently scheduled tasks, keyed by task key ("telnyx", "github")
+	current map[string]plannedTask
+
+	// mu serializes changes to the task set
+	mu sync.Mutex
+
+	// reloadMu serializes reloads, from reading the config file to applying it, so a
+	// file change and a reload signal can't interleave and apply an older config last
+	reloadMu sync.Mutex
+}
+
+// newTaskManager creates a taskManager for sched with no tasks registered yet.
+func newTaskManager(sched *scheduler.Scheduler) *taskManager {
+	return &taskManager{
+		sched:   sched,
+		current: make(map[string]plannedTask),
+	}
+}
+
+// apply reconciles the scheduler with the tasks enabled in cfg.
+func (m *taskManager) apply(cfg config.Config) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	notifiers := newTaskNotifiers(cfg)
+	planned := planTasks(cfg, notifiers)
+	m.sched.SetFailureAlerts(failureAlerts(cfg, notifiers))
+	m.sched.SetFailureBackoff(scheduler.FailureBackoff{MaxInterval: cfg.Scheduler.GetMaxBackoff()})
+
+	// Remove tasks that are no longer configured
+	for _, key := range sortedKeys(m.current) {
+		if _, ok := planned[key]; !ok {
+			log.Info().Str("task", key).Msg("Task removed from configuration, stopping it")
+			m.sched.RemoveTask(m.current[key].task)
+			delete(m.current, key)
+		}
+	}
+
+	for _, key := range sortedKeys(planned) {
+		next := planned[key]
+		existing, ok := m.current[key]
+
+		switch {
+		case !ok:
+			// Newly configured task
+			m.sched.ScheduleTask(next.task, next.interval)
+			m.current[key] = next
+		case !reflect.DeepEqual(existing.settings, next.settings):
+			// Settings changed - replace the task with a freshly built one
+			log.Info().Str("task", key).Msg("Task configuration changed, restarting it")
+			m.sched.RemoveTask(existing.task)
+			m.sched.ScheduleTask(next.task, next.interval)
+			m.current[key] = next
+		case existing.interval != next.interval:
+			// Only the interval changed - keep the task (and its state), just retime it
+			log.Info().Str("task", key).Dur("interval", next.interval).Msg("Task interval changed, rescheduling it")
+			m.sched.Reschedule(existing.task, next.interval)
+			existing.interval = next.interval
+			m.current[key] = existing
+		}
+	}
+}
+
+// reload re-reads configuration from the config file at path, validates it, and applies it.
+// An unreadable or invalid configuration is logged and rejected, and the previous tasks
+// keep running.
+//
+// Each reload reads the file with its own viper instance, since viper isn't safe for
+// concurrent use and the file watcher reads into the shared one on its own goroutine.
+func (m *taskManager) reload(path string) {
+	m.reloadMu.Lock()
+	defer m.reloadMu.Unlock()
+
+	cfg, err := readConfig(viper.New(), path)
+	if err != nil {
+		log.Error().Err(err).Msg("Unable to read reloaded config, keeping previous configuration")
+		return
+	}
+	if err := applyIntervalOverride(&cfg, intervalOverride); err != nil {
+		log.Error().Err(err).Msg("Unable to apply --interval to reloaded config, keeping previous configuration")
+		return
+	}
+	if err := validateConfig(&cfg); err != nil {
+		log.Error().Err(err).Msg("Reloaded config is invalid, keeping previous configuration")
+		return
+	}
+	for _, warning := range serviceURLWarnings(cfg) {
+		log.Warn().Msg(warning)
+	}
+
+	log.Info().Msg("Configuration reloaded")
+	m.apply(cfg)
+}
+
+// watchConfig reloads configuration whenever the config file used by v changes on disk.
+// After this call v belongs to viper's watcher; reloads read the file themselves.
+func watchConfig(v *viper.Viper, m *taskManager) {
+	path := v.ConfigFileUsed()
+	v.OnConfigChange(func(e fsnotify.Event) {
+		log.Info().Str("file", e.Name).Msg("Config file changed")
+		m.reload(path)
+	})
+	v.WatchConfig()
+}
+
+// watchReloadSignal reloads configuration from the config file at path each time the
+// reload signal (SIGHUP, where supported) is received, for operators who expect
+// `kill -HUP` to work. The returned function stops watching.
+func watchReloadSignal(path string, m *taskManager) func() {
+	signals := make(chan os.Signal, 1)
+	if !notifyReloadSignal(signals) {
+		return func() {}
+	}
+	go handleReloadSignals(path, m, signals)
+	return func() {
+		signal.Stop(signals)
+		close(signals)
+	}
+}
+
+// handleReloadSignals reloads the config file at path for every value received on
+// signals, until it is closed. A file that can't be read keeps the previous configuration.
+func handleReloadSignals(path string, m *taskManager, signals <-chan os.Signal) {
+	for range signals {
+		log.Info().Str("file", path).Msg("Reload signal received")
+		m.reload(path)
+	}
+}
+
+// sortedKeys returns the keys of tasks in sorted order so reconciliation is deterministic.
+func sortedKeys(tasks map[string]plannedTask) []string {
+	keys := make([]string, 0, len(tasks))
+	for key := range tasks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,417 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"watchdog/internal/config"
+	"watchdog/internal/notifier"
+	"watchdog/tasks"
+)
+
+const reloadNotifierYAML = `
+notifier:
+  apprise_api_url: "https://apprise.example.com/notify"
+  apprise_service_url: "tgram://token/id"
+`
+
+const reloadTelnyxYAML = `
+tasks:
+  telnyx:
+    api_url: "https://api.telnyx.com/v2/balance"
+    api_key: "KEY123"
+`
+
+const reloadBothYAML = `
+tasks:
+  telnyx:
+    api_url: "https://api.telnyx.com/v2/balance"
+    api_key: "KEY123"
+  github:
+    repositories:
+      - owner: "owner"
+        repo: "repo"
+`
+
+const reloadGitHubYAML = `
+tasks:
+  github:
+    repositories:
+      - owner: "owner"
+        repo: "repo"
+`
+
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestWatchConfig_ReloadsTaskSet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, reloadNotifierYAML+reloadTelnyxYAML)
+
+	v := viper.New()
+	cfg, err := loadConfig(v, path)
+	require.NoError(t, err)
+
+	sched, manager := buildScheduler(cfg)
+	require.Equal(t, []string{"telnyx-balance"}, sched.TaskNames())
+
+	watchConfig(v, manager)
+
+	// Adding a repository enables the GitHub task
+	writeConfig(t, path, reloadNotifierYAML+reloadBothYAML)
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"telnyx-balance", "github-pr-review"}, sched.TaskNames())
+	}, 5*time.Second, 20*time.Millisecond)
+
+	// An invalid config is rejected and the previous tasks keep running
+	writeConfig(t, path, reloadBothYAML)
+	time.Sleep(300 * time.Millisecond)
+	assert.Equal(t, []string{"telnyx-balance", "github-pr-review"}, sched.TaskNames())
+
+	// Removing Telnyx settings stops that task
+	writeConfig(t, path, reloadNotifierYAML+reloadGitHubYAML)
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"github-pr-review"}, sched.TaskNames())
+	}, 5*time.Second, 20*time.Millisecond)
+}
+
+func TestHandleReloadSignals_ReloadsTaskSet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, reloadNotifierYAML+reloadTelnyxYAML)
+
+	v := viper.New()
+	cfg, err := loadConfig(v, path)
+	require.NoError(t, err)
+
+	sched, manager := buildScheduler(cfg)
+	require.Equal(t, []string{"telnyx-balance"}, sched.TaskNames())
+
+	signals := make(chan os.Signal)
+	done := make(chan struct{})
+	go func() {
+		handleReloadSignals(path, manager, signals)
+		close(done)
+	}()
+
+	// Nothing happens until the signal arrives
+	writeConfig(t, path, reloadNotifierYAML+reloadBothYAML)
+	assert.Equal(t, []string{"telnyx-balance"}, sched.TaskNames())
+
+	signals <- os.Interrupt // any value reloads
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"telnyx-balance", "github-pr-review"}, sched.TaskNames())
+	}, time.Second, time.Millisecond)
+
+	// An invalid config is rejected and the previous tasks keep running
+	writeConfig(t, path, reloadBothYAML)
+	signals <- os.Interrupt
+	// A missing file is rejected too
+	require.NoError(t, os.Remove(path))
+	signals <- os.Interrupt
+	close(signals)
+	<-done
+	assert.Equal(t, []string{"telnyx-balance", "github-pr-review"}, sched.TaskNames())
+}
+
+func TestTaskManager_FileChangeAndSignalReloadsTogether(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, reloadNotifierYAML+reloadTelnyxYAML)
+
+	v := viper.New()
+	cfg, err := loadConfig(v, path)
+	require.NoError(t, err)
+
+	sched, manager := buildScheduler(cfg)
+	watchConfig(v, manager)
+
+	signals := make(chan os.Signal)
+	done := make(chan struct{})
+	go func() {
+		handleReloadSignals(path, manager, signals)
+		close(done)
+	}()
+
+	// File changes and reload signals arrive at the same time
+	for i := 0; i < 10; i++ {
+		if i%2 == 0 {
+			writeConfig(t, path, reloadNotifierYAML+reloadBothYAML)
+		} else {
+			writeConfig(t, path, reloadNotifierYAML+reloadTelnyxYAML)
+		}
+		signals <- os.Interrupt
+	}
+	writeConfig(t, path, reloadNotifierYAML+reloadGitHubYAML)
+	signals <- os.Interrupt
+	close(signals)
+	<-done
+
+	// Whichever reload runs last reads the final file
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"github-pr-review"}, sched.TaskNames())
+	}, 5*time.Second, 20*time.Millisecond)
+}
+
+func TestApplyIntervalOverride(t *testing.T) {
+	cfg := config.Config{
+		Notifier: config.NotifierConfig{
+			AppriseAPIURL:     "https://apprise.example.com/notify",
+			AppriseServiceURL: "tgram://token/id",
+		},
+		Scheduler: config.SchedulerConfig{Interval: "10m"},
+		Tasks: config.TasksConfig{
+			Telnyx: config.TelnyxConfig{APIURL: "https://api.telnyx.com/v2/balance", APIKey: "KEY123", Threshold: 5},
+		},
+	}
+
+	require.NoError(t, applyIntervalOverride(&cfg, "30s"))
+	_, manager := buildScheduler(cfg)
+	assert.Equal(t, 30*time.Second, manager.current["telnyx"].interval)
+
+	// Without the flag the config file's interval is kept
+	unchanged := cfg
+	unchanged.Scheduler.Interval = "10m"
+	require.NoError(t, applyIntervalOverride(&unchanged, ""))
+	assert.Equal(t, "10m", unchanged.Scheduler.Interval)
+
+	for _, invalid := range []string{"soon", "30", "0s", "-1m"} {
+		err := applyIntervalOverride(&unchanged, invalid)
+		assert.ErrorContains(t, err, "invalid --interval", invalid)
+		assert.Equal(t, "10m", unchanged.Scheduler.Interval)
+	}
+}
+
+func TestTaskManager_Reload_KeepsIntervalOverride(t *testing.T) {
+	intervalOverride = "45s"
+	t.Cleanup(func() { intervalOverride = "" })
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, reloadNotifierYAML+reloadTelnyxYAML)
+
+	v := viper.New()
+	cfg, err := loadConfig(v, path)
+	require.NoError(t, err)
+	require.NoError(t, applyIntervalOverride(&cfg, intervalOverride))
+
+	_, manager := buildScheduler(cfg)
+	require.Equal(t, 45*time.Second, manager.current["telnyx"].interval)
+
+	// The config file's interval doesn't win after a reload
+	writeConfig(t, path, reloadNotifierYAML+reloadTelnyxYAML+"scheduler:\n  interval: \"10m\"\n")
+	manager.reload(path)
+	assert.Equal(t, 45*time.Second, manager.current["telnyx"].interval)
+}
+
+func TestTaskManager_Apply(t *testing.T) {
+	base := config.Config{
+		Notifier: config.NotifierConfig{
+			AppriseAPIURL:     "https://apprise.example.com/notify",
+			AppriseServiceURL: "tgram://token/id",
+		},
+		Tasks: config.TasksConfig{
+			Telnyx: config.TelnyxConfig{APIURL: "https://api.telnyx.com/v2/balance", APIKey: "KEY123", Threshold: 5},
+		},
+	}
+
+	sched, manager := buildScheduler(base)
+	original := manager.current["telnyx"].task
+
+	// Unchanged config keeps the same task instance
+	manager.apply(base)
+	assert.Same(t, original, manager.current["telnyx"].task)
+
+	// Interval-only change reschedules the existing task
+	retimed := base
+	retimed.Tasks.Telnyx.Interval = "1m"
+	manager.apply(retimed)
+	assert.Same(t, original, manager.current["telnyx"].task)
+	assert.Equal(t, time.Minute, manager.current["telnyx"].interval)
+
+	// Settings change replaces the task
+	changed := retimed
+	changed.Tasks.Telnyx.Threshold = 20
+	manager.apply(changed)
+	assert.NotSame(t, original, manager.current["telnyx"].task)
+	assert.Equal(t, []string{"telnyx-balance"}, sched.TaskNames())
+}
+
+func TestPlanTasks_MonitorIssues(t *testing.T) {
+	cfg := config.Config{
+		Notifier: config.NotifierConfig{
+			AppriseAPIURL:     "https://apprise.example.com/notify",
+			AppriseServiceURL: "tgram://token/id",
+		},
+		Tasks: config.TasksConfig{
+			GitHub: config.GitHubConfig{
+				Repositories: []config.RepositoryConfig{{Owner: "owner", Repo: "repo"}},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"github"}, sortedKeys(planTasks(cfg, newTaskNotifiers(cfg))))
+
+	cfg.Tasks.GitHub.MonitorIssues = true
+	planned := planTasks(cfg, newTaskNotifiers(cfg))
+	assert.Equal(t, []string{"github", "github_issues"}, sortedKeys(planned))
+	assert.IsType(t, &tasks.IssueReviewCheckTask{}, planned["github_issues"].task)
+}
+
+func TestPlanTasks_Workflows(t *testing.T) {
+	cfg := config.Config{
+		Notifier: config.NotifierConfig{
+			AppriseAPIURL:     "https://apprise.example.com/notify",
+			AppriseServiceURL: "tgram://token/id",
+		},
+		Tasks: config.TasksConfig{
+			GitHub: config.GitHubConfig{
+				Interval:  "15m",
+				Workflows: []config.WorkflowConfig{{Owner: "owner", Repo: "repo"}},
+			},
+		},
+	}
+
+	planned := planTasks(cfg, newTaskNotifiers(cfg))
+
+	assert.Equal(t, []string{"github_workflows"}, sortedKeys(planned))
+	assert.IsType(t, &tasks.WorkflowCheckTask{}, planned["github_workflows"].task)
+	assert.Equal(t, 15*time.Minute, planned["github_workflows"].interval)
+}
+
+func TestPlanTasks_TelnyxTotalBalance(t *testing.T) {
+	cfg := config.Config{
+		Notifier: config.NotifierConfig{
+			AppriseAPIURL:     "https://apprise.example.com/notify",
+			AppriseServiceURL: "tgram://token/id",
+		},
+		Tasks: config.TasksConfig{
+			Telnyx: config.TelnyxConfig{APIURL: "https://api.telnyx.com/v2/balance", APIKey: "KEY123", Interval: "10m"},
+		},
+	}
+	assert.Equal(t, []string{"telnyx"}, sortedKeys(planTasks(cfg, newTaskNotifiers(cfg))))
+
+	cfg.Tasks.Telnyx.TotalThreshold = 100
+	cfg.Tasks.Telnyx.Accounts = []config.TelnyxAccountConfig{{Name: "eu", APIKey: "KEY_EU"}}
+	planned := planTasks(cfg, newTaskNotifiers(cfg))
+	assert.Equal(t, []string{"telnyx", "telnyx_total"}, sortedKeys(planned))
+	assert.IsType(t, &tasks.TelnyxTotalBalanceCheckTask{}, planned["telnyx_total"].task)
+	assert.Equal(t, 10*time.Minute, planned["telnyx_total"].interval)
+}
+
+func TestPlanTasks_HTTPChecks(t *testing.T) {
+	cfg := config.Config{
+		Notifier: config.NotifierConfig{
+			AppriseAPIURL:     "https://apprise.example.com/notify",
+			AppriseServiceURL: "tgram://token/id",
+		},
+		Tasks: config.TasksConfig{
+			HTTPChecks: []config.HTTPCheckConfig{
+				{Name: "api", URL: "https://api.example.com/healthz", Interval: "1m"},
+				{URL: "https://www.example.com"},
+			},
+		},
+	}
+
+	planned := planTasks(cfg, newTaskNotifiers(cfg))
+
+	assert.Equal(t, []string{"http_check:api", "http_check:https://www.example.com"}, sortedKeys(planned))
+	assert.IsType(t, &tasks.HTTPCheckTask{}, planned["http_check:api"].task)
+	assert.Equal(t, time.Minute, planned["http_check:api"].interval)
+}
+
+// slackRecorder is a fake Slack webhook that records the header of each message it receives.
+func slackRecorder(t *testing.T) (*httptest.Server, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var subjects []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload notifier.SlackPayload
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		mu.Lock()
+		subjects = append(subjects, payload.Blocks[0].Text.Text)
+		mu.Unlock()
+		_, _ = io.WriteString(w, "ok")
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), subjects...)
+	}
+}
+
+func TestPlanTasks_NamedNotifiers(t *testing.T) {
+	defaultHook, defaultSubjects := slackRecorder(t)
+	opsHook, opsSubjects := slackRecorder(t)
+
+	telnyxAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"data": {"balance": "1.00", "currency": "USD"}}`)
+	}))
+	defer telnyxAPI.Close()
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer down.Close()
+
+	cfg := config.Config{
+		Notifier: config.NotifierConfig{Backend: "slack", SlackWebhookURL: defaultHook.URL},
+		Notifiers: map[string]config.NotifierConfig{
+			"ops": {Backend: "slack", SlackWebhookURL: opsHook.URL},
+		},
+		Tasks: config.TasksConfig{
+			Telnyx: config.TelnyxConfig{APIURL: telnyxAPI.URL, APIKey: "KEY123", Threshold: 5, Notifier: "ops"},
+			HTTPChecks: []config.HTTPCheckConfig{
+				{Name: "website", URL: down.URL},
+				{Name: "api", URL: down.URL, Notifier: "OPS"},
+			},
+		},
+	}
+	require.NoError(t, validateConfig(&cfg))
+
+	planned := planTasks(cfg, newTaskNotifiers(cfg))
+	for _, key := range []string{"telnyx", "http_check:website", "http_check:api"} {
+		require.Contains(t, planned, key)
+		_ = planned[key].task.Run(context.Background())
+	}
+
+	assert.Equal(t, []string{"HTTP Check Failed: website"}, defaultSubjects())
+	assert.ElementsMatch(t, []string{"Telnyx Balance Alert", "HTTP Check Failed: api"}, opsSubjects())
+}
+
+func TestTaskManager_Apply_NamedNotifierChange(t *testing.T) {
+	base := config.Config{
+		Notifier: config.NotifierConfig{Backend: "slack", SlackWebhookURL: "https://hooks.slack.com/services/T/B/default"},
+		Notifiers: map[string]config.NotifierConfig{
+			"ops": {Backend: "slack", SlackWebhookURL: "https://hooks.slack.com/services/T/B/ops"},
+		},
+		Tasks: config.TasksConfig{
+			Telnyx:     config.TelnyxConfig{APIURL: "https://api.telnyx.com/v2/balance", APIKey: "KEY123", Notifier: "ops"},
+			HTTPChecks: []config.HTTPCheckConfig{{Name: "website", URL: "https://example.com"}},
+		},
+	}
+
+	_, manager := buildScheduler(base)
+	telnyxTask := manager.current["telnyx"].task
+	checkTask := manager.current["http_check:website"].task
+
+	// Changing the ops notifier only restarts the tasks using it
+	changed := base
+	changed.Notifiers = map[string]config.NotifierConfig{
+		"ops": {Backend: "slack", SlackWebhookURL: "https://hooks.slack.com/services/T/B/ops2"},
+	}
+	manager.apply(changed)
+	assert.NotSame(t, telnyxTask, manager.current["telnyx"].task)
+	assert.Same(t, checkTask, manager.current["http_check:website"].task)
+}
@@ -0,0 +1,15 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifyReloadSignal relays SIGHUP to signals.
+func notifyReloadSignal(signals chan<- os.Signal) bool {
+	signal.Notify(signals, syscall.SIGHUP)
+	return true
+}
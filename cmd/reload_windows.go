@@ -0,0 +1,11 @@
+//go:build windows
+
+package main
+
+import "os"
+
+// notifyReloadSignal reports that reloading by signal isn't supported on Windows,
+// which has no SIGHUP; config file changes are still picked up.
+func notifyReloadSignal(signals chan<- os.Signal) bool {
+	return false
+}
@@ -1,30 +1,36 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"watchdog/internal/api"
 	"watchdog/internal/config"
+	"watchdog/internal/health"
+	"watchdog/internal/metrics"
 	"watchdog/internal/notifier"
+	"watchdog/internal/proxy"
 	"watchdog/internal/scheduler"
+	"watchdog/internal/state"
+	"watchdog/internal/tlsconfig"
 	"watchdog/tasks"
 )
 
-// Version information populated by ldflags during build.
-// These are set by GoReleaser or manual builds with -ldflags.
-var (
-	version   = "dev"
-	commit    = "none"
-	buildDate = "unknown"
-)
-
 // cfgFile holds the path to the configuration file specified via command-line flag.
 // If empty, the application will look for config.yaml in the current directory.
 var cfgFile string
@@ -32,6 +38,21 @@ var cfgFile string
 // showVersion indicates if the --version flag was provided.
 var showVersion bool
 
+// logFormat and logLevel hold the --log-format and --log-level flag values.
+// When empty, the log.format and log.level config values (or their defaults) are used.
+var (
+	logFormat string
+	logLevel  string
+)
+
+// runOnce indicates if the --once flag was provided.
+// In this mode every task runs a single time and the process exits.
+var runOnce bool
+
+// intervalOverride holds the --interval flag value. When set, it replaces scheduler.interval
+// (the interval of tasks without their own), including after config reloads.
+var intervalOverride string
+
 // appConfig stores the parsed configuration from the YAML file.
 // This includes settings for Telnyx monitoring, GitHub PR monitoring, notifications, and scheduling.
 var appConfig config.Config
@@ -46,16 +67,79 @@ var rootCmd = &cobra.Command{
   - Monitors GitHub pull requests and notifies when they're stale (pending review for too long)
   - Sends notifications via Apprise (supports Telegram, Discord, email, and more)`,
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
-		// Initialize the global logger with pretty console output
-		log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
+		// Load configuration (not needed just to print the version)
+		if !showVersion {
+			initConfig()
+		}
+
+		// Initialize the global logger. Flags take precedence over the config file.
+		logCfg := appConfig.Log
+		if logFormat != "" {
+			logCfg.Format = logFormat
+		}
+		if logLevel != "" {
+			logCfg.Level = logLevel
+		}
+
+		logger, err := newLogger(os.Stderr, logCfg.GetFormat(), logCfg.GetLevel())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid logging configuration: %v\n", err)
+			os.Exit(1)
+		}
+		log.Logger = logger
 		zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
+
+		if !showVersion {
+			configureProxy(appConfig.HTTP)
+			api.SetRequestBudget(appConfig.HTTP.APIRequestsPerHour)
+			configureTLS(appConfig.TLS)
+		}
 	},
 	Run: func(cmd *cobra.Command, args []string) {
 		if showVersion {
-			fmt.Printf("watchdog version %s\ncommit: %s\nbuilt: %s\n", version, commit, buildDate)
+			_, _ = fmt.Fprint(cmd.OutOrStdout(), formatVersion(currentBuildInfo()))
 			return
 		}
-		runApp()
+
+		log.Info().Str("config_file", viper.ConfigFileUsed()).Msg("Configuration loaded")
+
+		sched, manager := buildScheduler(appConfig)
+
+		// Check if at least one task was scheduled
+		if !sched.HasTasks() {
+			log.Fatal().Msg("No tasks configured! Please configure at least one of: Telnyx balance monitoring, GitHub PR, issue or workflow monitoring, or HTTP checks")
+		}
+
+		// Wait for interrupt signal for graceful shutdown
+		// This allows the program to be stopped cleanly with Ctrl+C (SIGINT) or kill (SIGTERM)
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+
+		// Expose Prometheus metrics and health probes if configured (not useful for one-shot runs)
+		if !runOnce {
+			if appConfig.Metrics.ListenAddr != "" {
+				defer startServer(metrics.NewServer(appConfig.Metrics.ListenAddr))()
+			}
+			if appConfig.Health.ListenAddr != "" {
+				defer startServer(health.NewServer(appConfig.Health.ListenAddr, sched))()
+			}
+		}
+
+		// SIGUSR1 pauses and resumes monitoring (e.g., during planned maintenance)
+		if !runOnce {
+			defer watchPauseSignal(sched)()
+		}
+
+		// Pick up config file changes, and SIGHUP, without restarting
+		if !runOnce && viper.ConfigFileUsed() != "" {
+			watchConfig(viper.GetViper(), manager)
+			defer watchReloadSignal(viper.ConfigFileUsed(), manager)()
+		}
+
+		if err := runApp(sched, runOnce, sigChan, appConfig.Scheduler.GetShutdownTimeout()); err != nil {
+			log.Error().Err(err).Msg("One or more tasks failed")
+			os.Exit(1)
+		}
 	},
 }
 
@@ -70,58 +154,226 @@ func Execute() {
 	}
 }
 
-// init is called automatically before main() and sets up the CLI flags and configuration.
-// It registers the initConfig function to be called on Cobra initialization and defines
-// persistent flags including --config and --version.
+// init is called automatically before main() and sets up the CLI flags.
+// It defines persistent flags including --config, --version, --log-format and --log-level,
+// and the --once and --interval flags. Configuration itself is loaded in PersistentPreRun.
 func init() {
-	cobra.OnInitialize(initConfig)
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is ./config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default: $WATCHDOG_CONFIG, or config.yaml/.json/.toml in ., $HOME/.config/watchdog or /etc/watchdog)")
 	rootCmd.PersistentFlags().BoolVarP(&showVersion, "version", "v", false, "show version information")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "", "log output format: console or json (default is console)")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "", "minimum log level: trace, debug, info, warn, error (default is info)")
+	rootCmd.Flags().BoolVar(&runOnce, "once", false, "run each configured task once and exit (for cron-based deployments)")
+	rootCmd.Flags().StringVar(&intervalOverride, "interval", "", "override scheduler.interval, the interval of tasks without their own (e.g. 30s)")
 }
 
-// initConfig reads the configuration file and unmarshals it into the appConfig struct.
-// It supports both explicit config file paths (via --config flag) and automatic discovery.
-// If no config file is specified, it looks for config.yaml in the current directory.
-// Environment variables are also automatically bound and can override config file values.
-// This function will terminate the application with a fatal error if:
-//   - The config file cannot be read
-//   - The config file cannot be unmarshaled
-//
-// initConfig reads configuration from the file specified by the --config flag (or config.yaml in the current directory) and environment variables, unmarshals it into the package-level appConfig, and validates required fields.
+// initConfig loads configuration from the file specified by the --config flag or WATCHDOG_CONFIG
+// (or a config file found in the search paths, if present) and environment variables into the
+// package-level appConfig.
 // On read, unmarshal, or validation failure it writes an error message to stderr and exits the process with status 1.
 func initConfig() {
-	if cfgFile != "" {
+	cfg, err := loadConfig(viper.GetViper(), configPath(cfgFile))
+	if err == nil {
+		err = applyIntervalOverride(&cfg, intervalOverride)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+	appConfig = cfg
+}
+
+// applyIntervalOverride replaces cfg's scheduler.interval with interval (the --interval
+// flag), unless it is empty. It returns an error if interval isn't a positive duration.
+func applyIntervalOverride(cfg *config.Config, interval string) error {
+	interval = strings.TrimSpace(interval)
+	if interval == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(interval)
+	if err != nil {
+		return fmt.Errorf("invalid --interval %q: %v", interval, err)
+	}
+	if d <= 0 {
+		return fmt.Errorf("invalid --interval %q: must be positive", interval)
+	}
+	cfg.Scheduler.Interval = interval
+	return nil
+}
+
+// loadConfig reads configuration into a config.Config using v.
+// If path is set, that file is read and must exist. Otherwise a config file (YAML, JSON or
+// TOML) is looked up in the current directory, $HOME/.config/watchdog and /etc/watchdog, in
+// that order; if none exists, configuration comes from environment variables alone
+// (e.g., NOTIFIER_APPRISE_API_URL, TASKS_TELNYX_API_KEY).
+// Environment variables always override values from the file.
+// The returned error describes the first read, decode or validation failure.
+func loadConfig(v *viper.Viper, path string) (config.Config, error) {
+	cfg, err := readConfig(v, path)
+	if err != nil {
+		return cfg, err
+	}
+
+	// Validate required configuration fields
+	if err := validateConfig(&cfg); err != nil {
+		return cfg, fmt.Errorf("configuration validation failed: %v", err)
+	}
+	for _, warning := range serviceURLWarnings(cfg) {
+		log.Warn().Msg(warning)
+	}
+
+	return cfg, nil
+}
+
+// configEnvVar names the environment variable that can point at the config file instead of --config.
+const configEnvVar = "WATCHDOG_CONFIG"
+
+// configSearchPaths returns the directories searched, in order, for a config file when
+// neither --config nor WATCHDOG_CONFIG is set. It's a variable so tests can replace it.
+var configSearchPaths = defaultConfigSearchPaths
+
+// defaultConfigSearchPaths returns the current directory, $HOME/.config/watchdog and /etc/watchdog.
+func defaultConfigSearchPaths() []string {
+	paths := []string{"."}
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".config", "watchdog"))
+	}
+	return append(paths, "/etc/watchdog")
+}
+
+// configPath returns the config file to read: the --config flag if set, otherwise
+// WATCHDOG_CONFIG. An empty result means the search paths are used.
+func configPath(flag string) string {
+	if flag != "" {
+		return flag
+	}
+	return strings.TrimSpace(os.Getenv(configEnvVar))
+}
+
+// readConfig reads and decodes configuration like loadConfig, but does not validate it.
+func readConfig(v *viper.Viper, path string) (config.Config, error) {
+	var cfg config.Config
+
+	if path != "" {
 		// Use config file from the flag
-		viper.SetConfigFile(cfgFile)
+		v.SetConfigFile(path)
 	} else {
-		// Search for config.yaml in the current directory
-		viper.AddConfigPath(".")
-		viper.SetConfigName("config")
-		viper.SetConfigType("yaml")
+		// Search for config.yaml (or .yml, .json, .toml) in the standard locations.
+		// The type is left unset so viper detects it from the extension.
+		for _, dir := range configSearchPaths() {
+			v.AddConfigPath(dir)
+		}
+		v.SetConfigName("config")
 	}
 
 	// Read environment variables that match config keys
-	viper.AutomaticEnv()
+	// Nested keys map to upper-case, underscore-separated names (tasks.telnyx.api_key -> TASKS_TELNYX_API_KEY)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+	for _, key := range config.Keys() {
+		if err := v.BindEnv(key); err != nil {
+			return cfg, fmt.Errorf("failed to bind environment variable for %s: %v", key, err)
+		}
+	}
 
-	// Read the config file - this is fatal if it fails
-	if err := viper.ReadInConfig(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading config file: %s\n", err)
-		fmt.Fprintf(os.Stderr, "Please ensure a valid config file exists (use --config flag or create config.yaml)\n")
-		os.Exit(1)
+	// Read the config file - a missing config.yaml is fine if the environment provides everything
+	if err := v.ReadInConfig(); err != nil {
+		var notFound viper.ConfigFileNotFoundError
+		if path != "" || !errors.As(err, &notFound) {
+			return cfg, fmt.Errorf("error reading config file: %s\n"+
+				"Please ensure a valid config file exists (use --config flag or create config.yaml)", err)
+		}
 	}
 
-	// Unmarshal the config into our struct - this is fatal if it fails
-	if err := viper.Unmarshal(&appConfig); err != nil {
-		fmt.Fprintf(os.Stderr, "Unable to decode config into struct: %v\n", err)
-		fmt.Fprintf(os.Stderr, "Please check your config file format matches the expected structure\n")
-		os.Exit(1)
+	// Unmarshal the config into our struct
+	if err := v.Unmarshal(&cfg); err != nil {
+		return cfg, fmt.Errorf("unable to decode config into struct: %v\n"+
+			"Please check your config file format matches the expected structure", err)
 	}
 
-	// Validate required configuration fields
-	if err := validateConfig(&appConfig); err != nil {
-		fmt.Fprintf(os.Stderr, "Configuration validation failed: %v\n", err)
-		os.Exit(1)
+	return cfg, nil
+}
+
+// serviceURLWarnings describes Apprise service URLs whose scheme watchdog doesn't recognize.
+// Apprise supports far more services than watchdog knows about, so these are warnings:
+// the URL may be fine, or it may contain a typo such as "tgam://".
+// Both the default notifier and the named notifiers are checked.
+func serviceURLWarnings(cfg config.Config) []string {
+	warnings := notifierServiceURLWarnings("notifier", cfg.Notifier)
+	for _, name := range slices.Sorted(maps.Keys(cfg.Notifiers)) {
+		warnings = append(warnings, notifierServiceURLWarnings("notifiers."+name, cfg.Notifiers[name])...)
 	}
+	return warnings
+}
+
+// notifierServiceURLWarnings is serviceURLWarnings for a single notifier, whose
+// settings are under key.
+func notifierServiceURLWarnings(key string, cfg config.NotifierConfig) []string {
+	var warnings []string
+	for _, serviceURL := range cfg.GetServiceURLs() {
+		if recognized, err := notifier.CheckServiceURL(serviceURL); err == nil && !recognized {
+			warnings = append(warnings, fmt.Sprintf("%s.apprise_service_url: %q has an unrecognized scheme; check it is supported by Apprise", key, serviceURL))
+		}
+	}
+	return warnings
+}
+
+// validateNotifier checks the settings of a notifier: those of each selected backend,
+// the quiet hours and the body format.
+func validateNotifier(cfg config.NotifierConfig) error {
+	for _, backend := range cfg.GetBackends() {
+		if err := notifier.ValidateBackend(cfg, backend); err != nil {
+			return err
+		}
+	}
+	if cfg.QuietHours.IsEnabled() {
+		if _, _, _, err := cfg.QuietHours.GetWindow(); err != nil {
+			return fmt.Errorf("notifier.quiet_hours.%v", err)
+		}
+		switch cfg.QuietHours.GetMode() {
+		case config.QuietHoursSuppress, config.QuietHoursQueue:
+		default:
+			return fmt.Errorf("notifier.quiet_hours.mode must be %q or %q (got %q)", config.QuietHoursSuppress, config.QuietHoursQueue, cfg.QuietHours.Mode)
+		}
+	}
+
+	if cfg.MaxInFlight < 0 {
+		return fmt.Errorf("notifier.max_in_flight must not be negative (got %d)", cfg.MaxInFlight)
+	}
+	if err := validateWebhookAuth(cfg.Auth); err != nil {
+		return err
+	}
+	if cfg.RateLimit.MaxPerMinute < 0 {
+		return fmt.Errorf("notifier.rate_limit.max_per_minute must not be negative (got %d)", cfg.RateLimit.MaxPerMinute)
+	}
+	switch cfg.RateLimit.GetMode() {
+	case config.RateLimitDrop, config.RateLimitDelay:
+	default:
+		return fmt.Errorf("notifier.rate_limit.mode must be %q or %q (got %q)", config.RateLimitDrop, config.RateLimitDelay, cfg.RateLimit.Mode)
+	}
+	if !cfg.IsValidFormat() {
+		return fmt.Errorf("notifier.format must be one of text, markdown or html (got %q)", cfg.Format)
+	}
+	return nil
+}
+
+// validateWebhookAuth checks the Apprise credentials. Header values are secrets, so
+// they are never included in the errors.
+func validateWebhookAuth(auth config.WebhookAuthConfig) error {
+	if auth.Username == "" && auth.Password != "" {
+		return fmt.Errorf("notifier.auth.username is required when notifier.auth.password is set")
+	}
+	for _, name := range slices.Sorted(maps.Keys(auth.Headers)) {
+		if name == "" || strings.ContainsAny(name, " \t\r\n:") {
+			return fmt.Errorf("notifier.auth.headers: invalid header name %q", name)
+		}
+		if strings.ContainsAny(auth.Headers[name], "\r\n") {
+			return fmt.Errorf("notifier.auth.headers.%s must not contain line breaks", name)
+		}
+		if auth.Username != "" && strings.EqualFold(name, "Authorization") {
+			return fmt.Errorf("notifier.auth.headers.%s conflicts with notifier.auth.username (Basic Auth)", name)
+		}
+	}
+	return nil
 }
 
 // validateConfig checks that all required configuration fields are properly set.
@@ -132,12 +384,28 @@ func initConfig() {
 //   - Telnyx fields are validated only when Tasks.Telnyx.APIURL is set.
 //   - Each GitHub repository must include both Owner and Repo when any repositories are configured.
 func validateConfig(cfg *config.Config) error {
-	// Validate notifier configuration
-	if cfg.Notifier.AppriseAPIURL == "" {
-		return fmt.Errorf("notifier.apprise_api_url is required but not set")
+	if err := validateNotifier(cfg.Notifier); err != nil {
+		return err
 	}
-	if len(cfg.Notifier.GetServiceURLs()) == 0 {
-		return fmt.Errorf("notifier.apprise_service_url is required but not set")
+	for _, name := range slices.Sorted(maps.Keys(cfg.Notifiers)) {
+		if err := validateNotifier(cfg.Notifiers[name]); err != nil {
+			return fmt.Errorf("notifiers.%s: %v", name, err)
+		}
+	}
+
+	// Tasks may only select defined notifiers
+	type selection struct{ key, name string }
+	selections := []selection{
+		{"tasks.telnyx.notifier", cfg.Tasks.Telnyx.Notifier},
+		{"tasks.github.notifier", cfg.Tasks.GitHub.Notifier},
+	}
+	for i, check := range cfg.Tasks.HTTPChecks {
+		selections = append(selections, selection{fmt.Sprintf("tasks.http_checks[%d].notifier", i), check.Notifier})
+	}
+	for _, s := range selections {
+		if _, err := cfg.GetNotifier(s.name); err != nil {
+			return fmt.Errorf("%s: %v", s.key, err)
+		}
 	}
 
 	// Validate scheduler configuration
@@ -149,6 +417,31 @@ func validateConfig(cfg *config.Config) error {
 		if cfg.Tasks.Telnyx.APIKey == "" {
 			return fmt.Errorf("tasks.telnyx.api_key is required when api_url is set")
 		}
+		if _, _, err := cfg.Tasks.Telnyx.GetMinBalanceChange(); err != nil {
+			return fmt.Errorf("tasks.telnyx.min_balance_change %v", err)
+		}
+		if cfg.Tasks.Telnyx.NotifyOnAPIError < 0 {
+			return fmt.Errorf("tasks.telnyx.notify_on_api_error must not be negative (got %d)", cfg.Tasks.Telnyx.NotifyOnAPIError)
+		}
+		if cfg.Tasks.Telnyx.TotalThreshold < 0 {
+			return fmt.Errorf("tasks.telnyx.total_threshold must not be negative (got %g)", cfg.Tasks.Telnyx.TotalThreshold)
+		}
+		if len(cfg.Tasks.Telnyx.Accounts) > 0 && cfg.Tasks.Telnyx.TotalThreshold == 0 {
+			return fmt.Errorf("tasks.telnyx.total_threshold is required when accounts are set")
+		}
+		names := map[string]bool{strings.ToLower(tasks.TelnyxDefaultAccount): true}
+		for i, account := range cfg.Tasks.Telnyx.Accounts {
+			if account.Name == "" {
+				return fmt.Errorf("tasks.telnyx.accounts[%d].name is required", i)
+			}
+			if names[strings.ToLower(account.Name)] {
+				return fmt.Errorf("tasks.telnyx.accounts[%d]: duplicate account name %q", i, account.Name)
+			}
+			names[strings.ToLower(account.Name)] = true
+			if account.APIKey == "" {
+				return fmt.Errorf("tasks.telnyx.accounts[%d].api_key is required", i)
+			}
+		}
 	}
 
 	// Validate GitHub configuration if repositories are configured
@@ -157,44 +450,225 @@ func validateConfig(cfg *config.Config) error {
 			if repo.Owner == "" {
 				return fmt.Errorf("tasks.github.repositories[%d].owner is required", i)
 			}
-			if repo.Repo == "" {
-				return fmt.Errorf("tasks.github.repositories[%d].repo is required", i)
+			if strings.Contains(repo.Repo, "*") && !repo.IsOrgWide() {
+				return fmt.Errorf("tasks.github.repositories[%d].repo must be a repository name, or \"*\" (or empty) for all repositories of the organization", i)
 			}
+			if repo.StaleMetric != "" && repo.GetStaleMetric() != strings.ToLower(strings.TrimSpace(repo.StaleMetric)) {
+				return fmt.Errorf("tasks.github.repositories[%d].stale_metric must be %q or %q", i, config.StaleMetricUpdated, config.StaleMetricCreated)
+			}
+			if repo.FilterMode != "" && repo.GetFilterMode() != strings.ToLower(strings.TrimSpace(repo.FilterMode)) {
+				return fmt.Errorf("tasks.github.repositories[%d].filter_mode must be %q or %q", i, config.FilterModeAnd, config.FilterModeOr)
+			}
+			if repo.BusinessHours.IsEnabled() {
+				if _, err := repo.GetBusinessHours(); err != nil {
+					return fmt.Errorf("tasks.github.repositories[%d].%v", i, err)
+				}
+				if repo.BusinessHours.Mode != "" && repo.BusinessHours.GetMode() != strings.ToLower(strings.TrimSpace(repo.BusinessHours.Mode)) {
+					return fmt.Errorf("tasks.github.repositories[%d].business_hours.mode must be %q or %q (got %q)",
+						i, config.BusinessHoursDrop, config.BusinessHoursQueue, repo.BusinessHours.Mode)
+				}
+			} else if strings.TrimSpace(repo.Timezone) != "" {
+				// The timezone is only used for business hours, but a typo should still be caught
+				if _, err := time.LoadLocation(strings.TrimSpace(repo.Timezone)); err != nil {
+					return fmt.Errorf("tasks.github.repositories[%d].timezone %v", i, err)
+				}
+			}
+		}
+	}
+
+	for i, workflow := range cfg.Tasks.GitHub.Workflows {
+		if workflow.Owner == "" || workflow.Repo == "" {
+			return fmt.Errorf("tasks.github.workflows[%d] requires owner and repo", i)
+		}
+	}
+
+	githubCfg := cfg.Tasks.GitHub
+	if _, err := githubCfg.GetRepositories(); err != nil {
+		return fmt.Errorf("tasks.github.%v", err)
+	}
+	if githubCfg.NotifyMode != "" && githubCfg.GetNotifyMode() != strings.ToLower(strings.TrimSpace(githubCfg.NotifyMode)) {
+		return fmt.Errorf("tasks.github.notify_mode must be %q or %q (got %q)", config.NotifyModeIndividual, config.NotifyModeDigest, githubCfg.NotifyMode)
+	}
+	if githubCfg.MergeConflicts != "" && githubCfg.GetMergeConflicts() != strings.ToLower(strings.TrimSpace(githubCfg.MergeConflicts)) {
+		return fmt.Errorf("tasks.github.merge_conflicts must be %q, %q or %q (got %q)",
+			config.MergeConflictsNotify, config.MergeConflictsLabel, config.MergeConflictsSkip, githubCfg.MergeConflicts)
+	}
+	if githubCfg.LastUpdatedFormat != "" && githubCfg.GetLastUpdatedFormat() != strings.ToLower(strings.TrimSpace(githubCfg.LastUpdatedFormat)) {
+		return fmt.Errorf("tasks.github.last_updated_format must be %q, %q or %q (got %q)",
+			config.LastUpdatedAbsolute, config.LastUpdatedRelative, config.LastUpdatedBoth, githubCfg.LastUpdatedFormat)
+	}
+	if githubCfg.API != "" && githubCfg.GetAPI() != strings.ToLower(strings.TrimSpace(githubCfg.API)) {
+		return fmt.Errorf("tasks.github.api must be %q or %q (got %q)", config.GitHubAPIREST, config.GitHubAPIGraphQL, githubCfg.API)
+	}
+	// GitHub's GraphQL API doesn't accept unauthenticated requests
+	if githubCfg.GetAPI() == config.GitHubAPIGraphQL && githubCfg.Token == "" && !githubCfg.App.IsConfigured() {
+		return fmt.Errorf("tasks.github.api %q requires tasks.github.token or tasks.github.app", config.GitHubAPIGraphQL)
+	}
+	// "@me" is resolved via the API, which needs to know who "me" is
+	if strings.EqualFold(strings.TrimSpace(githubCfg.OnlyRequestedFor), config.RequestedForMe) && githubCfg.Token == "" && !githubCfg.App.IsConfigured() {
+		return fmt.Errorf("tasks.github.only_requested_for %q requires tasks.github.token or tasks.github.app", config.RequestedForMe)
+	}
+	// An invalid stale_duration would silently fall back to stale_days, so reject it up front
+	if problem := checkDuration("tasks.github.stale_duration", githubCfg.StaleDuration); problem != "" {
+		return errors.New(problem)
+	}
+
+	// Notification templates must parse and only reference known fields
+	if _, err := tasks.ParsePRTemplates(cfg.Tasks.GitHub); err != nil {
+		return err
+	}
+
+	// Validate GitHub App authentication if any app setting is present
+	if app := cfg.Tasks.GitHub.App; app.IsConfigured() {
+		if app.AppID == 0 || app.InstallationID == 0 || app.PrivateKeyPath == "" {
+			return fmt.Errorf("tasks.github.app requires app_id, installation_id and private_key_path")
+		}
+		if _, err := api.LoadPrivateKey(app.PrivateKeyPath); err != nil {
+			return fmt.Errorf("tasks.github.app.private_key_path: %v", err)
 		}
 	}
 
+	// Validate the outbound proxy
+	if _, err := proxy.Func(cfg.HTTP.ProxyURL, cfg.HTTP.NoProxy); err != nil {
+		return fmt.Errorf("http.proxy_url: %v", err)
+	}
+
+	if cfg.HTTP.APIRequestsPerHour < 0 {
+		return fmt.Errorf("http.api_requests_per_hour must not be negative (got %d)", cfg.HTTP.APIRequestsPerHour)
+	}
+
+	// Validate the CA certificate
+	if _, err := tlsconfig.Load(cfg.TLS.CACertPath, cfg.TLS.InsecureSkipVerify); err != nil {
+		return fmt.Errorf("tls.ca_cert_path: %v", err)
+	}
+
+	// Validate HTTP checks; names must be unique since they identify the task
+	checkNames := make(map[string]bool)
+	for i, check := range cfg.Tasks.HTTPChecks {
+		if check.URL == "" {
+			return fmt.Errorf("tasks.http_checks[%d].url is required", i)
+		}
+		if checkNames[check.GetName()] {
+			return fmt.Errorf("tasks.http_checks[%d]: duplicate check name %q", i, check.GetName())
+		}
+		checkNames[check.GetName()] = true
+	}
+
 	return nil
 }
 
-// runApp is the main application logic that runs after CLI initialization.
-// It performs the following steps:
-//  1. Creates a scheduler to manage periodic tasks
-//  2. Initializes the webhook notifier (Apprise) for sending alerts
-//  3. Sets up the Telnyx balance check task (if configured)
-//  4. Sets up the GitHub PR review check task (if repositories are configured)
-//  5. Starts the scheduler and keeps the application running indefinitely
-//
-// runApp initializes the scheduler and notifier, registers configured tasks (Telnyx balance checks and GitHub PR review checks), starts periodic execution, and waits for a termination signal to perform a graceful shutdown.
-// It prints runtime status to stdout and exits with status 1 if no tasks are configured.
-func runApp() {
+// buildScheduler creates a scheduler and registers every task enabled in cfg.
+// The returned taskManager can later reconcile the scheduler with a reloaded config.
+// The returned scheduler has not been started; callers should check HasTasks.
+func buildScheduler(cfg config.Config) (*scheduler.Scheduler, *taskManager) {
 	// Initialize the scheduler that will run our tasks periodically
 	sched := scheduler.NewScheduler()
+	manager := newTaskManager(sched)
+	manager.apply(cfg)
+	return sched, manager
+}
+
+// failureAlerts builds the scheduler's failing/recovered task notifications from cfg.
+// They are sent through the default notifier from notifiers, sharing its dedup window,
+// rate limit and quiet hours queue with the tasks' own alerts.
+// They are disabled when neither threshold is set.
+func failureAlerts(cfg config.Config, notifiers *taskNotifiers) scheduler.FailureAlerts {
+	alerts := scheduler.FailureAlerts{
+		AlertAfter:    max(cfg.Scheduler.AlertAfterFailures, 0),
+		RecoveryAfter: max(cfg.Scheduler.RecoveryAfterFailures, 0),
+	}
+	if alerts.AlertAfter == 0 && alerts.RecoveryAfter == 0 {
+		return alerts
+	}
+	notif, _, err := notifiers.get("")
+	if err != nil {
+		// validateConfig rejects such configs before we get here
+		log.Error().Err(err).Msg("Invalid notifier configuration, task failure alerts disabled")
+		return scheduler.FailureAlerts{}
+	}
+	alerts.Notifier = notif
+	return alerts
+}
 
-	log.Info().Str("config_file", viper.ConfigFileUsed()).Msg("Configuration loaded")
+// plannedTask is a task built from configuration, ready to be scheduled.
+type plannedTask struct {
+	// task is the task instance to schedule
+	task scheduler.Task
+
+	// interval is how often the task should run
+	interval time.Duration
+
+	// settings captures all configuration the task was built from, except its interval.
+	// Config reloads compare settings to decide whether a task must be recreated.
+	settings interface{}
+}
+
+// taskNotifiers builds the notifiers tasks send through on demand, once per notifier name,
+// so tasks selecting the same notifier share its dedup window and quiet hours queue.
+type taskNotifiers struct {
+	cfg   config.Config
+	built map[string]notifier.Notifier
+}
+
+// newTaskNotifiers creates a taskNotifiers for the notifiers defined in cfg.
+func newTaskNotifiers(cfg config.Config) *taskNotifiers {
+	return &taskNotifiers{cfg: cfg, built: make(map[string]notifier.Notifier)}
+}
+
+// get returns the notifier a task selected by name (empty for the default notifier)
+// along with its configuration.
+func (n *taskNotifiers) get(name string) (notifier.Notifier, config.NotifierConfig, error) {
+	notifierCfg, err := n.cfg.GetNotifier(name)
+	if err != nil {
+		return nil, notifierCfg, err
+	}
+	key := strings.ToLower(name)
+	if notif, ok := n.built[key]; ok {
+		return notif, notifierCfg, nil
+	}
+	notif, err := notifier.NewFromConfig(notifierCfg)
+	if err != nil {
+		return nil, notifierCfg, err
+	}
+	n.built[key] = notif
+	return notif, notifierCfg, nil
+}
+
+// planTasks builds every task enabled in cfg, keyed by a stable task key ("telnyx", "github").
+// It performs the following steps:
+//  1. Sets up the Telnyx balance check task (if configured)
+//  2. Sets up the GitHub PR review check task (if repositories are configured)
+//  3. Sets up one HTTP check task per configured endpoint
+//
+// Each task sends alerts through the notifier (Apprise, Slack, Discord or Telegram) it
+// selects with its notifier setting, or the default notifier, built by notifiers.
+// A task whose notifier can't be built is skipped.
+func planTasks(cfg config.Config, notifiers *taskNotifiers) map[string]plannedTask {
+	planned := make(map[string]plannedTask)
 
 	// Get global default interval from scheduler config
-	globalInterval := appConfig.Scheduler.GetInterval()
+	globalInterval := cfg.Scheduler.GetInterval()
 	log.Info().Dur("global_interval", globalInterval).Msg("Global scheduler interval set")
 
-	// Initialize the notifier - this handles sending alerts via Apprise
-	// Apprise supports multiple notification services (Telegram, Discord, email, etc.)
-	notif := notifier.NewWebhookNotifier(appConfig.Notifier.AppriseAPIURL, appConfig.Notifier.GetServiceURLs())
+	// Persist notification cooldowns across restarts if a state file is configured
+	var store state.StateStore
+	if cfg.State.Path != "" {
+		store = state.NewFileStore(cfg.State.Path)
+		log.Info().Str("path", cfg.State.Path).Msg("Persisting notification state")
+	}
 
 	// Register the Telnyx balance check task (if configured)
 	// This task periodically checks your Telnyx account balance and sends an alert
 	// if it falls below the configured threshold
-	telnyxCfg := appConfig.Tasks.Telnyx
-	if telnyxCfg.APIURL != "" && telnyxCfg.APIKey != "" {
+	telnyxCfg := cfg.Tasks.Telnyx
+	notif, notifierCfg, err := notifiers.get(telnyxCfg.Notifier)
+	switch {
+	case telnyxCfg.APIURL == "" || telnyxCfg.APIKey == "":
+		log.Info().Msg("Telnyx monitoring disabled (api_url or api_key not configured)")
+	case err != nil:
+		// validateConfig rejects such configs before we get here
+		log.Error().Err(err).Msg("Invalid notifier configuration, Telnyx monitoring disabled")
+	default:
 		telnyxInterval := telnyxCfg.GetInterval(globalInterval)
 		log.Info().
 			Str("api_url", telnyxCfg.APIURL).
@@ -209,47 +683,261 @@ func runApp() {
 			telnyxCfg.GetNotificationCooldown(),
 			notif,
 		)
-		sched.ScheduleTask(task, telnyxInterval)
-	} else {
-		log.Info().Msg("Telnyx monitoring disabled (api_url or api_key not configured)")
+		task.MinBalanceChange, task.MinBalanceChangeIsPercent, _ = telnyxCfg.GetMinBalanceChange()
+		task.MinRunway = telnyxCfg.GetMinRunway()
+		task.NotifyOnAPIError = telnyxCfg.NotifyOnAPIError
+		task.Timeout = telnyxCfg.GetTimeout()
+		task.Locale = telnyxCfg.Locale
+		task.Tags = telnyxCfg.Tags
+		task.LoadState(store)
+
+		settings := telnyxCfg
+		settings.Interval = ""
+		planned["telnyx"] = plannedTask{
+			task:     task,
+			interval: telnyxInterval,
+			settings: []interface{}{settings, notifierCfg, cfg.State},
+		}
+
+		// The total balance across accounts is checked by a task of its own
+		if telnyxCfg.TotalThreshold > 0 {
+			accounts := []tasks.TelnyxAccount{{Name: tasks.TelnyxDefaultAccount, Client: api.NewTelnyxAPI(telnyxCfg.APIURL, telnyxCfg.APIKey)}}
+			for _, account := range telnyxCfg.Accounts {
+				accounts = append(accounts, tasks.TelnyxAccount{
+					Name:   account.Name,
+					Client: api.NewTelnyxAPI(account.GetAPIURL(telnyxCfg.APIURL), account.APIKey),
+				})
+			}
+			log.Info().
+				Int("account_count", len(accounts)).
+				Float64("total_threshold", telnyxCfg.TotalThreshold).
+				Msg("Telnyx total balance monitoring enabled")
+
+			totalTask := tasks.NewTelnyxTotalBalanceCheckTask(accounts, telnyxCfg.TotalThreshold, telnyxCfg.GetNotificationCooldown(), notif)
+			totalTask.Timeout = telnyxCfg.GetTimeout()
+			totalTask.Locale = telnyxCfg.Locale
+			totalTask.Tags = telnyxCfg.Tags
+			totalTask.LoadState(store)
+			planned["telnyx_total"] = plannedTask{
+				task:     totalTask,
+				interval: telnyxInterval,
+				settings: []interface{}{settings, notifierCfg, cfg.State},
+			}
+		}
 	}
 
 	// Register and schedule GitHub PR review check task if repositories are configured
 	// This task monitors GitHub PRs and alerts when they've been pending review for too long
-	githubCfg := appConfig.Tasks.GitHub
-	if len(githubCfg.Repositories) > 0 {
+	githubCfg := cfg.Tasks.GitHub
+	notif, notifierCfg, err = notifiers.get(githubCfg.Notifier)
+	switch {
+	case len(githubCfg.Repositories) == 0:
+		log.Info().Msg("GitHub monitoring disabled (no repositories configured)")
+	case err != nil:
+		// validateConfig rejects such configs before we get here
+		log.Error().Err(err).Msg("Invalid notifier configuration, GitHub monitoring disabled")
+	default:
+		format := notifierCfg.GetFormat()
 		githubInterval := githubCfg.GetInterval(globalInterval)
 		log.Info().
 			Int("repository_count", len(githubCfg.Repositories)).
-			Int("stale_threshold_days", githubCfg.GetStaleDays()).
+			Dur("stale_threshold", githubCfg.GetStaleThreshold()).
 			Dur("interval", githubInterval).
 			Msg("GitHub monitoring enabled")
 
-		prTask := tasks.NewPRReviewCheckTask(githubCfg, notif)
-		sched.ScheduleTask(prTask, githubInterval)
-	} else {
-		log.Info().Msg("GitHub monitoring disabled (no repositories configured)")
+		prTask := tasks.NewPRReviewCheckTask(githubCfg, notif, format)
+		prTask.LoadState(store)
+
+		settings := githubCfg
+		settings.Interval = ""
+		planned["github"] = plannedTask{
+			task:     prTask,
+			interval: githubInterval,
+			settings: []interface{}{settings, notifierCfg, cfg.State},
+		}
+
+		// Stale issues are monitored alongside PRs when opted in
+		if githubCfg.MonitorIssues {
+			log.Info().Msg("GitHub issue monitoring enabled")
+			issueTask := tasks.NewIssueReviewCheckTask(githubCfg, notif, format)
+			issueTask.LoadState(store)
+			planned["github_issues"] = plannedTask{
+				task:     issueTask,
+				interval: githubInterval,
+				settings: []interface{}{settings, notifierCfg, cfg.State},
+			}
+		}
 	}
 
-	// Check if at least one task was scheduled
-	if !sched.HasTasks() {
-		log.Fatal().Msg("No tasks configured! Please configure at least one of: Telnyx monitoring or GitHub monitoring")
+	// Failed GitHub Actions runs are watched independently of the PR check
+	if len(githubCfg.Workflows) > 0 {
+		if notif, notifierCfg, err := notifiers.get(githubCfg.Notifier); err != nil {
+			// validateConfig rejects such configs before we get here
+			log.Error().Err(err).Msg("Invalid notifier configuration, GitHub workflow monitoring disabled")
+		} else {
+			githubInterval := githubCfg.GetInterval(globalInterval)
+			log.Info().
+				Int("workflow_count", len(githubCfg.Workflows)).
+				Dur("interval", githubInterval).
+				Msg("GitHub workflow monitoring enabled")
+
+			workflowTask := tasks.NewWorkflowCheckTask(githubCfg, notif, notifierCfg.GetFormat())
+			workflowTask.LoadState(store)
+
+			settings := githubCfg
+			settings.Interval = ""
+			planned["github_workflows"] = plannedTask{
+				task:     workflowTask,
+				interval: githubInterval,
+				settings: []interface{}{settings, notifierCfg, cfg.State},
+			}
+		}
+	}
+
+	// Register one HTTP health-check task per configured endpoint
+	for _, checkCfg := range cfg.Tasks.HTTPChecks {
+		notif, notifierCfg, err := notifiers.get(checkCfg.Notifier)
+		if err != nil {
+			// validateConfig rejects such configs before we get here
+			log.Error().Err(err).Str("check", checkCfg.GetName()).Msg("Invalid notifier configuration, HTTP check disabled")
+			continue
+		}
+
+		checkInterval := checkCfg.GetInterval(globalInterval)
+		log.Info().
+			Str("check", checkCfg.GetName()).
+			Str("url", checkCfg.URL).
+			Dur("interval", checkInterval).
+			Msg("HTTP check enabled")
+
+		settings := checkCfg
+		settings.Interval = ""
+		checkTask := tasks.NewHTTPCheckTask(checkCfg, notif)
+		checkTask.LoadState(store)
+		planned["http_check:"+checkCfg.GetName()] = plannedTask{
+			task:     checkTask,
+			interval: checkInterval,
+			settings: []interface{}{settings, notifierCfg, cfg.State},
+		}
+	}
+
+	return planned
+}
+
+// configureProxy routes API and notification requests through the configured proxy.
+// The proxy URL has already been checked by validateConfig.
+func configureProxy(cfg config.HTTPConfig) {
+	proxyFunc, err := proxy.Func(cfg.ProxyURL, cfg.NoProxy)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid proxy configuration")
+	}
+	api.SetProxy(proxyFunc)
+	notifier.SetProxy(proxyFunc)
+	if cfg.ProxyURL != "" {
+		log.Info().Str("no_proxy", cfg.NoProxy).Msg("Routing outbound requests through the configured proxy")
+	}
+}
+
+// configureTLS applies the configured CA certificate and verification settings to API
+// and notification requests. The CA file has already been checked by validateConfig.
+func configureTLS(cfg config.TLSConfig) {
+	tlsCfg, err := tlsconfig.Load(cfg.CACertPath, cfg.InsecureSkipVerify)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid TLS configuration")
+	}
+	if cfg.InsecureSkipVerify {
+		log.Warn().Msg("TLS CERTIFICATE VERIFICATION IS DISABLED (tls.insecure_skip_verify). " +
+			"Connections can be intercepted and credentials stolen; use tls.ca_cert_path instead.")
+	}
+	api.SetTLSConfig(tlsCfg)
+	notifier.SetTLSConfig(tlsCfg)
+}
+
+// newLogger builds a zerolog logger writing to w.
+// format is "console" (human-friendly, colored output) or "json" (one JSON object per line).
+// level is any zerolog level name (e.g., "debug", "info", "warn").
+func newLogger(w io.Writer, format, level string) (zerolog.Logger, error) {
+	lvl, err := zerolog.ParseLevel(level)
+	if err != nil {
+		return zerolog.Logger{}, fmt.Errorf("invalid log level %q: %v", level, err)
+	}
+
+	switch format {
+	case "console":
+		return zerolog.New(zerolog.ConsoleWriter{Out: w}).Level(lvl).With().Timestamp().Logger(), nil
+	case "json":
+		return zerolog.New(w).Level(lvl).With().Timestamp().Logger(), nil
+	default:
+		return zerolog.Logger{}, fmt.Errorf("invalid log format %q (must be console or json)", format)
+	}
+}
+
+// backgroundServer is an auxiliary HTTP server (metrics, health) that runs alongside the scheduler.
+type backgroundServer interface {
+	Start()
+	Shutdown(ctx context.Context) error
+}
+
+// startServer starts srv and returns a function that shuts it down gracefully.
+func startServer(srv backgroundServer) func() {
+	srv.Start()
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(ctx)
+	}
+}
+
+// runApp executes the tasks registered on sched.
+//
+// In one-shot mode (once == true) every task runs exactly one time, synchronously,
+// and runApp returns the aggregated task errors without starting the scheduler.
+// A value received on stop cancels the run.
+// This is intended for external schedulers such as cron or Kubernetes CronJobs.
+//
+// Otherwise the scheduler is started and runApp blocks until a value is received
+// on stop (typically SIGINT/SIGTERM), then stops the scheduler gracefully and returns nil.
+// In-flight task runs get up to shutdownTimeout to finish; after that runApp logs a
+// warning and returns anyway, so a hung HTTP call can't block the process from exiting.
+func runApp(sched *scheduler.Scheduler, once bool, stop <-chan os.Signal, shutdownTimeout time.Duration) error {
+	if once {
+		// A signal cancels the in-flight task and skips the rest
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			select {
+			case <-stop:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		log.Info().Msg("Running all tasks once...")
+		if err := sched.RunOnce(ctx); err != nil {
+			return err
+		}
+		log.Info().Msg("All tasks completed.")
+		return nil
 	}
 
 	// Start the scheduler - this begins executing all registered tasks
 	log.Info().Msg("Starting scheduler...")
 	sched.Start()
 
-	// Wait for interrupt signal for graceful shutdown
-	// This allows the program to be stopped cleanly with Ctrl+C (SIGINT) or kill (SIGTERM)
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-
 	log.Info().Msg("Watchdog is running. Press Ctrl+C to stop.")
-	<-sigChan
+	<-stop
 
 	// Graceful shutdown
-	log.Info().Msg("Shutting down gracefully...")
-	sched.Stop()
+	log.Info().Dur("timeout", shutdownTimeout).Msg("Shutting down gracefully...")
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := sched.Shutdown(ctx); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Warn().Dur("timeout", shutdownTimeout).Msg("Tasks still running after shutdown timeout, exiting anyway")
+			return nil
+		}
+		return fmt.Errorf("shutdown: %w", err)
+	}
 	log.Info().Msg("Shutdown complete.")
+	return nil
 }
@@ -0,0 +1,749 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"watchdog/internal/config"
+	"watchdog/internal/scheduler"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingTask is a scheduler.Task that records how many times it ran
+type countingTask struct {
+	mu       sync.Mutex
+	runCount int
+	err      error
+}
+
+func (c *countingTask) Run(context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.runCount++
+	return c.err
+}
+
+func (c *countingTask) Name() string {
+	return "counting"
+}
+
+func (c *countingTask) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.runCount
+}
+
+// blockingTask is a scheduler.Task whose run hangs until release is closed
+type blockingTask struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b blockingTask) Run(context.Context) error {
+	close(b.started)
+	<-b.release
+	return nil
+}
+
+func (b blockingTask) Name() string {
+	return "blocking"
+}
+
+func TestRunApp_Once_RunsEachTaskOnce(t *testing.T) {
+	sched := scheduler.NewScheduler()
+	task1 := &countingTask{}
+	task2 := &countingTask{}
+	sched.ScheduleTask(task1, time.Hour)
+	sched.ScheduleTask(task2, time.Hour)
+
+	done := make(chan error, 1)
+	go func() {
+		// A nil stop channel would block forever if the signal wait were reached
+		done <- runApp(sched, true, nil, time.Second)
+	}()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("runApp blocked in one-shot mode")
+	}
+
+	assert.Equal(t, 1, task1.count())
+	assert.Equal(t, 1, task2.count())
+}
+
+func TestRunApp_Once_ReturnsTaskErrors(t *testing.T) {
+	sched := scheduler.NewScheduler()
+	taskErr := errors.New("balance check failed")
+	failing := &countingTask{err: taskErr}
+	healthy := &countingTask{}
+	sched.ScheduleTask(failing, time.Hour)
+	sched.ScheduleTask(healthy, time.Hour)
+
+	err := runApp(sched, true, nil, time.Second)
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, taskErr)
+	assert.Equal(t, 1, healthy.count())
+}
+
+func TestRunApp_StopsOnSignal(t *testing.T) {
+	sched := scheduler.NewScheduler()
+	task := &countingTask{}
+	sched.ScheduleTask(task, time.Hour)
+
+	stop := make(chan os.Signal, 1)
+	stop <- os.Interrupt
+
+	err := runApp(sched, false, stop, time.Second)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, task.count())
+}
+
+// closeFailingTask is a countingTask whose final cleanup fails
+type closeFailingTask struct {
+	countingTask
+}
+
+func (c *closeFailingTask) Close() error {
+	return errors.New("state flush failed")
+}
+
+func TestRunApp_ReturnsCleanupErrors(t *testing.T) {
+	sched := scheduler.NewScheduler()
+	sched.ScheduleTask(&closeFailingTask{}, time.Hour)
+
+	stop := make(chan os.Signal, 1)
+	stop <- os.Interrupt
+
+	err := runApp(sched, false, stop, time.Second)
+
+	assert.ErrorContains(t, err, "state flush failed")
+}
+
+func TestRunApp_ShutdownTimeoutBoundsSlowTask(t *testing.T) {
+	sched := scheduler.NewScheduler()
+	release := make(chan struct{})
+	started := make(chan struct{})
+	defer close(release)
+	sched.ScheduleTask(blockingTask{started: started, release: release}, time.Hour)
+
+	stop := make(chan os.Signal, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- runApp(sched, false, stop, 100*time.Millisecond)
+	}()
+
+	<-started
+	stop <- os.Interrupt
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("runApp did not return after the shutdown timeout")
+	}
+}
+
+func TestNewLogger_JSONFormat(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger, err := newLogger(&buf, "json", "info")
+	require.NoError(t, err)
+
+	logger.Info().Str("task", "telnyx").Msg("hello")
+	logger.Debug().Msg("filtered out by level")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 1)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.Equal(t, "info", entry["level"])
+	assert.Equal(t, "hello", entry["message"])
+	assert.Equal(t, "telnyx", entry["task"])
+}
+
+func TestNewLogger_ConsoleFormat(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger, err := newLogger(&buf, "console", "debug")
+	require.NoError(t, err)
+
+	logger.Debug().Msg("hello")
+	assert.Contains(t, buf.String(), "hello")
+	assert.False(t, json.Valid(buf.Bytes()))
+}
+
+func TestNewLogger_InvalidOptions(t *testing.T) {
+	_, err := newLogger(&bytes.Buffer{}, "xml", "info")
+	assert.Error(t, err)
+
+	_, err = newLogger(&bytes.Buffer{}, "json", "loud")
+	assert.Error(t, err)
+}
+
+func TestLoadConfig_FromEnvironmentWithoutFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("NOTIFIER_APPRISE_API_URL", "https://apprise.example.com/notify")
+	t.Setenv("NOTIFIER_APPRISE_SERVICE_URL", "tgram://token/id")
+	t.Setenv("TASKS_TELNYX_API_URL", "https://api.telnyx.com/v2/balance")
+	t.Setenv("TASKS_TELNYX_API_KEY", "KEY123")
+	t.Setenv("TASKS_TELNYX_THRESHOLD", "7.5")
+	t.Setenv("SCHEDULER_INTERVAL", "10m")
+
+	cfg, err := loadConfig(viper.New(), "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "https://apprise.example.com/notify", cfg.Notifier.AppriseAPIURL)
+	assert.Equal(t, []string{"tgram://token/id"}, cfg.Notifier.GetServiceURLs())
+	assert.Equal(t, "KEY123", cfg.Tasks.Telnyx.APIKey)
+	assert.Equal(t, 7.5, cfg.Tasks.Telnyx.Threshold)
+	assert.Equal(t, 10*time.Minute, cfg.Scheduler.GetInterval())
+}
+
+func TestLoadConfig_NoFileAndMissingEnvironment(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	_, err := loadConfig(viper.New(), "")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "notifier.apprise_api_url is required")
+}
+
+func TestLoadConfig_EnvironmentOverridesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+notifier:
+  apprise_api_url: "https://file.example.com/notify"
+  apprise_service_url: "tgram://token/id"
+tasks:
+  telnyx:
+    api_url: "https://api.telnyx.com/v2/balance"
+    api_key: "FILEKEY"
+`), 0o600))
+	t.Setenv("TASKS_TELNYX_API_KEY", "ENVKEY")
+
+	cfg, err := loadConfig(viper.New(), path)
+
+	require.NoError(t, err)
+	assert.Equal(t, "https://file.example.com/notify", cfg.Notifier.AppriseAPIURL)
+	assert.Equal(t, "ENVKEY", cfg.Tasks.Telnyx.APIKey)
+}
+
+func TestLoadConfig_ExplicitFileMissing(t *testing.T) {
+	t.Setenv("NOTIFIER_APPRISE_API_URL", "https://apprise.example.com/notify")
+	t.Setenv("NOTIFIER_APPRISE_SERVICE_URL", "tgram://token/id")
+
+	_, err := loadConfig(viper.New(), filepath.Join(t.TempDir(), "missing.yaml"))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error reading config file")
+}
+
+func TestValidateConfig_NotifierBackend(t *testing.T) {
+	tests := []struct {
+		name     string
+		notifier config.NotifierConfig
+		wantErr  string
+	}{
+		{
+			name:     "apprise by default",
+			notifier: config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com/notify", AppriseServiceURL: "tgram://t/c"},
+		},
+		{
+			name:     "apprise missing url",
+			notifier: config.NotifierConfig{AppriseServiceURL: "tgram://t/c"},
+			wantErr:  "notifier.apprise_api_url is required",
+		},
+		{
+			name:     "slack",
+			notifier: config.NotifierConfig{Backend: "slack", SlackWebhookURL: "https://hooks.slack.com/services/T/B/X"},
+		},
+		{
+			name:     "slack missing webhook",
+			notifier: config.NotifierConfig{Backend: "slack"},
+			wantErr:  "notifier.slack_webhook_url is required",
+		},
+		{
+			name:     "discord",
+			notifier: config.NotifierConfig{Backend: "discord", DiscordWebhookURL: "https://discord.com/api/webhooks/1/x"},
+		},
+		{
+			name:     "discord missing webhook",
+			notifier: config.NotifierConfig{Backend: "discord"},
+			wantErr:  "notifier.discord_webhook_url is required",
+		},
+		{
+			name:     "telegram",
+			notifier: config.NotifierConfig{Backend: "telegram", TelegramBotToken: "123:ABC", TelegramChatID: "42", TelegramParseMode: "HTML"},
+		},
+		{
+			name:     "telegram missing chat id",
+			notifier: config.NotifierConfig{Backend: "telegram", TelegramBotToken: "123:ABC"},
+			wantErr:  "notifier.telegram_chat_id are required",
+		},
+		{
+			name:     "telegram invalid parse mode",
+			notifier: config.NotifierConfig{Backend: "telegram", TelegramBotToken: "123:ABC", TelegramChatID: "42", TelegramParseMode: "bbcode"},
+			wantErr:  "notifier.telegram_parse_mode must be one of",
+		},
+		{
+			name:     "multiple backends",
+			notifier: config.NotifierConfig{Backend: "slack, apprise", SlackWebhookURL: "https://hooks.slack.com/x", AppriseAPIURL: "https://apprise.example.com/notify", AppriseServiceURL: "mailto://u:p@example.com"},
+		},
+		{
+			name:     "multiple backends with one misconfigured",
+			notifier: config.NotifierConfig{Backend: "apprise,slack", AppriseAPIURL: "https://apprise.example.com/notify", AppriseServiceURL: "mailto://u:p@example.com"},
+			wantErr:  "notifier.slack_webhook_url is required",
+		},
+		{
+			name:     "unknown backend",
+			notifier: config.NotifierConfig{Backend: "pager"},
+			wantErr:  "notifier.backend must be one of",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateConfig(&config.Config{Notifier: tt.notifier})
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateConfig_HTTPChecks(t *testing.T) {
+	base := config.Config{Notifier: config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com/notify", AppriseServiceURL: "tgram://t/c"}}
+
+	valid := base
+	valid.Tasks.HTTPChecks = []config.HTTPCheckConfig{{Name: "api", URL: "https://api.example.com"}, {URL: "https://www.example.com"}}
+	assert.NoError(t, validateConfig(&valid))
+
+	missingURL := base
+	missingURL.Tasks.HTTPChecks = []config.HTTPCheckConfig{{Name: "api"}}
+	assert.ErrorContains(t, validateConfig(&missingURL), "tasks.http_checks[0].url is required")
+
+	duplicate := base
+	duplicate.Tasks.HTTPChecks = []config.HTTPCheckConfig{{Name: "api", URL: "https://a.example.com"}, {Name: "api", URL: "https://b.example.com"}}
+	assert.ErrorContains(t, validateConfig(&duplicate), `duplicate check name "api"`)
+}
+
+func TestValidateConfig_NamedNotifiers(t *testing.T) {
+	cfg := config.Config{
+		Notifier:  config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com/notify", AppriseServiceURL: "tgram://t/c"},
+		Notifiers: map[string]config.NotifierConfig{"ops": {Backend: "slack", SlackWebhookURL: "https://hooks.slack.com/services/T/B/X"}},
+	}
+	cfg.Tasks.Telnyx = config.TelnyxConfig{APIURL: "https://api.telnyx.com/v2/balance", APIKey: "KEY", Notifier: "ops"}
+	cfg.Tasks.HTTPChecks = []config.HTTPCheckConfig{{Name: "api", URL: "https://api.example.com"}}
+	assert.NoError(t, validateConfig(&cfg))
+
+	cfg.Tasks.HTTPChecks[0].Notifier = "dev"
+	assert.EqualError(t, validateConfig(&cfg), `tasks.http_checks[0].notifier: unknown notifier "dev" (define it under notifiers)`)
+
+	cfg.Tasks.HTTPChecks[0].Notifier = ""
+	cfg.Notifiers["ops"] = config.NotifierConfig{Backend: "slack"}
+	assert.ErrorContains(t, validateConfig(&cfg), "notifiers.ops: notifier.slack_webhook_url")
+}
+
+func TestValidateConfig_TelnyxMinBalanceChange(t *testing.T) {
+	cfg := config.Config{Notifier: config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com/notify", AppriseServiceURL: "tgram://t/c"}}
+	cfg.Tasks.Telnyx = config.TelnyxConfig{APIURL: "https://api.telnyx.com/v2/balance", APIKey: "KEY", MinBalanceChange: "10%"}
+	assert.NoError(t, validateConfig(&cfg))
+
+	cfg.Tasks.Telnyx.MinBalanceChange = "ten"
+	assert.ErrorContains(t, validateConfig(&cfg), "tasks.telnyx.min_balance_change must be")
+}
+
+func TestValidateConfig_TelnyxNotifyOnAPIError(t *testing.T) {
+	cfg := config.Config{Notifier: config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com/notify", AppriseServiceURL: "tgram://t/c"}}
+	cfg.Tasks.Telnyx = config.TelnyxConfig{APIURL: "https://api.telnyx.com/v2/balance", APIKey: "KEY", NotifyOnAPIError: 3}
+	assert.NoError(t, validateConfig(&cfg))
+
+	cfg.Tasks.Telnyx.NotifyOnAPIError = -1
+	assert.ErrorContains(t, validateConfig(&cfg), "tasks.telnyx.notify_on_api_error must not be negative")
+}
+
+func TestValidateConfig_TelnyxAccounts(t *testing.T) {
+	cfg := config.Config{Notifier: config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com/notify", AppriseServiceURL: "tgram://t/c"}}
+	cfg.Tasks.Telnyx = config.TelnyxConfig{
+		APIURL:         "https://api.telnyx.com/v2/balance",
+		APIKey:         "KEY",
+		TotalThreshold: 100,
+		Accounts:       []config.TelnyxAccountConfig{{Name: "eu", APIKey: "KEY_EU"}},
+	}
+	assert.NoError(t, validateConfig(&cfg))
+
+	tests := []struct {
+		name    string
+		modify  func(*config.TelnyxConfig)
+		wantErr string
+	}{
+		{name: "negative total", modify: func(c *config.TelnyxConfig) { c.TotalThreshold = -1 }, wantErr: "tasks.telnyx.total_threshold must not be negative"},
+		{name: "accounts without total", modify: func(c *config.TelnyxConfig) { c.TotalThreshold = 0 }, wantErr: "tasks.telnyx.total_threshold is required when accounts are set"},
+		{name: "missing name", modify: func(c *config.TelnyxConfig) { c.Accounts[0].Name = "" }, wantErr: "tasks.telnyx.accounts[0].name is required"},
+		{name: "reserved name", modify: func(c *config.TelnyxConfig) { c.Accounts[0].Name = "Default" }, wantErr: `duplicate account name "Default"`},
+		{name: "missing key", modify: func(c *config.TelnyxConfig) { c.Accounts[0].APIKey = "" }, wantErr: "tasks.telnyx.accounts[0].api_key is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invalid := cfg
+			invalid.Tasks.Telnyx.Accounts = append([]config.TelnyxAccountConfig(nil), cfg.Tasks.Telnyx.Accounts...)
+			tt.modify(&invalid.Tasks.Telnyx)
+			assert.ErrorContains(t, validateConfig(&invalid), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateConfig_ProxyURL(t *testing.T) {
+	cfg := config.Config{Notifier: config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com/notify", AppriseServiceURL: "tgram://t/c"}}
+
+	cfg.HTTP.ProxyURL = "http://proxy.corp:3128"
+	assert.NoError(t, validateConfig(&cfg))
+
+	cfg.HTTP.ProxyURL = "ftp://proxy.corp"
+	assert.ErrorContains(t, validateConfig(&cfg), "http.proxy_url")
+}
+
+func TestValidateConfig_APIRequestsPerHour(t *testing.T) {
+	cfg := config.Config{Notifier: config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com/notify", AppriseServiceURL: "tgram://t/c"}}
+
+	cfg.HTTP.APIRequestsPerHour = 1000
+	assert.NoError(t, validateConfig(&cfg))
+
+	cfg.HTTP.APIRequestsPerHour = -1
+	assert.ErrorContains(t, validateConfig(&cfg), "http.api_requests_per_hour must not be negative")
+}
+
+func TestValidateConfig_TLSCACertPath(t *testing.T) {
+	cfg := config.Config{Notifier: config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com/notify", AppriseServiceURL: "tgram://t/c"}}
+	cfg.TLS.CACertPath = filepath.Join(t.TempDir(), "missing.pem")
+	assert.ErrorContains(t, validateConfig(&cfg), "tls.ca_cert_path")
+
+	cfg.TLS = config.TLSConfig{InsecureSkipVerify: true}
+	assert.NoError(t, validateConfig(&cfg))
+}
+
+func TestValidateConfig_QuietHours(t *testing.T) {
+	cfg := config.Config{Notifier: config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com/notify", AppriseServiceURL: "tgram://t/c"}}
+	cfg.Notifier.QuietHours = config.QuietHoursConfig{Start: "22:00", End: "07:00", Timezone: "Europe/Berlin", Mode: "queue"}
+	assert.NoError(t, validateConfig(&cfg))
+
+	cfg.Notifier.QuietHours.End = "7am"
+	assert.ErrorContains(t, validateConfig(&cfg), "notifier.quiet_hours.end must be a time of day")
+
+	cfg.Notifier.QuietHours.End = "07:00"
+	cfg.Notifier.QuietHours.Timezone = "Mars/Olympus"
+	assert.ErrorContains(t, validateConfig(&cfg), "notifier.quiet_hours.timezone")
+
+	cfg.Notifier.QuietHours.Timezone = ""
+	cfg.Notifier.QuietHours.Mode = "defer"
+	assert.ErrorContains(t, validateConfig(&cfg), "notifier.quiet_hours.mode")
+}
+
+func TestValidateConfig_RateLimit(t *testing.T) {
+	cfg := config.Config{Notifier: config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com/notify", AppriseServiceURL: "tgram://t/c"}}
+	cfg.Notifier.RateLimit = config.RateLimitConfig{MaxPerMinute: 10, Mode: "delay"}
+	assert.NoError(t, validateConfig(&cfg))
+
+	cfg.Notifier.RateLimit.Mode = "queue"
+	assert.ErrorContains(t, validateConfig(&cfg), "notifier.rate_limit.mode")
+
+	cfg.Notifier.RateLimit = config.RateLimitConfig{MaxPerMinute: -1}
+	assert.ErrorContains(t, validateConfig(&cfg), "notifier.rate_limit.max_per_minute")
+}
+
+func TestValidateConfig_MaxInFlight(t *testing.T) {
+	cfg := config.Config{Notifier: config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com/notify", AppriseServiceURL: "tgram://t/c"}}
+	cfg.Notifier.MaxInFlight = 4
+	assert.NoError(t, validateConfig(&cfg))
+
+	cfg.Notifier.MaxInFlight = -1
+	assert.ErrorContains(t, validateConfig(&cfg), "notifier.max_in_flight must not be negative")
+}
+
+func TestValidateConfig_WebhookAuth(t *testing.T) {
+	tests := []struct {
+		name    string
+		auth    config.WebhookAuthConfig
+		wantErr string
+	}{
+		{name: "none"},
+		{name: "basic", auth: config.WebhookAuthConfig{Username: "watchdog", Password: "s3cret"}},
+		{name: "headers", auth: config.WebhookAuthConfig{Headers: map[string]string{"x-api-key": "key123", "authorization": "Bearer abc"}}},
+		{name: "password without username", auth: config.WebhookAuthConfig{Password: "s3cret"}, wantErr: "notifier.auth.username is required"},
+		{name: "invalid header name", auth: config.WebhookAuthConfig{Headers: map[string]string{"X API Key": "key123"}}, wantErr: `invalid header name "X API Key"`},
+		{name: "line break in value", auth: config.WebhookAuthConfig{Headers: map[string]string{"x-api-key": "key\r\nX-Evil: 1"}}, wantErr: "notifier.auth.headers.x-api-key must not contain line breaks"},
+		{name: "authorization with basic auth", auth: config.WebhookAuthConfig{Username: "watchdog", Headers: map[string]string{"authorization": "Bearer abc"}}, wantErr: "conflicts with notifier.auth.username"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Config{Notifier: config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com/notify", AppriseServiceURL: "tgram://t/c", Auth: tt.auth}}
+			err := validateConfig(&cfg)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+			assert.NotContains(t, err.Error(), "key123")
+		})
+	}
+}
+
+func TestValidateConfig_DuplicateRepositories(t *testing.T) {
+	cfg := config.Config{Notifier: config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com/notify", AppriseServiceURL: "tgram://t/c"}}
+	cfg.Tasks.GitHub.Repositories = []config.RepositoryConfig{
+		{Owner: "acme", Repo: "api", Authors: []string{"alice"}},
+		{Owner: "Acme", Repo: "API", Authors: []string{"Alice"}},
+	}
+	assert.NoError(t, validateConfig(&cfg))
+
+	cfg.Tasks.GitHub.Repositories[1].Authors = []string{"bob"}
+	assert.ErrorContains(t, validateConfig(&cfg), "tasks.github.repositories[1] duplicates repositories[0] (acme/api) with different filters")
+}
+
+func TestValidateConfig_OrgWideRepositories(t *testing.T) {
+	cfg := config.Config{Notifier: config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com/notify", AppriseServiceURL: "tgram://t/c"}}
+	cfg.Tasks.GitHub.Repositories = []config.RepositoryConfig{{Owner: "acme"}, {Owner: "other", Repo: "*"}}
+	assert.NoError(t, validateConfig(&cfg))
+
+	cfg.Tasks.GitHub.Repositories = []config.RepositoryConfig{{Owner: "acme", Repo: "api-*"}}
+	assert.ErrorContains(t, validateConfig(&cfg), "tasks.github.repositories[0].repo must be a repository name")
+}
+
+func TestValidateConfig_NotifyMode(t *testing.T) {
+	cfg := config.Config{Notifier: config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com/notify", AppriseServiceURL: "tgram://t/c"}}
+	cfg.Tasks.GitHub.NotifyMode = "Digest"
+	assert.NoError(t, validateConfig(&cfg))
+
+	cfg.Tasks.GitHub.NotifyMode = "batch"
+	assert.ErrorContains(t, validateConfig(&cfg), "tasks.github.notify_mode")
+}
+
+func TestValidateConfig_Workflows(t *testing.T) {
+	cfg := config.Config{Notifier: config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com/notify", AppriseServiceURL: "tgram://t/c"}}
+	cfg.Tasks.GitHub.Workflows = []config.WorkflowConfig{{Owner: "acme", Repo: "api", Branch: "develop"}}
+	assert.NoError(t, validateConfig(&cfg))
+
+	cfg.Tasks.GitHub.Workflows = append(cfg.Tasks.GitHub.Workflows, config.WorkflowConfig{Owner: "acme"})
+	assert.ErrorContains(t, validateConfig(&cfg), "tasks.github.workflows[1] requires owner and repo")
+}
+
+func TestValidateConfig_MergeConflicts(t *testing.T) {
+	cfg := config.Config{Notifier: config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com/notify", AppriseServiceURL: "tgram://t/c"}}
+	cfg.Tasks.GitHub.MergeConflicts = "skip"
+	assert.NoError(t, validateConfig(&cfg))
+
+	cfg.Tasks.GitHub.MergeConflicts = "ignore"
+	assert.ErrorContains(t, validateConfig(&cfg), "tasks.github.merge_conflicts")
+}
+
+func TestValidateConfig_LastUpdatedFormat(t *testing.T) {
+	cfg := config.Config{Notifier: config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com/notify", AppriseServiceURL: "tgram://t/c"}}
+	cfg.Tasks.GitHub.LastUpdatedFormat = "Both"
+	assert.NoError(t, validateConfig(&cfg))
+
+	cfg.Tasks.GitHub.LastUpdatedFormat = "human"
+	assert.ErrorContains(t, validateConfig(&cfg), "tasks.github.last_updated_format")
+}
+
+func TestValidateConfig_BusinessHours(t *testing.T) {
+	cfg := config.Config{Notifier: config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com/notify", AppriseServiceURL: "tgram://t/c"}}
+	cfg.Tasks.GitHub.Repositories = []config.RepositoryConfig{{
+		Owner:         "acme",
+		Repo:          "api",
+		Timezone:      "Europe/Berlin",
+		BusinessHours: config.BusinessHoursConfig{Start: "09:00", End: "17:00", Days: []string{"mon", "tue"}, Mode: "queue"},
+	}}
+	assert.NoError(t, validateConfig(&cfg))
+
+	cfg.Tasks.GitHub.Repositories[0].BusinessHours.Mode = "later"
+	assert.ErrorContains(t, validateConfig(&cfg), "tasks.github.repositories[0].business_hours.mode must be")
+
+	cfg.Tasks.GitHub.Repositories[0].BusinessHours = config.BusinessHoursConfig{Start: "09:00"}
+	assert.ErrorContains(t, validateConfig(&cfg), "tasks.github.repositories[0].business_hours.end")
+
+	// A timezone without business hours is still checked
+	cfg.Tasks.GitHub.Repositories[0].BusinessHours = config.BusinessHoursConfig{}
+	cfg.Tasks.GitHub.Repositories[0].Timezone = "Europe/Berln"
+	assert.ErrorContains(t, validateConfig(&cfg), "tasks.github.repositories[0].timezone")
+}
+
+func TestValidateConfig_GitHubAPI(t *testing.T) {
+	cfg := config.Config{Notifier: config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com/notify", AppriseServiceURL: "tgram://t/c"}}
+	cfg.Tasks.GitHub.API = "graphql"
+	assert.ErrorContains(t, validateConfig(&cfg), `tasks.github.api "graphql" requires tasks.github.token or tasks.github.app`)
+
+	cfg.Tasks.GitHub.Token = "ghp_test"
+	assert.NoError(t, validateConfig(&cfg))
+
+	cfg.Tasks.GitHub.API = "soap"
+	assert.ErrorContains(t, validateConfig(&cfg), "tasks.github.api must be")
+}
+
+func TestValidateConfig_StaleDuration(t *testing.T) {
+	cfg := config.Config{Notifier: config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com/notify", AppriseServiceURL: "tgram://t/c"}}
+	cfg.Tasks.GitHub.StaleDuration = "36h"
+	assert.NoError(t, validateConfig(&cfg))
+
+	cfg.Tasks.GitHub.StaleDuration = "2 days"
+	assert.ErrorContains(t, validateConfig(&cfg), `tasks.github.stale_duration: invalid duration "2 days"`)
+
+	cfg.Tasks.GitHub.StaleDuration = "-12h"
+	assert.ErrorContains(t, validateConfig(&cfg), "tasks.github.stale_duration: duration must be positive")
+}
+
+func TestValidateConfig_OnlyRequestedFor(t *testing.T) {
+	cfg := config.Config{Notifier: config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com/notify", AppriseServiceURL: "tgram://t/c"}}
+	cfg.Tasks.GitHub.OnlyRequestedFor = "alice"
+	assert.NoError(t, validateConfig(&cfg))
+
+	cfg.Tasks.GitHub.OnlyRequestedFor = "@me"
+	assert.ErrorContains(t, validateConfig(&cfg), `tasks.github.only_requested_for "@me" requires tasks.github.token`)
+
+	cfg.Tasks.GitHub.Token = "ghp_test"
+	assert.NoError(t, validateConfig(&cfg))
+}
+
+func TestValidateConfig_NotificationTemplates(t *testing.T) {
+	cfg := config.Config{Notifier: config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com/notify", AppriseServiceURL: "tgram://t/c"}}
+	cfg.Tasks.GitHub.SubjectTemplate = "PR #{{.Number}}: {{.Title}}"
+	assert.NoError(t, validateConfig(&cfg))
+
+	cfg.Tasks.GitHub.BodyTemplate = "{{.Reviewers}}"
+	assert.ErrorContains(t, validateConfig(&cfg), "tasks.github.body_template")
+}
+
+func TestValidateConfig_GitHubApp(t *testing.T) {
+	base := config.Config{Notifier: config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com/notify", AppriseServiceURL: "tgram://t/c"}}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPath := filepath.Join(t.TempDir(), "app.pem")
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0600))
+
+	valid := base
+	valid.Tasks.GitHub.App = config.GitHubAppConfig{AppID: 42, InstallationID: 99, PrivateKeyPath: keyPath}
+	assert.NoError(t, validateConfig(&valid))
+
+	incomplete := base
+	incomplete.Tasks.GitHub.App = config.GitHubAppConfig{AppID: 42, PrivateKeyPath: keyPath}
+	assert.ErrorContains(t, validateConfig(&incomplete), "tasks.github.app requires app_id, installation_id and private_key_path")
+
+	badKey := base
+	badKey.Tasks.GitHub.App = config.GitHubAppConfig{AppID: 42, InstallationID: 99, PrivateKeyPath: filepath.Join(t.TempDir(), "missing.pem")}
+	assert.ErrorContains(t, validateConfig(&badKey), "tasks.github.app.private_key_path")
+}
+
+func TestConfigPath(t *testing.T) {
+	t.Setenv("WATCHDOG_CONFIG", "/etc/watchdog/prod.toml")
+	assert.Equal(t, "/etc/watchdog/prod.toml", configPath(""))
+	assert.Equal(t, "flag.yaml", configPath("flag.yaml"), "--config wins over WATCHDOG_CONFIG")
+
+	t.Setenv("WATCHDOG_CONFIG", "")
+	assert.Equal(t, "", configPath(""))
+}
+
+func TestLoadConfig_SearchPathsAndFormats(t *testing.T) {
+	files := map[string]string{
+		"config.yaml": `
+notifier:
+  apprise_api_url: "https://apprise.example.com/notify"
+  apprise_service_url: "tgram://token/id"
+scheduler:
+  interval: "7m"
+`,
+		"config.json": `{
+  "notifier": {"apprise_api_url": "https://apprise.example.com/notify", "apprise_service_url": "tgram://token/id"},
+  "scheduler": {"interval": "7m"}
+}`,
+		"config.toml": `
+[notifier]
+apprise_api_url = "https://apprise.example.com/notify"
+apprise_service_url = "tgram://token/id"
+
+[scheduler]
+interval = "7m"
+`,
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			first, second := t.TempDir(), t.TempDir()
+			configSearchPaths = func() []string { return []string{first, second} }
+			t.Cleanup(func() { configSearchPaths = defaultConfigSearchPaths })
+
+			// Only the second search directory has a config file
+			path := filepath.Join(second, name)
+			require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+			v := viper.New()
+			cfg, err := loadConfig(v, "")
+
+			require.NoError(t, err)
+			assert.Equal(t, path, v.ConfigFileUsed())
+			assert.Equal(t, "https://apprise.example.com/notify", cfg.Notifier.AppriseAPIURL)
+			assert.Equal(t, 7*time.Minute, cfg.Scheduler.GetInterval())
+		})
+	}
+}
+
+func TestLoadConfig_SearchPathOrder(t *testing.T) {
+	t.Chdir(t.TempDir())
+	first, second := t.TempDir(), t.TempDir()
+	configSearchPaths = func() []string { return []string{first, second} }
+	t.Cleanup(func() { configSearchPaths = defaultConfigSearchPaths })
+
+	write := func(dir, interval string) {
+		content := "notifier:\n  apprise_api_url: \"https://apprise.example.com/notify\"\n  apprise_service_url: \"tgram://token/id\"\nscheduler:\n  interval: \"" + interval + "\"\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(content), 0o600))
+	}
+	write(first, "3m")
+	write(second, "9m")
+
+	cfg, err := loadConfig(viper.New(), "")
+
+	require.NoError(t, err)
+	assert.Equal(t, 3*time.Minute, cfg.Scheduler.GetInterval())
+}
+
+func TestFailureAlerts(t *testing.T) {
+	cfg := config.Config{Notifier: config.NotifierConfig{Backend: "slack", SlackWebhookURL: "https://hooks.slack.com/services/T/B/X"}}
+	assert.Nil(t, failureAlerts(cfg, newTaskNotifiers(cfg)).Notifier, "disabled by default")
+
+	cfg.Scheduler.AlertAfterFailures = 3
+	cfg.Scheduler.RecoveryAfterFailures = -1
+	notifiers := newTaskNotifiers(cfg)
+	alerts := failureAlerts(cfg, notifiers)
+	assert.NotNil(t, alerts.Notifier)
+	assert.Equal(t, 3, alerts.AlertAfter)
+	assert.Equal(t, 0, alerts.RecoveryAfter)
+
+	// Failure alerts share the default notifier (and its dedup, rate limit and quiet hours) with tasks
+	taskNotifier, _, err := notifiers.get("")
+	require.NoError(t, err)
+	assert.Same(t, taskNotifier, alerts.Notifier)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"watchdog/internal/config"
+	"watchdog/internal/notifier"
+)
+
+var (
+	testNotifySubject  string
+	testNotifyMessage  string
+	testNotifyNotifier string
+)
+
+// testNotifyCmd sends a single notification through the configured backends, so users can
+// check their notifier settings before relying on them.
+var testNotifyCmd = &cobra.Command{
+	Use:   "test-notify",
+	Short: "Send a test notification and exit",
+	Long: `Test-notify loads the configuration, builds the notifier exactly like the running app
+(same backend selection and settings) and sends one notification.
+
+Use --notifier to test one of the named notifiers defined under notifiers instead of the
+default one. Quiet hours and de-duplication are skipped so the test notification is always
+delivered. Exits with status 1 if the notification could not be sent.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		notifierCfg, err := appConfig.GetNotifier(testNotifyNotifier)
+		if err != nil {
+			_, _ = fmt.Fprintln(cmd.ErrOrStderr(), err)
+			os.Exit(1)
+		}
+		if err := runTestNotify(cmd.Context(), cmd.OutOrStdout(), notifierCfg, testNotifySubject, testNotifyMessage); err != nil {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Failed to send test notification: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	testNotifyCmd.Flags().StringVar(&testNotifySubject, "subject", "Watchdog test notification", "notification subject")
+	testNotifyCmd.Flags().StringVar(&testNotifyMessage, "message", "If you can read this, watchdog notifications are working.", "notification body")
+	testNotifyCmd.Flags().StringVar(&testNotifyNotifier, "notifier", "", "named notifier to test (default: the notifier section)")
+	rootCmd.AddCommand(testNotifyCmd)
+}
+
+// runTestNotify sends subject and message through the notifier built from cfg and
+// reports the result to out. Quiet hours and de-duplication are disabled for the test.
+func runTestNotify(ctx context.Context, out io.Writer, cfg config.NotifierConfig, subject, message string) error {
+	cfg.QuietHours = config.QuietHoursConfig{}
+	cfg.DedupWindow = ""
+
+	notif, err := notifier.NewFromConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	if err := notif.SendNotification(ctx, subject, message); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(out, "Test notification sent via %s.\n", strings.Join(cfg.GetBackends(), ", "))
+	return nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"watchdog/internal/config"
+	"watchdog/internal/notifier"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunTestNotify_SendsNotification(t *testing.T) {
+	var payload notifier.WebhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := config.NotifierConfig{
+		AppriseAPIURL:     server.URL,
+		AppriseServiceURL: "tgram://token/id",
+		// Quiet hours covering the whole day must not hold back the test notification
+		QuietHours: config.QuietHoursConfig{Start: "00:00", End: "23:59"},
+	}
+
+	var out bytes.Buffer
+	err := runTestNotify(context.Background(), &out, cfg, "Hello", "Pipeline check")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", payload.Title)
+	assert.Equal(t, "Pipeline check", payload.Body)
+	assert.Equal(t, []string{"tgram://token/id"}, payload.URLs)
+	assert.Contains(t, out.String(), "Test notification sent via apprise.")
+}
+
+func TestRunTestNotify_ReportsSendFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad service url", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	noRetries := 0
+	cfg := config.NotifierConfig{AppriseAPIURL: server.URL, AppriseServiceURL: "tgram://token/id", MaxRetries: &noRetries}
+
+	var out bytes.Buffer
+	err := runTestNotify(context.Background(), &out, cfg, "Hello", "Pipeline check")
+
+	assert.Error(t, err)
+	assert.Empty(t, out.String())
+}
+
+func TestRunTestNotify_InvalidBackend(t *testing.T) {
+	var out bytes.Buffer
+	err := runTestNotify(context.Background(), &out, config.NotifierConfig{Backend: "slack"}, "Hello", "Pipeline check")
+
+	assert.ErrorContains(t, err, "notifier.slack_webhook_url is required")
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"watchdog/internal/config"
+	"watchdog/internal/notifier"
+)
+
+// validateCmd checks a configuration file without starting the scheduler or sending notifications.
+// It's intended for CI pipelines: the exit status is 0 if the config is valid and 1 otherwise.
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate the configuration file and exit",
+	Long: `Validate loads the configuration (file and environment variables) and reports any problems:
+  - Missing required settings
+  - Unparseable durations (intervals, cooldowns)
+  - Malformed Apprise API and notification service URLs (tgram, discord, slack and mailto
+    URLs must have all their parts; unrecognized schemes are reported as warnings)
+
+It never starts monitoring or sends notifications. Exits with status 1 if any problem is found.`,
+	// Override the root PersistentPreRun: validation reports config errors instead of exiting on them
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+	Run: func(cmd *cobra.Command, args []string) {
+		if !runValidate(cmd.OutOrStdout(), viper.New(), configPath(cfgFile)) {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+}
+
+// runValidate loads the configuration at path (or the default location) and writes a
+// human-readable report to out. It returns true if no problems were found.
+func runValidate(out io.Writer, v *viper.Viper, path string) bool {
+	cfg, err := readConfig(v, path)
+
+	source := v.ConfigFileUsed()
+	if source == "" {
+		source = "(environment only)"
+	}
+	_, _ = fmt.Fprintf(out, "Configuration: %s\n", source)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(out, "Problems found:\n  - %v\n", err)
+		return false
+	}
+
+	if warnings := serviceURLWarnings(cfg); len(warnings) > 0 {
+		_, _ = fmt.Fprintln(out, "Warnings:")
+		for _, warning := range warnings {
+			_, _ = fmt.Fprintf(out, "  - %s\n", warning)
+		}
+	}
+
+	problems := checkConfig(&cfg)
+	if len(problems) > 0 {
+		_, _ = fmt.Fprintln(out, "Problems found:")
+		for _, problem := range problems {
+			_, _ = fmt.Fprintf(out, "  - %s\n", problem)
+		}
+		return false
+	}
+
+	_, _ = fmt.Fprintln(out, "Configuration is valid.")
+	return true
+}
+
+// checkConfig runs validateConfig plus deeper checks that the application itself only
+// warns about at runtime (e.g., invalid durations silently fall back to defaults).
+// It returns every problem found, or nil if the configuration is valid.
+func checkConfig(cfg *config.Config) []string {
+	var problems []string
+
+	if err := validateConfig(cfg); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	// Durations must parse and be positive when set
+	type setting struct {
+		key   string
+		value string
+	}
+	durations := []setting{
+		{"scheduler.interval", cfg.Scheduler.Interval},
+		{"scheduler.shutdown_timeout", cfg.Scheduler.ShutdownTimeout},
+		{"scheduler.max_backoff", cfg.Scheduler.MaxBackoff},
+		{"tasks.telnyx.interval", cfg.Tasks.Telnyx.Interval},
+		{"tasks.telnyx.notification_cooldown", cfg.Tasks.Telnyx.NotificationCooldown},
+		{"tasks.telnyx.min_runway", cfg.Tasks.Telnyx.MinRunway},
+		{"tasks.telnyx.timeout", cfg.Tasks.Telnyx.Timeout},
+		{"tasks.github.interval", cfg.Tasks.GitHub.Interval},
+		{"tasks.github.notification_cooldown", cfg.Tasks.GitHub.NotificationCooldown},
+		{"tasks.github.grace_period", cfg.Tasks.GitHub.GracePeriod},
+		{"tasks.github.org_repos_cache_ttl", cfg.Tasks.GitHub.OrgReposCacheTTL},
+		{"tasks.github.timeout", cfg.Tasks.GitHub.Timeout},
+		{"notifier.dedup_window", cfg.Notifier.DedupWindow},
+		{"notifier.initial_backoff", cfg.Notifier.InitialBackoff},
+		{"notifier.max_backoff", cfg.Notifier.MaxBackoff},
+		{"notifier.timeout", cfg.Notifier.Timeout},
+	}
+	for _, name := range slices.Sorted(maps.Keys(cfg.Notifiers)) {
+		named := cfg.Notifiers[name]
+		durations = append(durations,
+			setting{"notifiers." + name + ".dedup_window", named.DedupWindow},
+			setting{"notifiers." + name + ".initial_backoff", named.InitialBackoff},
+			setting{"notifiers." + name + ".max_backoff", named.MaxBackoff},
+			setting{"notifiers." + name + ".timeout", named.Timeout},
+		)
+	}
+	for i, check := range cfg.Tasks.HTTPChecks {
+		durations = append(durations,
+			setting{fmt.Sprintf("tasks.http_checks[%d].interval", i), check.Interval},
+			setting{fmt.Sprintf("tasks.http_checks[%d].timeout", i), check.Timeout},
+			setting{fmt.Sprintf("tasks.http_checks[%d].notification_cooldown", i), check.NotificationCooldown},
+		)
+	}
+	for _, d := range durations {
+		if problem := checkDuration(d.key, d.value); problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+
+	// URLs must be well-formed
+	if cfg.Notifier.AppriseAPIURL != "" {
+		if problem := checkHTTPURL("notifier.apprise_api_url", cfg.Notifier.AppriseAPIURL); problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+	if cfg.Notifier.SlackWebhookURL != "" {
+		if problem := checkHTTPURL("notifier.slack_webhook_url", cfg.Notifier.SlackWebhookURL); problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+	if cfg.Notifier.DiscordWebhookURL != "" {
+		if problem := checkHTTPURL("notifier.discord_webhook_url", cfg.Notifier.DiscordWebhookURL); problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+	if cfg.Tasks.Telnyx.APIURL != "" {
+		if problem := checkHTTPURL("tasks.telnyx.api_url", cfg.Tasks.Telnyx.APIURL); problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+	for i, check := range cfg.Tasks.HTTPChecks {
+		if check.URL != "" {
+			if problem := checkHTTPURL(fmt.Sprintf("tasks.http_checks[%d].url", i), check.URL); problem != "" {
+				problems = append(problems, problem)
+			}
+		}
+	}
+	for _, serviceURL := range cfg.Notifier.GetServiceURLs() {
+		if _, err := notifier.CheckServiceURL(serviceURL); err != nil {
+			// validateConfig already reports the first bad URL when apprise is enabled
+			if problem := fmt.Sprintf("notifier.apprise_service_url: %v", err); !slices.Contains(problems, problem) {
+				problems = append(problems, problem)
+			}
+		}
+	}
+
+	return problems
+}
+
+// checkDuration returns a problem description if value is set but isn't a positive duration.
+func checkDuration(key, value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ""
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Sprintf("%s: invalid duration %q (examples: \"5m\", \"1h30m\")", key, value)
+	}
+	if d <= 0 {
+		return fmt.Sprintf("%s: duration must be positive (got %q)", key, value)
+	}
+	return ""
+}
+
+// checkHTTPURL returns a problem description if value isn't an absolute http(s) URL.
+func checkHTTPURL(key, value string) string {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Sprintf("%s: %q is not a valid http(s) URL", key, value)
+	}
+	return ""
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunValidate_ValidConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, `
+notifier:
+  apprise_api_url: "https://apprise.example.com/notify"
+  apprise_service_url: "tgram://token/id,discord://webhook/token"
+scheduler:
+  interval: "5m"
+tasks:
+  telnyx:
+    api_url: "https://api.telnyx.com/v2/balance"
+    api_key: "KEY123"
+    notification_cooldown: "6h"
+`)
+
+	var out bytes.Buffer
+	ok := runValidate(&out, viper.New(), path)
+
+	assert.True(t, ok)
+	assert.Contains(t, out.String(), path)
+	assert.Contains(t, out.String(), "Configuration is valid.")
+}
+
+func TestRunValidate_InvalidConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, `
+notifier:
+  apprise_api_url: "apprise.example.com/notify"
+  apprise_service_url: "tgram://token/id,not-a-url"
+scheduler:
+  interval: "5 minutes"
+tasks:
+  github:
+    notification_cooldown: "-1h"
+`)
+
+	var out bytes.Buffer
+	ok := runValidate(&out, viper.New(), path)
+
+	assert.False(t, ok)
+	report := out.String()
+	assert.Contains(t, report, "Problems found:")
+	assert.Contains(t, report, `notifier.apprise_api_url: "apprise.example.com/notify" is not a valid http(s) URL`)
+	assert.Contains(t, report, `"not-a-url" is not a valid service URL`)
+	assert.Contains(t, report, `scheduler.interval: invalid duration "5 minutes"`)
+	assert.Contains(t, report, `tasks.github.notification_cooldown: duration must be positive`)
+}
+
+func TestRunValidate_MissingFile(t *testing.T) {
+	var out bytes.Buffer
+	ok := runValidate(&out, viper.New(), filepath.Join(t.TempDir(), "missing.yaml"))
+
+	assert.False(t, ok)
+	assert.Contains(t, out.String(), "error reading config file")
+}
+
+func TestRunValidate_ServiceURLs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, `
+notifier:
+  apprise_api_url: "https://apprise.example.com/notify"
+  apprise_service_url: "tgram://token,discord://webhook/token,mystery://host/path"
+`)
+
+	var out bytes.Buffer
+	ok := runValidate(&out, viper.New(), path)
+
+	assert.False(t, ok)
+	report := out.String()
+	assert.Contains(t, report, "Warnings:")
+	assert.Contains(t, report, `"mystery://host/path" has an unrecognized scheme`)
+	assert.Contains(t, report, `notifier.apprise_service_url: "tgram://token" is missing path segments (expected tgram://bottoken/chatid)`)
+	assert.Equal(t, 1, strings.Count(report, `"tgram://token" is missing path segments`))
+	assert.NotContains(t, report, `"discord://webhook/token"`)
+}
+
+func TestRunValidate_UnknownSchemeIsOnlyAWarning(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, `
+notifier:
+  apprise_api_url: "https://apprise.example.com/notify"
+  apprise_service_url: "mystery://host/path"
+`)
+
+	var out bytes.Buffer
+	ok := runValidate(&out, viper.New(), path)
+
+	assert.True(t, ok)
+	assert.Contains(t, out.String(), `"mystery://host/path" has an unrecognized scheme`)
+	assert.Contains(t, out.String(), "Configuration is valid.")
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"watchdog/internal/api"
+	"watchdog/internal/notifier"
+
+	"github.com/spf13/cobra"
+)
+
+// Version information populated by ldflags during build.
+// These are set by GoReleaser or manual builds, e.g.:
+//
+//	go build -ldflags "-X main.version=v1.2.3 -X main.commit=$(git rev-parse HEAD) -X main.buildDate=$(date -u +%FT%TZ)" ./cmd
+var (
+	version   = "dev"
+	commit    = "none"
+	buildDate = "unknown"
+)
+
+// versionJSON indicates if the --json flag was provided to the version subcommand.
+var versionJSON bool
+
+// buildInfo describes the running build.
+type buildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+}
+
+// versionCmd prints build metadata. Like validate, it doesn't need a config file.
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print version, git commit and build date",
+	// Override the root PersistentPreRun: printing the version doesn't need configuration
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := writeVersion(cmd.OutOrStdout(), currentBuildInfo(), versionJSON); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to print version: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	versionCmd.Flags().BoolVar(&versionJSON, "json", false, "print version information as JSON")
+	rootCmd.AddCommand(versionCmd)
+
+	// Identify outbound API and notification traffic by build version
+	api.UserAgent = "watchdog/" + version
+	notifier.UserAgent = api.UserAgent
+}
+
+// currentBuildInfo returns the build metadata set via ldflags.
+func currentBuildInfo() buildInfo {
+	return buildInfo{
+		Version:   version,
+		Commit:    commit,
+		BuildDate: buildDate,
+	}
+}
+
+// formatVersion renders build metadata in the human-readable form used by --version.
+func formatVersion(info buildInfo) string {
+	return fmt.Sprintf("watchdog version %s\ncommit: %s\nbuilt: %s\n", info.Version, info.Commit, info.BuildDate)
+}
+
+// writeVersion writes build metadata to out, as JSON if asJSON is set.
+func writeVersion(out io.Writer, info buildInfo, asJSON bool) error {
+	if asJSON {
+		return json.NewEncoder(out).Encode(info)
+	}
+	_, err := fmt.Fprint(out, formatVersion(info))
+	return err
+}
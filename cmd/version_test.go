@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setBuildVars overrides the ldflags variables for the duration of a test
+func setBuildVars(t *testing.T, v, c, d string) {
+	t.Helper()
+	oldVersion, oldCommit, oldDate := version, commit, buildDate
+	version, commit, buildDate = v, c, d
+	t.Cleanup(func() {
+		version, commit, buildDate = oldVersion, oldCommit, oldDate
+	})
+}
+
+func TestWriteVersion_Text(t *testing.T) {
+	setBuildVars(t, "v1.2.3", "abc1234", "2026-01-02T03:04:05Z")
+
+	var out bytes.Buffer
+	require.NoError(t, writeVersion(&out, currentBuildInfo(), false))
+
+	assert.Equal(t, "watchdog version v1.2.3\ncommit: abc1234\nbuilt: 2026-01-02T03:04:05Z\n", out.String())
+}
+
+func TestWriteVersion_JSON(t *testing.T) {
+	setBuildVars(t, "v1.2.3", "abc1234", "2026-01-02T03:04:05Z")
+
+	var out bytes.Buffer
+	require.NoError(t, writeVersion(&out, currentBuildInfo(), true))
+
+	var info map[string]string
+	require.NoError(t, json.Unmarshal(out.Bytes(), &info))
+	assert.Equal(t, map[string]string{
+		"version":    "v1.2.3",
+		"commit":     "abc1234",
+		"build_date": "2026-01-02T03:04:05Z",
+	}, info)
+}
+
+func TestVersionCommand(t *testing.T) {
+	setBuildVars(t, "v9.9.9", "deadbeef", "today")
+
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetArgs([]string{"version", "--json"})
+	t.Cleanup(func() {
+		rootCmd.SetOut(nil)
+		rootCmd.SetArgs(nil)
+		versionJSON = false
+	})
+
+	require.NoError(t, rootCmd.Execute())
+	assert.Contains(t, out.String(), `"version":"v9.9.9"`)
+	assert.Contains(t, out.String(), `"commit":"deadbeef"`)
+}
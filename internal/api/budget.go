@@ -0,0 +1,85 @@
+package api
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BudgetExceededError is returned instead of making a request once the hourly request
+// budget is used up. The request can be made again after Reset.
+type BudgetExceededError struct {
+	// Limit is the number of requests allowed per hour
+	Limit int
+
+	// Reset is when the budget starts over
+	Reset time.Time
+}
+
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("api request budget of %d per hour used up until %s", e.Limit, e.Reset.Format(time.RFC3339))
+}
+
+// Budget caps the number of API requests made per hour, so watchdog can't use up a
+// quota it shares with other tools (e.g., GitHub's 5000 requests/hour per token).
+// Every attempt counts, including retries. The count starts over an hour after the
+// first request of the window. A limit of 0 means unlimited; a nil *Budget also allows
+// every request.
+//
+// A Budget is safe for concurrent use, so one can be shared by all clients.
+type Budget struct {
+	mu          sync.Mutex
+	limit       int
+	used        int
+	windowStart time.Time
+
+	// now returns the current time (overridable in tests)
+	now func() time.Time
+}
+
+// NewBudget creates a budget of perHour requests per hour (0 = unlimited).
+func NewBudget(perHour int) *Budget {
+	return &Budget{limit: perHour, now: time.Now}
+}
+
+// requestBudget is the budget shared by API clients that don't have their own.
+var requestBudget = NewBudget(0)
+
+// SetRequestBudget sets how many requests the GitHub and Telnyx clients may make per
+// hour in total (0 = unlimited). Requests already counted in the current hour still count.
+func SetRequestBudget(perHour int) {
+	requestBudget.SetLimit(perHour)
+}
+
+// SetLimit changes the number of requests allowed per hour (0 = unlimited).
+func (b *Budget) SetLimit(perHour int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.limit = perHour
+}
+
+// Take counts one request against the budget. It returns a *BudgetExceededError, without
+// counting the request, if the budget for the current hour is used up.
+func (b *Budget) Take() error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.limit <= 0 {
+		return nil
+	}
+
+	now := b.now()
+	if b.windowStart.IsZero() || now.Sub(b.windowStart) >= time.Hour {
+		b.windowStart = now
+		b.used = 0
+	}
+	if b.used >= b.limit {
+		return &BudgetExceededError{Limit: b.limit, Reset: b.windowStart.Add(time.Hour)}
+	}
+	b.used++
+	return nil
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudget_Take(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := start
+	budget := NewBudget(2)
+	budget.now = func() time.Time { return now }
+
+	require.NoError(t, budget.Take())
+	now = now.Add(10 * time.Minute)
+	require.NoError(t, budget.Take())
+
+	err := budget.Take()
+	var exceeded *BudgetExceededError
+	require.ErrorAs(t, err, &exceeded)
+	assert.Equal(t, 2, exceeded.Limit)
+	assert.Equal(t, start.Add(time.Hour), exceeded.Reset)
+	assert.EqualError(t, err, "api request budget of 2 per hour used up until 2024-01-01T13:00:00Z")
+
+	// An hour after the first request, the budget starts over
+	now = start.Add(time.Hour)
+	assert.NoError(t, budget.Take())
+	assert.NoError(t, budget.Take())
+	assert.Error(t, budget.Take())
+}
+
+func TestBudget_Unlimited(t *testing.T) {
+	budget := NewBudget(0)
+	for i := 0; i < 100; i++ {
+		require.NoError(t, budget.Take())
+	}
+
+	var nilBudget *Budget
+	assert.NoError(t, nilBudget.Take())
+}
+
+func TestBudget_SetLimit(t *testing.T) {
+	budget := NewBudget(1)
+	require.NoError(t, budget.Take())
+	require.Error(t, budget.Take())
+
+	budget.SetLimit(0)
+	assert.NoError(t, budget.Take())
+}
+
+func TestGitHubAPI_Budget_ShortCircuitsRequests(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode([]PullRequest{{Number: 7}})
+	}))
+	defer server.Close()
+
+	api := &GitHubAPI{BaseURL: server.URL, Budget: NewBudget(2)}
+
+	for i := 0; i < 2; i++ {
+		_, err := api.GetOpenPullRequests(context.Background(), "owner", "repo")
+		require.NoError(t, err)
+	}
+
+	_, err := api.GetOpenPullRequests(context.Background(), "owner", "repo")
+	var exceeded *BudgetExceededError
+	assert.ErrorAs(t, err, &exceeded)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests), "requests over the budget must not reach the server")
+}
+
+func TestGitHubAPI_Budget_CountsRetries(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	api := &GitHubAPI{
+		BaseURL:     server.URL,
+		Budget:      NewBudget(2),
+		RetryConfig: &RetryConfig{MaxRetries: 5, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, BackoffMultiplier: 1},
+	}
+
+	_, err := api.GetOpenPullRequests(context.Background(), "owner", "repo")
+	var exceeded *BudgetExceededError
+	assert.ErrorAs(t, err, &exceeded)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestTelnyxAPI_Budget_ShortCircuitsRequests(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		resp := TelnyxBalanceResponse{}
+		resp.Data.Balance = "42.00"
+		resp.Data.Currency = "USD"
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	api := &TelnyxAPI{APIURL: server.URL, APIKey: "testkey", Budget: NewBudget(1)}
+
+	_, err := api.GetBalance(context.Background())
+	require.NoError(t, err)
+
+	_, err = api.GetBalance(context.Background())
+	var exceeded *BudgetExceededError
+	assert.True(t, errors.As(err, &exceeded))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
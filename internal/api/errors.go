@@ -0,0 +1,118 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Sentinel errors for branching on why an API request failed, with errors.Is.
+var (
+	// ErrNetwork matches requests that got no response at all (DNS failure, refused
+	// connection, timeout, ...).
+	ErrNetwork = errors.New("network error")
+
+	// ErrNotFound matches an APIError with status 404 (e.g., a repository that was
+	// deleted or renamed, or that the token can't see).
+	ErrNotFound = errors.New("not found")
+
+	// ErrRateLimited matches a RateLimitError.
+	ErrRateLimited = errors.New("rate limited")
+)
+
+// APIError is an API response with an unexpected status code.
+type APIError struct {
+	// Service names the API that responded (e.g., "github" or "telnyx")
+	Service string
+
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+
+	// Body is the response body, usually the API's error message
+	Body string
+
+	// Hint optionally explains the likely cause (e.g., a missing token scope)
+	Hint string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s api request failed with status %d: %s", e.Service, e.StatusCode, e.Body)
+	if e.Hint != "" {
+		msg += " (" + e.Hint + ")"
+	}
+	return msg
+}
+
+// Is makes errors.Is(err, ErrNotFound) hold for a 404.
+func (e *APIError) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
+}
+
+// RateLimitError is an API response rejecting a request because the client exceeded
+// its rate limit. errors.As also finds it as an *APIError.
+type RateLimitError struct {
+	APIError
+
+	// Reset is when the limit resets and requests are accepted again.
+	// Zero if the API didn't say.
+	Reset time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	msg := e.APIError.Error()
+	if !e.Reset.IsZero() {
+		msg += fmt.Sprintf(" (rate limit resets at %s)", e.Reset.Format(time.RFC3339))
+	}
+	return msg
+}
+
+// Is makes errors.Is(err, ErrRateLimited) hold.
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// Unwrap returns the embedded APIError, so errors.As finds it too.
+func (e *RateLimitError) Unwrap() error {
+	return &e.APIError
+}
+
+// newStatusError returns the error for an unexpected response of service: a
+// RateLimitError for a 429 or a 403 with X-RateLimit-Remaining: 0 (GitHub's primary
+// rate limit), an APIError otherwise.
+func newStatusError(service string, resp *http.Response, body []byte) error {
+	apiErr := APIError{Service: service, StatusCode: resp.StatusCode, Body: string(body)}
+	rateLimited := resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0")
+	if !rateLimited {
+		return &apiErr
+	}
+	return &RateLimitError{APIError: apiErr, Reset: rateLimitReset(resp)}
+}
+
+// rateLimitReset returns when a rate limit resets, from the X-RateLimit-Reset (Unix
+// seconds) or Retry-After (seconds) header, or the zero time if neither is set.
+func rateLimitReset(resp *http.Response) time.Time {
+	if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		return time.Unix(reset, 0)
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		return time.Now().Add(time.Duration(seconds) * time.Second)
+	}
+	return time.Time{}
+}
+
+// networkError marks a request that got no response, so errors.Is(err, ErrNetwork)
+// holds while the underlying error (e.g., context.DeadlineExceeded) stays matchable.
+type networkError struct {
+	err error
+}
+
+func (e *networkError) Error() string {
+	return e.err.Error()
+}
+
+func (e *networkError) Unwrap() []error {
+	return []error{ErrNetwork, e.err}
+}
@@ -0,0 +1,187 @@
+package api
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// statusServer responds to every request with status, headers and body.
+func statusServer(t *testing.T, status int, headers map[string]string, body string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for k, v := range headers {
+			w.Header().Set(k, v)
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// gzipStatusServer is statusServer with a gzip-encoded body, counting the requests it serves.
+func gzipStatusServer(t *testing.T, status int, headers map[string]string, body string, requests *int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		for k, v := range headers {
+			w.Header().Set(k, v)
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(status)
+		gz := gzip.NewWriter(w)
+		_, _ = gz.Write([]byte(body))
+		_ = gz.Close()
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGitHubAPI_ErrorTypes(t *testing.T) {
+	noRetries := &RetryConfig{}
+
+	t.Run("not found", func(t *testing.T) {
+		server := statusServer(t, http.StatusNotFound, nil, `{"message":"Not Found"}`)
+		client := &GitHubAPI{BaseURL: server.URL, RetryConfig: noRetries}
+
+		_, err := client.GetOpenPullRequests(context.Background(), "owner", "gone")
+
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, "github", apiErr.Service)
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		assert.Equal(t, `{"message":"Not Found"}`, apiErr.Body)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.NotErrorIs(t, err, ErrRateLimited)
+		assert.NotErrorIs(t, err, ErrNetwork)
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		server := statusServer(t, http.StatusForbidden, map[string]string{
+			"X-RateLimit-Remaining": "0",
+			"X-RateLimit-Reset":     "1700000000",
+		}, `{"message":"API rate limit exceeded"}`)
+		client := &GitHubAPI{BaseURL: server.URL, Token: "ghp_test", RetryConfig: noRetries}
+
+		_, err := client.GetOpenPullRequests(context.Background(), "owner", "repo")
+
+		var rateLimit *RateLimitError
+		require.ErrorAs(t, err, &rateLimit)
+		assert.Equal(t, time.Unix(1700000000, 0), rateLimit.Reset)
+		assert.ErrorIs(t, err, ErrRateLimited)
+		assert.NotErrorIs(t, err, ErrNotFound)
+		assert.Contains(t, err.Error(), "rate limit resets at")
+
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+	})
+
+	t.Run("too many requests", func(t *testing.T) {
+		server := statusServer(t, http.StatusTooManyRequests, map[string]string{"Retry-After": "60"}, "")
+		client := &GitHubAPI{BaseURL: server.URL, RetryConfig: noRetries}
+
+		_, err := client.GetCommitStatus(context.Background(), "owner", "repo", "abc123")
+
+		var rateLimit *RateLimitError
+		require.ErrorAs(t, err, &rateLimit)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), rateLimit.Reset, 5*time.Second)
+	})
+
+	t.Run("retries exhausted with gzip body", func(t *testing.T) {
+		retries := &RetryConfig{MaxRetries: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, BackoffMultiplier: 1}
+
+		t.Run("too many requests", func(t *testing.T) {
+			var requests int32
+			server := gzipStatusServer(t, http.StatusTooManyRequests, map[string]string{"Retry-After": "60"},
+				`{"message":"secondary rate limit"}`, &requests)
+			client := &GitHubAPI{BaseURL: server.URL, RetryConfig: retries}
+
+			_, err := client.GetOpenPullRequests(context.Background(), "owner", "repo")
+
+			var rateLimit *RateLimitError
+			require.ErrorAs(t, err, &rateLimit)
+			assert.Equal(t, `{"message":"secondary rate limit"}`, rateLimit.Body)
+			assert.NotContains(t, err.Error(), "closed response body")
+			assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+		})
+
+		t.Run("service unavailable", func(t *testing.T) {
+			var requests int32
+			server := gzipStatusServer(t, http.StatusServiceUnavailable, nil, `{"message":"unavailable"}`, &requests)
+			client := &GitHubAPI{BaseURL: server.URL, RetryConfig: retries}
+
+			_, err := client.GetOpenPullRequests(context.Background(), "owner", "repo")
+
+			var apiErr *APIError
+			require.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+			assert.Equal(t, `{"message":"unavailable"}`, apiErr.Body)
+			assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+		})
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		server := forbiddenServer(t, map[string]string{"X-Accepted-GitHub-Permissions": "pull_requests=read"})
+		client := &GitHubAPI{BaseURL: server.URL, Token: "github_pat_test", RetryConfig: noRetries}
+
+		_, err := client.GetOpenPullRequests(context.Background(), "owner", "repo")
+
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+		assert.Contains(t, apiErr.Hint, "pull_requests=read")
+		assert.NotErrorIs(t, err, ErrRateLimited)
+	})
+
+	t.Run("network", func(t *testing.T) {
+		server := statusServer(t, http.StatusOK, nil, "")
+		server.Close()
+		client := &GitHubAPI{BaseURL: server.URL, RetryConfig: noRetries}
+
+		_, err := client.GetOpenPullRequests(context.Background(), "owner", "repo")
+
+		assert.ErrorIs(t, err, ErrNetwork)
+		var apiErr *APIError
+		assert.False(t, errors.As(err, &apiErr))
+	})
+}
+
+func TestTelnyxAPI_ErrorTypes(t *testing.T) {
+	t.Run("unauthorized", func(t *testing.T) {
+		server := statusServer(t, http.StatusUnauthorized, nil, `{"errors":[{"title":"Authentication failed"}]}`)
+
+		_, err := NewTelnyxAPI(server.URL, "KEY_bad").GetBalance(context.Background())
+
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, "telnyx", apiErr.Service)
+		assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+		assert.NotErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		server := statusServer(t, http.StatusNotFound, nil, "")
+
+		_, err := NewTelnyxAPI(server.URL, "KEY_test").GetBalance(context.Background())
+
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("network", func(t *testing.T) {
+		server := statusServer(t, http.StatusOK, nil, "")
+		server.Close()
+
+		_, err := NewTelnyxAPI(server.URL, "KEY_test").GetBalance(context.Background())
+
+		assert.ErrorIs(t, err, ErrNetwork)
+		assert.Contains(t, err.Error(), "failed to fetch balance")
+	})
+}
@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	neturl "net/url"
 	"regexp"
+	"sync"
 	"time"
 )
 
@@ -31,19 +33,108 @@ type PullRequest struct {
 	UpdatedAt time.Time `json:"updated_at"`
 
 	// Draft indicates if this is a draft PR (not ready for review)
-	// We skip draft PRs in our monitoring
+	// We skip draft PRs in our monitoring unless monitor_drafts is set
 	Draft bool `json:"draft"`
 
 	// HTMLURL is the web URL to view the PR (e.g., https://github.com/owner/repo/pull/123)
 	// We include this in notifications so users can click through
 	HTMLURL string `json:"html_url"`
 
-	// RequestedReviewers is a list of users who have been asked to review this PR.
-	// We use this to enrich notifications (e.g., "Waiting on: alice, bob")
+	// RequestedReviewers is a list of users who have been asked to review this PR
+	// and haven't reviewed yet. We use this to enrich notifications (e.g., "Waiting on: alice, bob")
+	// and for only_requested_for filtering
 	RequestedReviewers []User `json:"requested_reviewers"`
 
+	// Assignees are the users the PR is assigned to.
+	// We use these for assignee filtering
+	Assignees []User `json:"assignees"`
+
 	// Head represents the tip of the PR branch. We need the SHA to check CI status.
 	Head PRHead `json:"head"`
+
+	// Labels are the labels applied to the PR (e.g., "needs-review", "wip")
+	// We use these for include/exclude filtering
+	Labels []Label `json:"labels"`
+
+	// Mergeable and MergeableState tell whether the PR can be merged, e.g. state "dirty"
+	// for merge conflicts. GitHub only returns them for a single PR (GetPullRequest), not
+	// in the PR list, and computes them in the background: Mergeable is nil and the state
+	// "unknown" until it has.
+	Mergeable      *bool  `json:"mergeable"`
+	MergeableState string `json:"mergeable_state"`
+
+	// Additions, Deletions and ChangedFiles are the size of the PR's diff. Like the
+	// mergeable fields, GitHub only returns them for a single PR (GetPullRequest).
+	Additions    int `json:"additions"`
+	Deletions    int `json:"deletions"`
+	ChangedFiles int `json:"changed_files"`
+}
+
+// MergeableStateDirty is the mergeable_state of a PR with merge conflicts.
+const MergeableStateDirty = "dirty"
+
+// HasConflicts reports whether the PR is known to have merge conflicts.
+func (pr PullRequest) HasConflicts() bool {
+	return pr.MergeableState == MergeableStateDirty
+}
+
+// Repository represents a GitHub repository, as listed for an organization.
+type Repository struct {
+	// Name is the repository name without the owner (e.g., "signoz-web")
+	Name string `json:"name"`
+
+	// Archived repositories are read-only; we don't monitor them unless include_archived is set
+	Archived bool `json:"archived"`
+
+	// Disabled repositories are inaccessible (e.g., locked by GitHub); we never monitor them
+	Disabled bool `json:"disabled"`
+}
+
+// Issue represents a GitHub issue with the fields we care about for monitoring.
+// The issues endpoint also returns pull requests; those carry a non-nil PullRequest field.
+type Issue struct {
+	// Number is the issue number (e.g., #42)
+	Number int `json:"number"`
+
+	// Title is the issue title
+	Title string `json:"title"`
+
+	// User is who opened the issue
+	User User `json:"user"`
+
+	// Assignees are the users the issue is assigned to
+	Assignees []User `json:"assignees"`
+
+	// CreatedAt is when the issue was opened
+	CreatedAt time.Time `json:"created_at"`
+
+	// UpdatedAt is the last time the issue was modified (comments, labels, etc.)
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// HTMLURL is the web URL to view the issue
+	HTMLURL string `json:"html_url"`
+
+	// Labels are the labels applied to the issue
+	Labels []Label `json:"labels"`
+
+	// PullRequest is set only when this "issue" is actually a pull request
+	PullRequest *IssuePullRequest `json:"pull_request,omitempty"`
+}
+
+// IssuePullRequest marks an issues-endpoint entry as a pull request.
+type IssuePullRequest struct {
+	URL string `json:"url"`
+}
+
+// IsPullRequest reports whether the issue is actually a pull request.
+func (i Issue) IsPullRequest() bool {
+	return i.PullRequest != nil
+}
+
+// Label represents a GitHub issue/PR label.
+type Label struct {
+	// Name is the label text (e.g., "needs-review")
+	Name string `json:"name"`
 }
 
 // PRHead represents the head of a pull request (the commit at the tip).
@@ -55,6 +146,21 @@ type PRHead struct {
 type CommitStatus struct {
 	// State is the overall status: "pending", "success", "failure", or "error"
 	State string `json:"state"`
+
+	// Statuses are the latest statuses the overall state is combined from, one per context
+	Statuses []Status `json:"statuses"`
+}
+
+// Status is a single commit status reported by a CI system (e.g., Jenkins or CircleCI).
+type Status struct {
+	// State is "pending", "success", "failure", or "error"
+	State string `json:"state"`
+
+	// Context identifies the CI system or job (e.g., "ci/circleci: build")
+	Context string `json:"context"`
+
+	// TargetURL links to the CI run's details page. May be empty.
+	TargetURL string `json:"target_url"`
 }
 
 // CheckSuitesResponse represents the response from the Check Suites API.
@@ -76,11 +182,69 @@ type App struct {
 	Name string `json:"name"`
 }
 
-// User represents the GitHub user who created a pull request.
-// We only need the login (username) for filtering PRs by author.
+// WorkflowRun represents a single run of a GitHub Actions workflow.
+type WorkflowRun struct {
+	// ID identifies the run; newer runs have higher IDs
+	ID int64 `json:"id"`
+
+	// Name is the workflow's name (e.g., "CI")
+	Name string `json:"name"`
+
+	// RunNumber counts the runs of the workflow (e.g., run #42)
+	RunNumber int `json:"run_number"`
+
+	// Event is what triggered the run (e.g., "push", "schedule", "workflow_dispatch")
+	Event string `json:"event"`
+
+	// HeadBranch and HeadSHA are the branch and commit the run was for
+	HeadBranch string `json:"head_branch"`
+	HeadSHA    string `json:"head_sha"`
+
+	// Conclusion is the outcome of a completed run: success, failure, cancelled, timed_out, ...
+	Conclusion string `json:"conclusion"`
+
+	// HTMLURL is the web URL to view the run
+	HTMLURL string `json:"html_url"`
+
+	// CreatedAt is when the run was triggered
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WorkflowRunsResponse is the response of the workflow runs endpoint.
+type WorkflowRunsResponse struct {
+	TotalCount   int           `json:"total_count"`
+	WorkflowRuns []WorkflowRun `json:"workflow_runs"`
+}
+
+// Review represents a single pull request review.
+type Review struct {
+	// User is the reviewer
+	User User `json:"user"`
+
+	// State is the review outcome: "APPROVED", "CHANGES_REQUESTED", "COMMENTED", "DISMISSED" or "PENDING"
+	State string `json:"state"`
+
+	// SubmittedAt is when the review was submitted (zero for pending reviews)
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+// Review states reported by the GitHub API.
+const (
+	ReviewStateApproved         = "APPROVED"
+	ReviewStateChangesRequested = "CHANGES_REQUESTED"
+	ReviewStateCommented        = "COMMENTED"
+	ReviewStateDismissed        = "DISMISSED"
+	ReviewStatePending          = "PENDING"
+)
+
+// User represents a GitHub user: a PR author, assignee or requested reviewer.
+// We only need the login (username) and account type for filtering PRs.
 type User struct {
 	// Login is the GitHub username (e.g., "crazyuploader")
 	Login string `json:"login"`
+
+	// Type is the account type: "User", "Bot" or "Organization" (empty if not reported)
+	Type string `json:"type,omitempty"`
 }
 
 // GitHubAPI is a client for interacting with the GitHub REST API.
@@ -95,6 +259,24 @@ type GitHubAPI struct {
 	// With a token: 5000 requests/hour rate limit
 	// Leave empty for public repos if you don't need high rate limits
 	Token string
+
+	// TokenSource optionally supplies short-lived tokens (e.g., GitHub App installation
+	// tokens). When set, it is used instead of Token.
+	TokenSource TokenSource
+
+	// RetryConfig controls retries of transient failures (timeouts, 429, 5xx).
+	// Nil uses DefaultRetryConfig.
+	RetryConfig *RetryConfig
+
+	// Timeout bounds each request attempt. 0 uses DefaultHTTPClient's 30 seconds.
+	Timeout time.Duration
+
+	// Budget counts this client's requests against an hourly limit.
+	// Nil uses the budget shared by all clients (see SetRequestBudget).
+	Budget *Budget
+
+	// scopeWarning logs the first missing-permission hint (see statusError) only once
+	scopeWarning sync.Once
 }
 
 // NewGitHubAPI creates a new GitHub API client.
@@ -108,13 +290,41 @@ func NewGitHubAPI(token string) *GitHubAPI {
 	}
 }
 
+// retryConfig returns the retry settings for requests made by this client,
+// including the request budget they count against.
+func (g *GitHubAPI) retryConfig() RetryConfig {
+	config := DefaultRetryConfig
+	if g.RetryConfig != nil {
+		config = *g.RetryConfig
+	}
+	config.Budget = g.Budget
+	if config.Budget == nil {
+		config.Budget = requestBudget
+	}
+	return config
+}
+
 // setCommonHeaders adds common headers required for GitHub API requests.
-func (g *GitHubAPI) setCommonHeaders(req *http.Request) {
+// When a TokenSource is configured (GitHub App auth), its token takes precedence over Token;
+// fetching it may require a network round trip, hence the error.
+func (g *GitHubAPI) setCommonHeaders(req *http.Request) error {
 	req.Header.Add("Accept", "application/vnd.github.v3+json")
-	req.Header.Add("User-Agent", "watchdog-app")
-	if g.Token != "" {
-		req.Header.Add("Authorization", "token "+g.Token)
+	req.Header.Add("User-Agent", UserAgent)
+	// PR listings can be large; DoWithRetry decodes the compressed response
+	req.Header.Add("Accept-Encoding", "gzip")
+
+	token := g.Token
+	if g.TokenSource != nil {
+		var err error
+		token, err = g.TokenSource.Token(req.Context())
+		if err != nil {
+			return fmt.Errorf("failed to get github token: %w", err)
+		}
+	}
+	if token != "" {
+		req.Header.Add("Authorization", "token "+token)
 	}
+	return nil
 }
 
 // GetCommitStatus fetches the combined status (CI) for a specific commit ref (SHA).
@@ -126,17 +336,19 @@ func (g *GitHubAPI) GetCommitStatus(ctx context.Context, owner, repo, ref string
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %v", err)
 	}
-	g.setCommonHeaders(req)
+	if err := g.setCommonHeaders(req); err != nil {
+		return nil, err
+	}
 
-	resp, err := DoWithRetry(ctx, DefaultHTTPClient, req, DefaultRetryConfig)
+	resp, err := DoWithRetry(ctx, clientWithTimeout(g.Timeout), req, g.retryConfig())
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch commit status: %v", err)
+		return nil, fmt.Errorf("failed to fetch commit status: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("github api request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, g.statusError(resp, body)
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -152,6 +364,46 @@ func (g *GitHubAPI) GetCommitStatus(ctx context.Context, owner, repo, ref string
 	return &status, nil
 }
 
+// GetAuthenticatedUser fetches the user the token belongs to (GET /user).
+// It requires a token; without one GitHub responds with 401.
+func (g *GitHubAPI) GetAuthenticatedUser(ctx context.Context) (*User, error) {
+	url := fmt.Sprintf("%s/user", g.BaseURL)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	if err := g.setCommonHeaders(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := DoWithRetry(ctx, clientWithTimeout(g.Timeout), req, g.retryConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch authenticated user: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, g.statusError(resp, body)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %v", err)
+	}
+
+	var user User
+	if err := json.Unmarshal(body, &user); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %v", err)
+	}
+	if user.Login == "" {
+		return nil, fmt.Errorf("authenticated user has no login")
+	}
+
+	return &user, nil
+}
+
 // GetCheckSuites fetches the check suites for a specific commit ref (SHA).
 // This is required to get the status of GitHub Actions, which are not always covered by GetCommitStatus.
 func (g *GitHubAPI) GetCheckSuites(ctx context.Context, owner, repo, ref string) (*CheckSuitesResponse, error) {
@@ -161,17 +413,19 @@ func (g *GitHubAPI) GetCheckSuites(ctx context.Context, owner, repo, ref string)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %v", err)
 	}
-	g.setCommonHeaders(req)
+	if err := g.setCommonHeaders(req); err != nil {
+		return nil, err
+	}
 
-	resp, err := DoWithRetry(ctx, DefaultHTTPClient, req, DefaultRetryConfig)
+	resp, err := DoWithRetry(ctx, clientWithTimeout(g.Timeout), req, g.retryConfig())
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch check suites: %v", err)
+		return nil, fmt.Errorf("failed to fetch check suites: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("github api request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, g.statusError(resp, body)
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -187,6 +441,202 @@ func (g *GitHubAPI) GetCheckSuites(ctx context.Context, owner, repo, ref string)
 	return &suites, nil
 }
 
+// GetFailedWorkflowRuns fetches the most recent failed GitHub Actions workflow runs on a
+// branch of a repository (up to 100, newest first).
+func (g *GitHubAPI) GetFailedWorkflowRuns(ctx context.Context, owner, repo, branch string) ([]WorkflowRun, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/actions/runs?status=failure&branch=%s&per_page=100",
+		g.BaseURL, owner, repo, neturl.QueryEscape(branch))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	if err := g.setCommonHeaders(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := DoWithRetry(ctx, clientWithTimeout(g.Timeout), req, g.retryConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch workflow runs: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, g.statusError(resp, body)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %v", err)
+	}
+
+	var runs WorkflowRunsResponse
+	if err := json.Unmarshal(body, &runs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %v", err)
+	}
+
+	return runs.WorkflowRuns, nil
+}
+
+// GetPullRequestReviews fetches all reviews for a pull request, oldest first.
+// It follows pagination like GetOpenPullRequests.
+func (g *GitHubAPI) GetPullRequestReviews(ctx context.Context, owner, repo string, number int) ([]Review, error) {
+	var allReviews []Review
+
+	url := fmt.Sprintf("%s/repos/%s/%s/pulls/%d/reviews?per_page=100", g.BaseURL, owner, repo, number)
+
+	for url != "" {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %v", err)
+		}
+		if err := g.setCommonHeaders(req); err != nil {
+			return nil, err
+		}
+
+		resp, err := DoWithRetry(ctx, clientWithTimeout(g.Timeout), req, g.retryConfig())
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch pull request reviews: %w", err)
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, g.statusError(resp, body)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %v", err)
+		}
+
+		var reviews []Review
+		if err := json.Unmarshal(body, &reviews); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response: %v", err)
+		}
+		allReviews = append(allReviews, reviews...)
+
+		url = ""
+		if matches := linkHeaderRegex.FindStringSubmatch(resp.Header.Get("Link")); len(matches) > 1 {
+			url = matches[1]
+		}
+	}
+
+	return allReviews, nil
+}
+
+// GetOpenIssues fetches all open issues for a repository, following pagination.
+// If assignee is non-empty, only issues assigned to that user are returned.
+// Note that GitHub's issues endpoint includes pull requests; use Issue.IsPullRequest to filter them out.
+func (g *GitHubAPI) GetOpenIssues(ctx context.Context, owner, repo, assignee string) ([]Issue, error) {
+	var allIssues []Issue
+
+	url := fmt.Sprintf("%s/repos/%s/%s/issues?state=open&per_page=100", g.BaseURL, owner, repo)
+	if assignee != "" {
+		url += "&assignee=" + neturl.QueryEscape(assignee)
+	}
+
+	for url != "" {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %v", err)
+		}
+		if err := g.setCommonHeaders(req); err != nil {
+			return nil, err
+		}
+
+		resp, err := DoWithRetry(ctx, clientWithTimeout(g.Timeout), req, g.retryConfig())
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch issues: %w", err)
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, g.statusError(resp, body)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %v", err)
+		}
+
+		var issues []Issue
+		if err := json.Unmarshal(body, &issues); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response: %v", err)
+		}
+		allIssues = append(allIssues, issues...)
+
+		url = ""
+		if matches := linkHeaderRegex.FindStringSubmatch(resp.Header.Get("Link")); len(matches) > 1 {
+			url = matches[1]
+		}
+	}
+
+	return allIssues, nil
+}
+
+// GetOrgRepositories fetches all repositories of an organization, following pagination.
+// Archived and disabled repositories are included; check Repository.Archived and
+// Repository.Disabled to skip them.
+func (g *GitHubAPI) GetOrgRepositories(ctx context.Context, org string) ([]Repository, error) {
+	var allRepos []Repository
+
+	url := fmt.Sprintf("%s/orgs/%s/repos?type=all&per_page=100", g.BaseURL, org)
+
+	for url != "" {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %v", err)
+		}
+		if err := g.setCommonHeaders(req); err != nil {
+			return nil, err
+		}
+
+		resp, err := DoWithRetry(ctx, clientWithTimeout(g.Timeout), req, g.retryConfig())
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch repositories: %w", err)
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, g.statusError(resp, body)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %v", err)
+		}
+
+		var repos []Repository
+		if err := json.Unmarshal(body, &repos); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response: %v", err)
+		}
+		allRepos = append(allRepos, repos...)
+
+		url = ""
+		if matches := linkHeaderRegex.FindStringSubmatch(resp.Header.Get("Link")); len(matches) > 1 {
+			url = matches[1]
+		}
+	}
+
+	return allRepos, nil
+}
+
 // linkHeaderRegex parses the Link header to extract the next page URL.
 var linkHeaderRegex = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
 
@@ -230,23 +680,62 @@ func (g *GitHubAPI) GetOpenPullRequests(ctx context.Context, owner, repo string)
 	return allPRs, nil
 }
 
+// GetPullRequest fetches a single pull request, including the mergeable fields the PR
+// list doesn't return.
+func (g *GitHubAPI) GetPullRequest(ctx context.Context, owner, repo string, number int) (*PullRequest, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/pulls/%d", g.BaseURL, owner, repo, number)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	if err := g.setCommonHeaders(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := DoWithRetry(ctx, clientWithTimeout(g.Timeout), req, g.retryConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pull request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, g.statusError(resp, body)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %v", err)
+	}
+
+	var pr PullRequest
+	if err := json.Unmarshal(body, &pr); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %v", err)
+	}
+
+	return &pr, nil
+}
+
 // fetchPullRequestsPage fetches a single page of pull requests and returns the next page URL if available.
 func (g *GitHubAPI) fetchPullRequestsPage(ctx context.Context, url string) ([]PullRequest, string, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to create request: %v", err)
 	}
-	g.setCommonHeaders(req)
+	if err := g.setCommonHeaders(req); err != nil {
+		return nil, "", err
+	}
 
-	resp, err := DoWithRetry(ctx, DefaultHTTPClient, req, DefaultRetryConfig)
+	resp, err := DoWithRetry(ctx, clientWithTimeout(g.Timeout), req, g.retryConfig())
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to fetch pull requests: %v", err)
+		return nil, "", fmt.Errorf("failed to fetch pull requests: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, "", fmt.Errorf("github api request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, "", g.statusError(resp, body)
 	}
 
 	body, err := io.ReadAll(resp.Body)
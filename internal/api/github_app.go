@@ -0,0 +1,188 @@
+package api
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// TokenSource supplies GitHub API tokens on demand.
+type TokenSource interface {
+	// Token returns a valid token, refreshing it first if needed.
+	Token(ctx context.Context) (string, error)
+}
+
+// tokenRefreshMargin is how long before expiry an installation token is replaced,
+// so a token never expires in the middle of a run.
+const tokenRefreshMargin = 5 * time.Minute
+
+// GitHubAppTokenSource authenticates as a GitHub App installation.
+// It signs a short-lived JWT with the app's private key, exchanges it for an
+// installation access token (valid for one hour), and caches that token until
+// shortly before it expires.
+type GitHubAppTokenSource struct {
+	// AppID is the GitHub App's ID
+	AppID int64
+
+	// InstallationID is the ID of the app's installation on the user/organization
+	InstallationID int64
+
+	// PrivateKey is the app's private key, used to sign JWTs
+	PrivateKey *rsa.PrivateKey
+
+	// BaseURL is the GitHub API base URL (https://api.github.com)
+	BaseURL string
+
+	// now returns the current time (overridable in tests)
+	now func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// installationToken is the response of the installation access token endpoint.
+type installationToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// NewGitHubAppTokenSource creates a token source for the given app installation.
+func NewGitHubAppTokenSource(appID, installationID int64, privateKey *rsa.PrivateKey) *GitHubAppTokenSource {
+	return &GitHubAppTokenSource{
+		AppID:          appID,
+		InstallationID: installationID,
+		PrivateKey:     privateKey,
+		BaseURL:        "https://api.github.com",
+		now:            time.Now,
+	}
+}
+
+// Token returns the cached installation token, minting a new one if there is none
+// or it expires within tokenRefreshMargin.
+func (s *GitHubAppTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && s.now().Add(tokenRefreshMargin).Before(s.expiresAt) {
+		return s.token, nil
+	}
+
+	token, err := s.fetchInstallationToken(ctx)
+	if err != nil {
+		return "", err
+	}
+	s.token = token.Token
+	s.expiresAt = token.ExpiresAt
+	return s.token, nil
+}
+
+// fetchInstallationToken exchanges an app JWT for an installation access token.
+func (s *GitHubAppTokenSource) fetchInstallationToken(ctx context.Context) (*installationToken, error) {
+	jwt, err := s.signJWT()
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/app/installations/%d/access_tokens", s.BaseURL, s.InstallationID)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("User-Agent", UserAgent)
+	req.Header.Set("Authorization", "Bearer "+jwt)
+
+	resp, err := DoWithRetry(ctx, DefaultHTTPClient, req, DefaultRetryConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch installation token: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %v", err)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return nil, newStatusError("github", resp, body)
+	}
+
+	var token installationToken
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %v", err)
+	}
+	if token.Token == "" {
+		return nil, fmt.Errorf("github installation token response did not include a token")
+	}
+	return &token, nil
+}
+
+// signJWT builds the RS256-signed JWT GitHub expects from an app.
+// iat is backdated a minute to allow for clock drift; GitHub caps exp at 10 minutes.
+func (s *GitHubAppTokenSource) signJWT() (string, error) {
+	now := s.now()
+	header := map[string]string{"alg": "RS256", "typ": "JWT"}
+	claims := map[string]interface{}{
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(9 * time.Minute).Unix(),
+		"iss": strconv.FormatInt(s.AppID, 10),
+	}
+
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal jwt header: %v", err)
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal jwt claims: %v", err)
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.PrivateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign jwt: %v", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// LoadPrivateKey reads a PEM-encoded RSA private key (PKCS#1 or PKCS#8), as downloaded
+// from a GitHub App's settings page.
+func LoadPrivateKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key: %v", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("failed to parse private key: no PEM data found in %s", path)
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %v", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse private key: not an RSA key")
+	}
+	return key, nil
+}
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestAppServer serves the installation token endpoint (verifying the app JWT) and a
+// pulls endpoint that records the Authorization header it received.
+func newTestAppServer(t *testing.T, key *rsa.PrivateKey, expiresIn time.Duration, exchanges *int32, authHeaders chan<- string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/app/installations/99/access_tokens":
+			assert.Equal(t, "POST", r.Method)
+			jwt := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			parts := strings.Split(jwt, ".")
+			require.Len(t, parts, 3)
+
+			sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+			require.NoError(t, err)
+			digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+			assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], sig))
+
+			claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+			require.NoError(t, err)
+			var claims map[string]interface{}
+			require.NoError(t, json.Unmarshal(claimsJSON, &claims))
+			assert.Equal(t, "42", claims["iss"])
+
+			n := atomic.AddInt32(exchanges, 1)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"token":      "ghs_token" + string(rune('0'+n)),
+				"expires_at": time.Now().Add(expiresIn).UTC().Format(time.RFC3339),
+			})
+		default:
+			authHeaders <- r.Header.Get("Authorization")
+			_, _ = w.Write([]byte("[]"))
+		}
+	}))
+}
+
+func generateTestKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key
+}
+
+func TestGitHubAppTokenSource_UsesInstallationToken(t *testing.T) {
+	key := generateTestKey(t)
+	var exchanges int32
+	authHeaders := make(chan string, 10)
+	server := newTestAppServer(t, key, time.Hour, &exchanges, authHeaders)
+	defer server.Close()
+
+	source := NewGitHubAppTokenSource(42, 99, key)
+	source.BaseURL = server.URL
+
+	client := NewGitHubAPI("ignored-pat")
+	client.BaseURL = server.URL
+	client.TokenSource = source
+
+	_, err := client.GetOpenPullRequests(context.Background(), "owner", "repo")
+	require.NoError(t, err)
+	_, err = client.GetOpenPullRequests(context.Background(), "owner", "repo")
+	require.NoError(t, err)
+
+	assert.Equal(t, "token ghs_token1", <-authHeaders)
+	assert.Equal(t, "token ghs_token1", <-authHeaders)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&exchanges), "a valid token should be reused")
+}
+
+func TestGitHubAppTokenSource_RefreshesBeforeExpiry(t *testing.T) {
+	key := generateTestKey(t)
+	var exchanges int32
+	authHeaders := make(chan string, 10)
+	server := newTestAppServer(t, key, time.Hour, &exchanges, authHeaders)
+	defer server.Close()
+
+	now := time.Now()
+	source := NewGitHubAppTokenSource(42, 99, key)
+	source.BaseURL = server.URL
+	source.now = func() time.Time { return now }
+
+	client := NewGitHubAPI("")
+	client.BaseURL = server.URL
+	client.TokenSource = source
+
+	_, err := client.GetOpenPullRequests(context.Background(), "owner", "repo")
+	require.NoError(t, err)
+	assert.Equal(t, "token ghs_token1", <-authHeaders)
+
+	// 56 minutes later the token is within the refresh margin of its one-hour expiry
+	now = now.Add(56 * time.Minute)
+	_, err = client.GetOpenPullRequests(context.Background(), "owner", "repo")
+	require.NoError(t, err)
+	assert.Equal(t, "token ghs_token2", <-authHeaders)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&exchanges))
+}
+
+func TestGitHubAppTokenSource_ExchangeFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"Bad credentials"}`))
+	}))
+	defer server.Close()
+
+	source := NewGitHubAppTokenSource(42, 99, generateTestKey(t))
+	source.BaseURL = server.URL
+
+	client := NewGitHubAPI("")
+	client.BaseURL = server.URL
+	client.TokenSource = source
+
+	_, err := client.GetOpenPullRequests(context.Background(), "owner", "repo")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 401")
+}
+
+func TestLoadPrivateKey(t *testing.T) {
+	key := generateTestKey(t)
+	dir := t.TempDir()
+
+	pkcs1 := filepath.Join(dir, "pkcs1.pem")
+	require.NoError(t, os.WriteFile(pkcs1, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0600))
+	loaded, err := LoadPrivateKey(pkcs1)
+	require.NoError(t, err)
+	assert.True(t, key.Equal(loaded))
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	pkcs8 := filepath.Join(dir, "pkcs8.pem")
+	require.NoError(t, os.WriteFile(pkcs8, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600))
+	loaded, err = LoadPrivateKey(pkcs8)
+	require.NoError(t, err)
+	assert.True(t, key.Equal(loaded))
+
+	garbage := filepath.Join(dir, "garbage.pem")
+	require.NoError(t, os.WriteFile(garbage, []byte("not a key"), 0600))
+	_, err = LoadPrivateKey(garbage)
+	assert.Error(t, err)
+
+	_, err = LoadPrivateKey(filepath.Join(dir, "missing.pem"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// statusError describes a GitHub API response with an unexpected status code as an
+// *APIError, or a *RateLimitError if GitHub rejected the request for exceeding the rate limit.
+// For a 403 to an authenticated request that isn't a rate limit, it adds a hint about
+// the permission or scope the token is likely missing, based on GitHub's headers:
+//   - X-Accepted-GitHub-Permissions: what the endpoint needs from fine-grained tokens and
+//     GitHub Apps (e.g., "pull_requests=read")
+//   - X-Accepted-OAuth-Scopes and X-OAuth-Scopes: the scopes the endpoint accepts, and the
+//     scopes a classic token has
+//
+// The hint is also logged as a warning the first time it occurs for this client.
+func (g *GitHubAPI) statusError(resp *http.Response, body []byte) error {
+	err := newStatusError("github", resp, body)
+
+	hint := scopeHint(resp)
+	if hint == "" {
+		return err
+	}
+	g.scopeWarning.Do(func() {
+		log.Warn().Str("url", resp.Request.URL.Redacted()).Msg("GitHub denied access: " + hint)
+	})
+	if apiErr, ok := err.(*APIError); ok {
+		apiErr.Hint = hint
+	}
+	return err
+}
+
+// scopeHint explains a 403 caused by missing token permissions, or returns "" if the
+// response isn't one (not a 403, unauthenticated, or rate limited).
+func scopeHint(resp *http.Response) string {
+	if resp.StatusCode != http.StatusForbidden || resp.Request == nil || resp.Request.Header.Get("Authorization") == "" {
+		return ""
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		return ""
+	}
+
+	if permissions := strings.TrimSpace(resp.Header.Get("X-Accepted-GitHub-Permissions")); permissions != "" {
+		return fmt.Sprintf("the token is likely missing a permission; this endpoint requires %s "+
+			"(for a fine-grained token, grant it under \"Repository permissions\")", permissions)
+	}
+
+	accepted := strings.TrimSpace(resp.Header.Get("X-Accepted-OAuth-Scopes"))
+	granted, hasGranted := resp.Header["X-Oauth-Scopes"]
+	switch {
+	case accepted != "" && hasGranted:
+		has := strings.TrimSpace(strings.Join(granted, ","))
+		if has == "" {
+			has = "none"
+		}
+		return fmt.Sprintf("the token is likely missing a scope; this endpoint accepts %q but the token has %q", accepted, has)
+	case accepted != "":
+		return fmt.Sprintf("the token is likely missing a scope or permission; this endpoint accepts %q "+
+			"(a fine-grained token needs \"Pull requests\" and \"Contents\" read access to the repository)", accepted)
+	case hasGranted:
+		// Fine-grained tokens don't report scopes; a classic token's scopes are listed here
+		return fmt.Sprintf("the token may lack access to this resource (token scopes: %q)", strings.Join(granted, ","))
+	}
+	return ""
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// forbiddenServer responds 403 to every request with the given headers.
+func forbiddenServer(t *testing.T, headers map[string]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for k, v := range headers {
+			w.Header().Set(k, v)
+		}
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"Resource not accessible by personal access token"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGitHubAPI_Forbidden_ScopeHints(t *testing.T) {
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		token    string
+		wantHint string
+	}{
+		{
+			name:     "fine-grained token missing permission",
+			headers:  map[string]string{"X-Accepted-GitHub-Permissions": "pull_requests=read"},
+			token:    "github_pat_test",
+			wantHint: "the token is likely missing a permission; this endpoint requires pull_requests=read",
+		},
+		{
+			name:     "classic token missing scope",
+			headers:  map[string]string{"X-Accepted-OAuth-Scopes": "repo", "X-OAuth-Scopes": "read:org, gist"},
+			token:    "ghp_test",
+			wantHint: `this endpoint accepts "repo" but the token has "read:org, gist"`,
+		},
+		{
+			name:     "token without scopes",
+			headers:  map[string]string{"X-Accepted-OAuth-Scopes": "repo", "X-OAuth-Scopes": ""},
+			token:    "ghp_test",
+			wantHint: `this endpoint accepts "repo" but the token has "none"`,
+		},
+		{
+			name:     "only accepted scopes",
+			headers:  map[string]string{"X-Accepted-OAuth-Scopes": "repo"},
+			token:    "github_pat_test",
+			wantHint: `a fine-grained token needs "Pull requests" and "Contents" read access`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := forbiddenServer(t, tt.headers)
+			api := &GitHubAPI{BaseURL: server.URL, Token: tt.token}
+
+			_, err := api.GetOpenPullRequests(context.Background(), "owner", "repo")
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "github api request failed with status 403")
+			assert.Contains(t, err.Error(), tt.wantHint)
+		})
+	}
+}
+
+func TestGitHubAPI_Forbidden_NoHint(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		token   string
+	}{
+		{
+			name:    "unauthenticated",
+			headers: map[string]string{"X-Accepted-OAuth-Scopes": "repo"},
+		},
+		{
+			name:    "rate limited",
+			headers: map[string]string{"X-Accepted-OAuth-Scopes": "repo", "X-OAuth-Scopes": "", "X-RateLimit-Remaining": "0"},
+			token:   "ghp_test",
+		},
+		{
+			name:  "no scope headers",
+			token: "ghp_test",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := forbiddenServer(t, tt.headers)
+			api := &GitHubAPI{BaseURL: server.URL, Token: tt.token}
+
+			_, err := api.GetCommitStatus(context.Background(), "owner", "repo", "abc123")
+
+			require.Error(t, err)
+			assert.NotContains(t, err.Error(), "the token")
+		})
+	}
+}
@@ -0,0 +1,362 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// GitHubGraphQLAPI is a GitHubClient that lists open PRs through GitHub's GraphQL API,
+// fetching each PR's head commit status and check suites in the same query. Looking up
+// the CI of a listed PR (GetCommitStatus, GetCheckSuites) then needs no further requests,
+// where the REST API takes two per stale PR. Everything else goes through the embedded
+// REST client.
+//
+// The GraphQL API requires authentication (a token or GitHub App).
+type GitHubGraphQLAPI struct {
+	*GitHubAPI
+
+	// mu guards ci
+	mu sync.Mutex
+
+	// ci holds the CI results fetched with the last PR list of each repository,
+	// keyed by "owner/repo" and then by head commit SHA
+	ci map[string]map[string]graphQLCI
+}
+
+// graphQLCI is the CI of a commit as fetched alongside its PR.
+type graphQLCI struct {
+	status *CommitStatus
+	suites *CheckSuitesResponse
+}
+
+// NewGitHubGraphQLAPI creates a GraphQL client that authenticates, retries and falls
+// back to REST like rest.
+func NewGitHubGraphQLAPI(rest *GitHubAPI) *GitHubGraphQLAPI {
+	return &GitHubGraphQLAPI{
+		GitHubAPI: rest,
+		ci:        make(map[string]map[string]graphQLCI),
+	}
+}
+
+// openPullRequestsQuery lists a page of open PRs with their head commit's CI. The nested
+// connections are kept small, since GitHub limits the nodes a query may request.
+const openPullRequestsQuery = `query($owner: String!, $repo: String!, $cursor: String) {
+  repository(owner: $owner, name: $repo) {
+    pullRequests(states: OPEN, first: 50, after: $cursor) {
+      pageInfo { hasNextPage endCursor }
+      nodes {
+        number
+        title
+        url
+        createdAt
+        updatedAt
+        isDraft
+        author { login __typename }
+        assignees(first: 20) { nodes { login } }
+        labels(first: 20) { nodes { name } }
+        reviewRequests(first: 20) { nodes { requestedReviewer { ... on User { login } } } }
+        headRefOid
+        commits(last: 1) {
+          nodes {
+            commit {
+              status { state contexts { state context targetUrl } }
+              checkSuites(first: 20) { nodes { databaseId status conclusion app { name } } }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// graphQLPullRequests is the data returned for openPullRequestsQuery.
+type graphQLPullRequests struct {
+	Repository *struct {
+		PullRequests struct {
+			PageInfo struct {
+				HasNextPage bool   `json:"hasNextPage"`
+				EndCursor   string `json:"endCursor"`
+			} `json:"pageInfo"`
+			Nodes []graphQLPullRequest `json:"nodes"`
+		} `json:"pullRequests"`
+	} `json:"repository"`
+}
+
+// graphQLPullRequest is a PR as returned by openPullRequestsQuery.
+type graphQLPullRequest struct {
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	IsDraft   bool      `json:"isDraft"`
+
+	// Author is null for a deleted account
+	Author *struct {
+		Login string `json:"login"`
+		// Typename is the account type, as in the REST API ("User", "Bot", ...)
+		Typename string `json:"__typename"`
+	} `json:"author"`
+
+	Assignees struct {
+		Nodes []User `json:"nodes"`
+	} `json:"assignees"`
+	Labels struct {
+		Nodes []Label `json:"nodes"`
+	} `json:"labels"`
+	ReviewRequests struct {
+		Nodes []struct {
+			// RequestedReviewer has no login for a team
+			RequestedReviewer User `json:"requestedReviewer"`
+		} `json:"nodes"`
+	} `json:"reviewRequests"`
+
+	HeadRefOid string `json:"headRefOid"`
+	Commits    struct {
+		Nodes []struct {
+			Commit struct {
+				// Status is null when no commit status was reported
+				Status *struct {
+					State    string `json:"state"`
+					Contexts []struct {
+						State     string `json:"state"`
+						Context   string `json:"context"`
+						TargetURL string `json:"targetUrl"`
+					} `json:"contexts"`
+				} `json:"status"`
+				CheckSuites struct {
+					Nodes []struct {
+						DatabaseID int64  `json:"databaseId"`
+						Status     string `json:"status"`
+						Conclusion string `json:"conclusion"`
+						App        *App   `json:"app"`
+					} `json:"nodes"`
+				} `json:"checkSuites"`
+			} `json:"commit"`
+		} `json:"nodes"`
+	} `json:"commits"`
+}
+
+// pullRequest converts p to the REST API's representation.
+func (p graphQLPullRequest) pullRequest() PullRequest {
+	pr := PullRequest{
+		Number:    p.Number,
+		Title:     p.Title,
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+		Draft:     p.IsDraft,
+		HTMLURL:   p.URL,
+		Assignees: p.Assignees.Nodes,
+		Labels:    p.Labels.Nodes,
+		Head:      PRHead{SHA: p.HeadRefOid},
+	}
+	if p.Author != nil {
+		pr.User = User{Login: p.Author.Login, Type: p.Author.Typename}
+	}
+	for _, request := range p.ReviewRequests.Nodes {
+		if request.RequestedReviewer.Login != "" {
+			pr.RequestedReviewers = append(pr.RequestedReviewers, request.RequestedReviewer)
+		}
+	}
+	return pr
+}
+
+// ci converts the CI of p's head commit to the REST API's representation, where states
+// and conclusions are lowercase and a commit without statuses is "pending".
+func (p graphQLPullRequest) ci() graphQLCI {
+	ci := graphQLCI{
+		status: &CommitStatus{State: "pending"},
+		suites: &CheckSuitesResponse{},
+	}
+	if len(p.Commits.Nodes) == 0 {
+		return ci
+	}
+	commit := p.Commits.Nodes[0].Commit
+
+	if commit.Status != nil {
+		ci.status.State = strings.ToLower(commit.Status.State)
+		for _, context := range commit.Status.Contexts {
+			ci.status.Statuses = append(ci.status.Statuses, Status{
+				State:     strings.ToLower(context.State),
+				Context:   context.Context,
+				TargetURL: context.TargetURL,
+			})
+		}
+	}
+	for _, suite := range commit.CheckSuites.Nodes {
+		checkSuite := CheckSuite{
+			ID:         suite.DatabaseID,
+			Status:     strings.ToLower(suite.Status),
+			Conclusion: strings.ToLower(suite.Conclusion),
+		}
+		if suite.App != nil {
+			checkSuite.App = *suite.App
+		}
+		ci.suites.CheckSuites = append(ci.suites.CheckSuites, checkSuite)
+	}
+	ci.suites.TotalCount = len(ci.suites.CheckSuites)
+	return ci
+}
+
+// GetOpenPullRequests fetches all open pull requests of a repository, along with the CI
+// of their head commits, which GetCommitStatus and GetCheckSuites then return.
+func (g *GitHubGraphQLAPI) GetOpenPullRequests(ctx context.Context, owner, repo string) ([]PullRequest, error) {
+	var allPRs []PullRequest
+	ci := make(map[string]graphQLCI)
+
+	variables := map[string]any{"owner": owner, "repo": repo}
+	for {
+		var data graphQLPullRequests
+		if err := g.query(ctx, openPullRequestsQuery, variables, &data); err != nil {
+			return nil, fmt.Errorf("failed to fetch pull requests: %w", err)
+		}
+		if data.Repository == nil {
+			return nil, &graphQLError{Type: graphQLNotFound, Message: fmt.Sprintf("Could not resolve to a Repository with the name '%s/%s'.", owner, repo)}
+		}
+
+		page := data.Repository.PullRequests
+		for _, node := range page.Nodes {
+			allPRs = append(allPRs, node.pullRequest())
+			ci[node.HeadRefOid] = node.ci()
+		}
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = page.PageInfo.EndCursor
+	}
+
+	g.mu.Lock()
+	g.ci[repoKey(owner, repo)] = ci
+	g.mu.Unlock()
+
+	return allPRs, nil
+}
+
+// GetCommitStatus returns the commit status fetched with the repository's open PRs, or
+// fetches it through the REST API for a commit that isn't the head of one.
+func (g *GitHubGraphQLAPI) GetCommitStatus(ctx context.Context, owner, repo, ref string) (*CommitStatus, error) {
+	if ci, ok := g.cachedCI(owner, repo, ref); ok {
+		status := *ci.status
+		return &status, nil
+	}
+	return g.GitHubAPI.GetCommitStatus(ctx, owner, repo, ref)
+}
+
+// GetCheckSuites returns the check suites fetched with the repository's open PRs, or
+// fetches them through the REST API for a commit that isn't the head of one.
+func (g *GitHubGraphQLAPI) GetCheckSuites(ctx context.Context, owner, repo, ref string) (*CheckSuitesResponse, error) {
+	if ci, ok := g.cachedCI(owner, repo, ref); ok {
+		suites := *ci.suites
+		return &suites, nil
+	}
+	return g.GitHubAPI.GetCheckSuites(ctx, owner, repo, ref)
+}
+
+// cachedCI returns the CI fetched for commit ref with the open PRs of owner/repo.
+func (g *GitHubGraphQLAPI) cachedCI(owner, repo, ref string) (graphQLCI, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	ci, ok := g.ci[repoKey(owner, repo)][ref]
+	return ci, ok
+}
+
+// repoKey identifies a repository regardless of case, e.g. "owner/repo".
+func repoKey(owner, repo string) string {
+	return strings.ToLower(owner + "/" + repo)
+}
+
+// graphQLURL returns the GraphQL endpoint for the REST BaseURL: "https://api.github.com/graphql"
+// on github.com, and "https://HOST/api/graphql" on GitHub Enterprise Server ("https://HOST/api/v3").
+func (g *GitHubGraphQLAPI) graphQLURL() string {
+	return strings.TrimSuffix(strings.TrimSuffix(g.BaseURL, "/"), "/v3") + "/graphql"
+}
+
+// graphQLResponse is the envelope of every GraphQL response.
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []graphQLError  `json:"errors"`
+}
+
+// GraphQL error types GitHub returns that map to the REST API's errors.
+const (
+	graphQLNotFound    = "NOT_FOUND"
+	graphQLRateLimited = "RATE_LIMITED"
+)
+
+// graphQLError is an error GitHub reported in a GraphQL response (which has status 200).
+// errors.Is(err, ErrNotFound) holds for a repository that doesn't exist.
+type graphQLError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+func (e *graphQLError) Error() string {
+	if e.Type == "" {
+		return "github graphql request failed: " + e.Message
+	}
+	return fmt.Sprintf("github graphql request failed (%s): %s", e.Type, e.Message)
+}
+
+// Is makes errors.Is(err, ErrNotFound) hold for a NOT_FOUND error.
+func (e *graphQLError) Is(target error) bool {
+	return target == ErrNotFound && e.Type == graphQLNotFound
+}
+
+// query runs a GraphQL query and decodes its data into out. An error in the response is
+// returned as a *graphQLError, or a *RateLimitError if GitHub rate limited the query.
+func (g *GitHubGraphQLAPI) query(ctx context.Context, query string, variables map[string]any, out any) error {
+	payload, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		return fmt.Errorf("failed to marshal query: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", g.graphQLURL(), bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	if err := g.setCommonHeaders(req); err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := DoWithRetry(ctx, clientWithTimeout(g.Timeout), req, g.retryConfig())
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return g.statusError(resp, body)
+	}
+
+	var result graphQLResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %v", err)
+	}
+	if len(result.Errors) > 0 {
+		first := result.Errors[0]
+		if first.Type == graphQLRateLimited {
+			return &RateLimitError{
+				APIError: APIError{Service: "github", StatusCode: resp.StatusCode, Body: first.Message},
+				Reset:    rateLimitReset(resp),
+			}
+		}
+		return &first
+	}
+
+	if err := json.Unmarshal(result.Data, out); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %v", err)
+	}
+	return nil
+}
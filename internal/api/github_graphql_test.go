@@ -0,0 +1,278 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// graphQLRequest is the body of a GraphQL request.
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+func decodeGraphQLRequest(t *testing.T, r *http.Request) graphQLRequest {
+	t.Helper()
+	body, err := io.ReadAll(r.Body)
+	require.NoError(t, err)
+	var req graphQLRequest
+	require.NoError(t, json.Unmarshal(body, &req))
+	return req
+}
+
+const graphQLPullRequestsPage = `{
+  "data": {
+    "repository": {
+      "pullRequests": {
+        "pageInfo": {"hasNextPage": false, "endCursor": "Y3Vyc29yOjE="},
+        "nodes": [
+          {
+            "number": 42,
+            "title": "Add feature",
+            "url": "https://github.com/owner/repo/pull/42",
+            "createdAt": "2024-01-01T00:00:00Z",
+            "updatedAt": "2024-01-02T00:00:00Z",
+            "isDraft": true,
+            "author": {"login": "alice", "__typename": "User"},
+            "assignees": {"nodes": [{"login": "bob"}]},
+            "labels": {"nodes": [{"name": "needs-review"}]},
+            "reviewRequests": {"nodes": [{"requestedReviewer": {"login": "carol"}}, {"requestedReviewer": {}}]},
+            "headRefOid": "abc123",
+            "commits": {"nodes": [{"commit": {
+              "status": {"state": "FAILURE", "contexts": [
+                {"state": "FAILURE", "context": "ci/jenkins", "targetUrl": "https://ci.example.com/1"}
+              ]},
+              "checkSuites": {"nodes": [
+                {"databaseId": 7, "status": "COMPLETED", "conclusion": "TIMED_OUT", "app": {"name": "GitHub Actions"}}
+              ]}
+            }}]}
+          },
+          {
+            "number": 43,
+            "title": "Fix bug",
+            "url": "https://github.com/owner/repo/pull/43",
+            "createdAt": "2024-01-03T00:00:00Z",
+            "updatedAt": "2024-01-04T00:00:00Z",
+            "isDraft": false,
+            "author": null,
+            "assignees": {"nodes": []},
+            "labels": {"nodes": []},
+            "reviewRequests": {"nodes": []},
+            "headRefOid": "def456",
+            "commits": {"nodes": [{"commit": {"status": null, "checkSuites": {"nodes": []}}}]}
+          }
+        ]
+      }
+    }
+  }
+}`
+
+func TestGitHubGraphQLAPI_GetOpenPullRequests_FetchesCIInOneRoundTrip(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/graphql", r.URL.Path)
+		assert.Equal(t, "token ghp_test", r.Header.Get("Authorization"))
+
+		req := decodeGraphQLRequest(t, r)
+		assert.Contains(t, req.Query, "pullRequests(states: OPEN")
+		assert.Equal(t, "owner", req.Variables["owner"])
+		assert.Equal(t, "repo", req.Variables["repo"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(graphQLPullRequestsPage))
+	}))
+	defer server.Close()
+
+	api := NewGitHubGraphQLAPI(&GitHubAPI{BaseURL: server.URL, Token: "ghp_test"})
+
+	prs, err := api.GetOpenPullRequests(context.Background(), "owner", "repo")
+	require.NoError(t, err)
+	require.Len(t, prs, 2)
+
+	pr := prs[0]
+	assert.Equal(t, 42, pr.Number)
+	assert.Equal(t, "Add feature", pr.Title)
+	assert.Equal(t, "https://github.com/owner/repo/pull/42", pr.HTMLURL)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), pr.CreatedAt)
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), pr.UpdatedAt)
+	assert.True(t, pr.Draft)
+	assert.Equal(t, User{Login: "alice", Type: "User"}, pr.User)
+	assert.Equal(t, []User{{Login: "bob"}}, pr.Assignees)
+	assert.Equal(t, []Label{{Name: "needs-review"}}, pr.Labels)
+	assert.Equal(t, []User{{Login: "carol"}}, pr.RequestedReviewers, "team review requests have no login")
+	assert.Equal(t, "abc123", pr.Head.SHA)
+	assert.Empty(t, prs[1].User.Login, "deleted authors are null")
+
+	// CI comes from the same response
+	status, err := api.GetCommitStatus(context.Background(), "owner", "repo", "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, &CommitStatus{State: "failure", Statuses: []Status{
+		{State: "failure", Context: "ci/jenkins", TargetURL: "https://ci.example.com/1"},
+	}}, status)
+
+	suites, err := api.GetCheckSuites(context.Background(), "Owner", "Repo", "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, &CheckSuitesResponse{TotalCount: 1, CheckSuites: []CheckSuite{
+		{ID: 7, Status: "completed", Conclusion: "timed_out", App: App{Name: "GitHub Actions"}},
+	}}, suites)
+
+	// A commit without statuses is pending, as in the REST API
+	status, err = api.GetCommitStatus(context.Background(), "owner", "repo", "def456")
+	require.NoError(t, err)
+	assert.Equal(t, "pending", status.State)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func TestGitHubGraphQLAPI_GetOpenPullRequests_Paginates(t *testing.T) {
+	var cursors []any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := decodeGraphQLRequest(t, r)
+		cursors = append(cursors, req.Variables["cursor"])
+
+		w.Header().Set("Content-Type", "application/json")
+		if req.Variables["cursor"] == nil {
+			_, _ = w.Write([]byte(`{"data": {"repository": {"pullRequests": {
+				"pageInfo": {"hasNextPage": true, "endCursor": "page2"},
+				"nodes": [{"number": 1, "headRefOid": "sha1"}]}}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {"repository": {"pullRequests": {
+			"pageInfo": {"hasNextPage": false, "endCursor": "page3"},
+			"nodes": [{"number": 2, "headRefOid": "sha2"}]}}}}`))
+	}))
+	defer server.Close()
+
+	api := NewGitHubGraphQLAPI(&GitHubAPI{BaseURL: server.URL, Token: "ghp_test"})
+
+	prs, err := api.GetOpenPullRequests(context.Background(), "owner", "repo")
+	require.NoError(t, err)
+	require.Len(t, prs, 2)
+	assert.Equal(t, 1, prs[0].Number)
+	assert.Equal(t, 2, prs[1].Number)
+	assert.Equal(t, []any{nil, "page2"}, cursors)
+}
+
+func TestGitHubGraphQLAPI_GetOpenPullRequests_RetriesWithBody(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The query must be sent again on every attempt
+		req := decodeGraphQLRequest(t, r)
+		assert.NotEmpty(t, req.Query)
+
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {"repository": {"pullRequests": {"nodes": [{"number": 5}]}}}}`))
+	}))
+	defer server.Close()
+
+	api := NewGitHubGraphQLAPI(&GitHubAPI{
+		BaseURL:     server.URL,
+		Token:       "ghp_test",
+		RetryConfig: &RetryConfig{MaxRetries: 1, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, BackoffMultiplier: 1},
+	})
+
+	prs, err := api.GetOpenPullRequests(context.Background(), "owner", "repo")
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+}
+
+func TestGitHubGraphQLAPI_GetOpenPullRequests_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		headers map[string]string
+		check   func(t *testing.T, err error)
+	}{
+		{
+			name:   "repository not found",
+			status: http.StatusOK,
+			body:   `{"data": {"repository": null}, "errors": [{"type": "NOT_FOUND", "message": "Could not resolve to a Repository with the name 'owner/repo'."}]}`,
+			check: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, ErrNotFound)
+				assert.ErrorContains(t, err, "Could not resolve to a Repository")
+			},
+		},
+		{
+			name:    "rate limited",
+			status:  http.StatusOK,
+			body:    `{"errors": [{"type": "RATE_LIMITED", "message": "API rate limit exceeded"}]}`,
+			headers: map[string]string{"X-RateLimit-Reset": "1704110400"},
+			check: func(t *testing.T, err error) {
+				var rateLimit *RateLimitError
+				require.ErrorAs(t, err, &rateLimit)
+				assert.Equal(t, time.Unix(1704110400, 0), rateLimit.Reset)
+			},
+		},
+		{
+			name:   "unauthorized",
+			status: http.StatusUnauthorized,
+			body:   `{"message": "Bad credentials"}`,
+			check: func(t *testing.T, err error) {
+				var apiErr *APIError
+				require.ErrorAs(t, err, &apiErr)
+				assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for key, value := range tt.headers {
+					w.Header().Set(key, value)
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			api := NewGitHubGraphQLAPI(&GitHubAPI{BaseURL: server.URL, Token: "ghp_test"})
+
+			_, err := api.GetOpenPullRequests(context.Background(), "owner", "repo")
+			require.Error(t, err)
+			tt.check(t, err)
+		})
+	}
+}
+
+func TestGitHubGraphQLAPI_GetCommitStatus_FallsBackToREST(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/owner/repo/commits/unknown/status", r.URL.Path)
+		_ = json.NewEncoder(w).Encode(CommitStatus{State: "success"})
+	}))
+	defer server.Close()
+
+	api := NewGitHubGraphQLAPI(&GitHubAPI{BaseURL: server.URL, Token: "ghp_test"})
+
+	status, err := api.GetCommitStatus(context.Background(), "owner", "repo", "unknown")
+	require.NoError(t, err)
+	assert.Equal(t, "success", status.State)
+}
+
+func TestGitHubGraphQLAPI_GraphQLURL(t *testing.T) {
+	assert.Equal(t, "https://api.github.com/graphql", NewGitHubGraphQLAPI(NewGitHubAPI("")).graphQLURL())
+	assert.Equal(t, "https://github.example.com/api/graphql",
+		NewGitHubGraphQLAPI(&GitHubAPI{BaseURL: "https://github.example.com/api/v3/"}).graphQLURL())
+}
+
+func TestGraphQLError_Is(t *testing.T) {
+	assert.True(t, errors.Is(&graphQLError{Type: "NOT_FOUND"}, ErrNotFound))
+	assert.False(t, errors.Is(&graphQLError{Type: "FORBIDDEN"}, ErrNotFound))
+}
@@ -6,9 +6,18 @@ import "context"
 // This allows for easy mocking in tests.
 type GitHubClient interface {
 	GetOpenPullRequests(ctx context.Context, owner, repo string) ([]PullRequest, error)
+	GetPullRequest(ctx context.Context, owner, repo string, number int) (*PullRequest, error)
 	GetCommitStatus(ctx context.Context, owner, repo, ref string) (*CommitStatus, error)
 	GetCheckSuites(ctx context.Context, owner, repo, ref string) (*CheckSuitesResponse, error)
+	GetPullRequestReviews(ctx context.Context, owner, repo string, number int) ([]Review, error)
+	GetOpenIssues(ctx context.Context, owner, repo, assignee string) ([]Issue, error)
+	GetOrgRepositories(ctx context.Context, org string) ([]Repository, error)
+	GetAuthenticatedUser(ctx context.Context) (*User, error)
+	GetFailedWorkflowRuns(ctx context.Context, owner, repo, branch string) ([]WorkflowRun, error)
 }
 
 // Ensure GitHubAPI implements GitHubClient interface
 var _ GitHubClient = (*GitHubAPI)(nil)
+
+// Ensure GitHubGraphQLAPI implements GitHubClient interface
+var _ GitHubClient = (*GitHubGraphQLAPI)(nil)
@@ -1,10 +1,13 @@
 package api
 
 import (
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -48,7 +51,7 @@ func TestGitHubAPI_GetOpenPullRequests_Success(t *testing.T) {
 
 		// Verify headers
 		assert.Equal(t, "application/vnd.github.v3+json", r.Header.Get("Accept"))
-		assert.Equal(t, "watchdog-app", r.Header.Get("User-Agent"))
+		assert.Equal(t, UserAgent, r.Header.Get("User-Agent"))
 
 		// Send mock response
 		prs := []PullRequest{
@@ -105,6 +108,60 @@ func TestGitHubAPI_GetOpenPullRequests_Success(t *testing.T) {
 	assert.True(t, prs[1].Draft)
 }
 
+func TestGitHubAPI_GetOpenPullRequests_RealisticPayload(t *testing.T) {
+	// Trimmed from a real GET /repos/{owner}/{repo}/pulls response
+	payload := `[{
+		"url": "https://api.github.com/repos/octo-org/hello-world/pulls/1347",
+		"html_url": "https://github.com/octo-org/hello-world/pull/1347",
+		"number": 1347,
+		"state": "open",
+		"title": "Amazing new feature",
+		"user": {"login": "octocat", "id": 1, "type": "User"},
+		"labels": [{"id": 208045946, "name": "bug", "color": "f29513"}],
+		"created_at": "2011-01-26T19:01:12Z",
+		"updated_at": "2011-01-27T19:01:12Z",
+		"assignees": [{"login": "hubot", "id": 2}],
+		"requested_reviewers": [{"login": "other_user", "id": 3}, {"login": "monalisa", "id": 4}],
+		"requested_teams": [{"id": 1, "name": "Justice League", "slug": "justice-league"}],
+		"head": {
+			"label": "octocat:new-topic",
+			"ref": "new-topic",
+			"sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+			"user": {"login": "octocat", "id": 1}
+		},
+		"base": {"label": "octo-org:main", "ref": "main", "sha": "9049f1265b7d61be4a8904a9a27120d2064dab3b"},
+		"draft": false
+	}]`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(payload))
+	}))
+	defer server.Close()
+
+	api := &GitHubAPI{BaseURL: server.URL}
+
+	prs, err := api.GetOpenPullRequests(context.Background(), "octo-org", "hello-world")
+
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	pr := prs[0]
+	assert.Equal(t, 1347, pr.Number)
+	assert.Equal(t, "6dcb09b5b57875f334f61aebed695e2e4193db5e", pr.Head.SHA)
+	assert.Equal(t, []User{{Login: "other_user"}, {Login: "monalisa"}}, pr.RequestedReviewers)
+	assert.Equal(t, []User{{Login: "hubot"}}, pr.Assignees)
+	assert.Equal(t, []Label{{Name: "bug"}}, pr.Labels)
+	assert.Equal(t, time.Date(2011, 1, 26, 19, 1, 12, 0, time.UTC), pr.CreatedAt)
+
+	// The fields survive a JSON round trip under the same names
+	encoded, err := json.Marshal(pr)
+	require.NoError(t, err)
+	assert.Contains(t, string(encoded), `"head":{"sha":"6dcb09b5b57875f334f61aebed695e2e4193db5e"}`)
+	assert.Contains(t, string(encoded), `"requested_reviewers":[{"login":"other_user"},{"login":"monalisa"}]`)
+	var decoded PullRequest
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, pr, decoded)
+}
+
 func TestGitHubAPI_GetOpenPullRequests_WithToken(t *testing.T) {
 	token := "ghp_test123"
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -198,6 +255,56 @@ func TestGitHubAPI_GetOpenPullRequests_NonOKStatus(t *testing.T) {
 	}
 }
 
+func TestGitHubAPI_GetOpenPullRequests_RetriesTransientErrors(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Fail twice with 503 before succeeding
+		if atomic.AddInt32(&attempts, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode([]PullRequest{{Number: 7, Title: "Retried PR"}})
+	}))
+	defer server.Close()
+
+	api := &GitHubAPI{
+		BaseURL: server.URL,
+		Token:   "",
+	}
+
+	prs, err := api.GetOpenPullRequests(context.Background(), "owner", "repo")
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	assert.Equal(t, 7, prs[0].Number)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
+
+func TestGitHubAPI_GetOpenPullRequests_RetriesExhausted(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"message":"Service Unavailable"}`))
+	}))
+	defer server.Close()
+
+	api := &GitHubAPI{
+		BaseURL:     server.URL,
+		RetryConfig: &RetryConfig{MaxRetries: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, BackoffMultiplier: 1},
+	}
+
+	_, err := api.GetOpenPullRequests(context.Background(), "owner", "repo")
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+	assert.Equal(t, `{"message":"Service Unavailable"}`, apiErr.Body)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
+
 func TestGitHubAPI_GetOpenPullRequests_InvalidJSON(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -220,6 +327,46 @@ func TestGitHubAPI_GetOpenPullRequests_InvalidJSON(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to unmarshal response")
 }
 
+func TestGitHubAPI_GetOpenPullRequests_GzipResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "gzip", r.Header.Get("Accept-Encoding"))
+
+		prs := []PullRequest{
+			{Number: 1, Title: "Compressed PR", User: User{Login: "alice"}},
+			{Number: 2, Title: "Another PR", User: User{Login: "bob"}},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		require.NoError(t, json.NewEncoder(gz).Encode(prs))
+		require.NoError(t, gz.Close())
+	}))
+	defer server.Close()
+
+	api := &GitHubAPI{BaseURL: server.URL}
+	prs, err := api.GetOpenPullRequests(context.Background(), "owner", "repo")
+
+	require.NoError(t, err)
+	require.Len(t, prs, 2)
+	assert.Equal(t, "Compressed PR", prs[0].Title)
+	assert.Equal(t, "bob", prs[1].User.Login)
+}
+
+func TestGitHubAPI_GetOpenPullRequests_MalformedGzip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = w.Write([]byte("definitely not gzip"))
+	}))
+	defer server.Close()
+
+	api := &GitHubAPI{BaseURL: server.URL}
+	prs, err := api.GetOpenPullRequests(context.Background(), "owner", "repo")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "gzip")
+	assert.Nil(t, prs)
+}
+
 func TestGitHubAPI_GetOpenPullRequests_ServerTimeout(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		time.Sleep(15 * time.Second) // Longer than the timeout
@@ -240,6 +387,239 @@ func TestGitHubAPI_GetOpenPullRequests_ServerTimeout(t *testing.T) {
 	assert.Nil(t, prs)
 }
 
+func TestGitHubAPI_GetOpenPullRequests_CancelledContext(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		_ = json.NewEncoder(w).Encode([]PullRequest{})
+	}))
+	defer server.Close()
+
+	api := &GitHubAPI{BaseURL: server.URL}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	prs, err := api.GetOpenPullRequests(ctx, "owner", "repo")
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, prs)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&requests), "no request should be made with a cancelled context")
+}
+
+func TestGitHubAPI_GetCommitStatus_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/repos/owner/repo/commits/abc123/status", r.URL.Path)
+		assert.Equal(t, "application/vnd.github.v3+json", r.Header.Get("Accept"))
+		assert.Equal(t, UserAgent, r.Header.Get("User-Agent"))
+		assert.Equal(t, "token ghp_test", r.Header.Get("Authorization"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"state":"failure","total_count":2,"statuses":[
+			{"state":"success","context":"ci/lint","target_url":"https://ci.example.com/lint/7"},
+			{"state":"failure","context":"ci/build","target_url":"https://ci.example.com/build/42","description":"Build failed"}
+		]}`))
+	}))
+	defer server.Close()
+
+	api := &GitHubAPI{BaseURL: server.URL, Token: "ghp_test"}
+
+	status, err := api.GetCommitStatus(context.Background(), "owner", "repo", "abc123")
+
+	require.NoError(t, err)
+	require.NotNil(t, status)
+	assert.Equal(t, "failure", status.State)
+	assert.Equal(t, []Status{
+		{State: "success", Context: "ci/lint", TargetURL: "https://ci.example.com/lint/7"},
+		{State: "failure", Context: "ci/build", TargetURL: "https://ci.example.com/build/42"},
+	}, status.Statuses)
+}
+
+func TestGitHubAPI_GetAuthenticatedUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/user", r.URL.Path)
+		assert.Equal(t, "token ghp_test", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"login":"octocat","id":1}`))
+	}))
+	defer server.Close()
+
+	api := &GitHubAPI{BaseURL: server.URL, Token: "ghp_test"}
+
+	user, err := api.GetAuthenticatedUser(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "octocat", user.Login)
+}
+
+func TestGitHubAPI_GetAuthenticatedUser_Unauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"Requires authentication"}`))
+	}))
+	defer server.Close()
+
+	api := &GitHubAPI{BaseURL: server.URL}
+
+	user, err := api.GetAuthenticatedUser(context.Background())
+
+	require.Error(t, err)
+	assert.Nil(t, user)
+	assert.Contains(t, err.Error(), "github api request failed with status 401")
+}
+
+func TestGitHubAPI_GetCommitStatus_NonOKStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"No commit found for SHA: abc123"}`))
+	}))
+	defer server.Close()
+
+	api := &GitHubAPI{BaseURL: server.URL}
+
+	status, err := api.GetCommitStatus(context.Background(), "owner", "repo", "abc123")
+
+	require.Error(t, err)
+	assert.Nil(t, status)
+	assert.Contains(t, err.Error(), "github api request failed with status 404")
+}
+
+func TestGitHubAPI_GetCommitStatus_InvalidJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`not json`))
+	}))
+	defer server.Close()
+
+	api := &GitHubAPI{BaseURL: server.URL}
+
+	status, err := api.GetCommitStatus(context.Background(), "owner", "repo", "abc123")
+
+	require.Error(t, err)
+	assert.Nil(t, status)
+	assert.Contains(t, err.Error(), "failed to unmarshal response")
+}
+
+func TestGitHubAPI_GetCheckSuites_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/repos/owner/repo/commits/abc123/check-suites", r.URL.Path)
+		assert.Equal(t, "application/vnd.github.v3+json", r.Header.Get("Accept"))
+		assert.Equal(t, UserAgent, r.Header.Get("User-Agent"))
+		assert.Equal(t, "token ghp_test", r.Header.Get("Authorization"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"total_count": 2,
+			"check_suites": [
+				{"id": 1, "status": "completed", "conclusion": "success", "app": {"name": "GitHub Actions"}},
+				{"id": 2, "status": "in_progress", "conclusion": null, "app": {"name": "CircleCI"}}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	api := &GitHubAPI{BaseURL: server.URL, Token: "ghp_test"}
+
+	suites, err := api.GetCheckSuites(context.Background(), "owner", "repo", "abc123")
+
+	require.NoError(t, err)
+	require.NotNil(t, suites)
+	assert.Equal(t, 2, suites.TotalCount)
+	require.Len(t, suites.CheckSuites, 2)
+	assert.Equal(t, int64(1), suites.CheckSuites[0].ID)
+	assert.Equal(t, "success", suites.CheckSuites[0].Conclusion)
+	assert.Equal(t, "GitHub Actions", suites.CheckSuites[0].App.Name)
+	assert.Equal(t, "in_progress", suites.CheckSuites[1].Status)
+	assert.Empty(t, suites.CheckSuites[1].Conclusion)
+}
+
+func TestGitHubAPI_GetPullRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/owner/repo/pulls/7", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"number": 7, "title": "Conflicted", "mergeable": false, "mergeable_state": "dirty"}`))
+	}))
+	defer server.Close()
+
+	api := &GitHubAPI{BaseURL: server.URL}
+
+	pr, err := api.GetPullRequest(context.Background(), "owner", "repo", 7)
+
+	require.NoError(t, err)
+	assert.Equal(t, 7, pr.Number)
+	require.NotNil(t, pr.Mergeable)
+	assert.False(t, *pr.Mergeable)
+	assert.True(t, pr.HasConflicts())
+	assert.False(t, PullRequest{MergeableState: "clean"}.HasConflicts())
+}
+
+func TestGitHubAPI_GetFailedWorkflowRuns(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/owner/repo/actions/runs", r.URL.Path)
+		assert.Equal(t, "failure", r.URL.Query().Get("status"))
+		assert.Equal(t, "release/v1", r.URL.Query().Get("branch"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"total_count": 1,
+			"workflow_runs": [
+				{"id": 30433642, "name": "Nightly", "run_number": 562, "event": "schedule",
+				 "head_branch": "release/v1", "head_sha": "acb5820", "conclusion": "failure",
+				 "html_url": "https://github.com/owner/repo/actions/runs/30433642",
+				 "created_at": "2024-01-02T03:04:05Z"}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	api := &GitHubAPI{BaseURL: server.URL}
+
+	runs, err := api.GetFailedWorkflowRuns(context.Background(), "owner", "repo", "release/v1")
+
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, int64(30433642), runs[0].ID)
+	assert.Equal(t, "Nightly", runs[0].Name)
+	assert.Equal(t, 562, runs[0].RunNumber)
+	assert.Equal(t, "schedule", runs[0].Event)
+	assert.Equal(t, "failure", runs[0].Conclusion)
+	assert.Equal(t, "https://github.com/owner/repo/actions/runs/30433642", runs[0].HTMLURL)
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), runs[0].CreatedAt)
+}
+
+func TestGitHubAPI_GetCheckSuites_NonOKStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"Resource not accessible by integration"}`))
+	}))
+	defer server.Close()
+
+	api := &GitHubAPI{BaseURL: server.URL}
+
+	suites, err := api.GetCheckSuites(context.Background(), "owner", "repo", "abc123")
+
+	require.Error(t, err)
+	assert.Nil(t, suites)
+	assert.Contains(t, err.Error(), "github api request failed with status 403")
+}
+
+func TestGitHubAPI_GetCheckSuites_InvalidJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"check_suites": "oops"}`))
+	}))
+	defer server.Close()
+
+	api := &GitHubAPI{BaseURL: server.URL}
+
+	suites, err := api.GetCheckSuites(context.Background(), "owner", "repo", "abc123")
+
+	require.Error(t, err)
+	assert.Nil(t, suites)
+	assert.Contains(t, err.Error(), "failed to unmarshal response")
+}
+
 func TestPullRequestJSON_Marshaling(t *testing.T) {
 	now := time.Now()
 	pr := PullRequest{
@@ -266,3 +646,151 @@ func TestPullRequestJSON_Marshaling(t *testing.T) {
 	assert.Equal(t, pr.User.Login, decoded.User.Login)
 	assert.Equal(t, pr.Draft, decoded.Draft)
 }
+
+func TestGitHubAPI_GetPullRequestReviews_Paginated(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/owner/repo/pulls/42/reviews", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", `<`+server.URL+`/repos/owner/repo/pulls/42/reviews?per_page=100&page=2>; rel="next"`)
+			_, _ = w.Write([]byte(`[{"user":{"login":"alice"},"state":"CHANGES_REQUESTED","submitted_at":"2024-01-01T10:00:00Z"}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"user":{"login":"alice"},"state":"APPROVED","submitted_at":"2024-01-02T10:00:00Z"}]`))
+	}))
+	defer server.Close()
+
+	api := &GitHubAPI{BaseURL: server.URL}
+
+	reviews, err := api.GetPullRequestReviews(context.Background(), "owner", "repo", 42)
+
+	require.NoError(t, err)
+	require.Len(t, reviews, 2)
+	assert.Equal(t, "alice", reviews[0].User.Login)
+	assert.Equal(t, ReviewStateChangesRequested, reviews[0].State)
+	assert.Equal(t, ReviewStateApproved, reviews[1].State)
+	assert.Equal(t, time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC), reviews[1].SubmittedAt)
+}
+
+func TestGitHubAPI_GetPullRequestReviews_NonOKStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+	}))
+	defer server.Close()
+
+	api := &GitHubAPI{BaseURL: server.URL}
+
+	reviews, err := api.GetPullRequestReviews(context.Background(), "owner", "repo", 42)
+
+	require.Error(t, err)
+	assert.Nil(t, reviews)
+	assert.Contains(t, err.Error(), "status 404")
+}
+
+func TestGitHubAPI_GetOpenIssues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/owner/repo/issues", r.URL.Path)
+		assert.Equal(t, "open", r.URL.Query().Get("state"))
+		assert.Equal(t, "alice", r.URL.Query().Get("assignee"))
+		assert.Equal(t, UserAgent, r.Header.Get("User-Agent"))
+
+		_, _ = w.Write([]byte(`[
+			{"number": 1, "title": "Bug", "assignees": [{"login": "alice"}]},
+			{"number": 2, "title": "A PR", "pull_request": {"url": "https://api.github.com/repos/owner/repo/pulls/2"}}
+		]`))
+	}))
+	defer server.Close()
+
+	api := &GitHubAPI{BaseURL: server.URL}
+
+	issues, err := api.GetOpenIssues(context.Background(), "owner", "repo", "alice")
+
+	require.NoError(t, err)
+	require.Len(t, issues, 2)
+	assert.Equal(t, 1, issues[0].Number)
+	assert.Equal(t, "alice", issues[0].Assignees[0].Login)
+	assert.False(t, issues[0].IsPullRequest())
+	assert.True(t, issues[1].IsPullRequest())
+}
+
+func TestGitHubAPI_GetOpenIssues_NoAssignee(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NotContains(t, r.URL.Query(), "assignee")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	api := &GitHubAPI{BaseURL: server.URL}
+
+	issues, err := api.GetOpenIssues(context.Background(), "owner", "repo", "")
+
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+}
+
+func TestGitHubAPI_GetOrgRepositories_Paginates(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/orgs/acme/repos", r.URL.Path)
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = w.Write([]byte(`[{"name": "legacy", "archived": true}]`))
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s/orgs/acme/repos?per_page=100&page=2>; rel="next"`, server.URL))
+		_, _ = w.Write([]byte(`[{"name": "api"}, {"name": "web"}]`))
+	}))
+	defer server.Close()
+
+	api := &GitHubAPI{BaseURL: server.URL}
+
+	repos, err := api.GetOrgRepositories(context.Background(), "acme")
+
+	require.NoError(t, err)
+	require.Len(t, repos, 3)
+	assert.Equal(t, "api", repos[0].Name)
+	assert.False(t, repos[0].Archived)
+	assert.Equal(t, "legacy", repos[2].Name)
+	assert.True(t, repos[2].Archived)
+}
+
+func TestGitHubAPI_GetOrgRepositories_DecodesStatusFlags(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"name": "active"}, {"name": "old", "archived": true}, {"name": "locked", "disabled": true}]`))
+	}))
+	defer server.Close()
+
+	api := &GitHubAPI{BaseURL: server.URL}
+
+	repos, err := api.GetOrgRepositories(context.Background(), "acme")
+
+	require.NoError(t, err)
+	require.Len(t, repos, 3)
+	assert.False(t, repos[0].Archived || repos[0].Disabled)
+	assert.True(t, repos[1].Archived)
+	assert.True(t, repos[2].Disabled)
+}
+
+func TestGitHubAPI_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(200 * time.Millisecond):
+		case <-r.Context().Done():
+			return
+		}
+		_ = json.NewEncoder(w).Encode([]PullRequest{})
+	}))
+	defer server.Close()
+
+	short := &GitHubAPI{BaseURL: server.URL, Timeout: 50 * time.Millisecond, RetryConfig: &RetryConfig{}}
+	_, err := short.GetOpenPullRequests(context.Background(), "owner", "repo")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Timeout")
+
+	long := &GitHubAPI{BaseURL: server.URL, Timeout: 5 * time.Second, RetryConfig: &RetryConfig{}}
+	prs, err := long.GetOpenPullRequests(context.Background(), "owner", "repo")
+	require.NoError(t, err)
+	assert.Empty(t, prs)
+}
@@ -1,11 +1,16 @@
 package api
 
 import (
+	"compress/gzip"
 	"context"
+	"crypto/tls"
+	"fmt"
 	"io"
 	"math"
 	"net"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog/log"
@@ -17,6 +22,7 @@ import (
 var DefaultHTTPClient = &http.Client{
 	Timeout: 30 * time.Second,
 	Transport: &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
 		MaxIdleConns:        100,
 		MaxIdleConnsPerHost: 10,
 		IdleConnTimeout:     90 * time.Second,
@@ -27,6 +33,32 @@ var DefaultHTTPClient = &http.Client{
 	},
 }
 
+// clientWithTimeout returns DefaultHTTPClient, or a client sharing its transport (and so
+// its connection pool, proxy and TLS settings) with a different per-request timeout.
+func clientWithTimeout(timeout time.Duration) *http.Client {
+	if timeout <= 0 || timeout == DefaultHTTPClient.Timeout {
+		return DefaultHTTPClient
+	}
+	return &http.Client{Transport: DefaultHTTPClient.Transport, Timeout: timeout}
+}
+
+// UserAgent is sent with every outbound API request so operators can identify
+// watchdog's traffic in their logs (GitHub also rejects requests without one).
+// The CLI sets it to "watchdog/<version>" at startup.
+var UserAgent = "watchdog/dev"
+
+// SetProxy sets how DefaultHTTPClient picks a proxy for each request (see proxy.Func).
+// It must be called before any requests are made.
+func SetProxy(proxy func(*http.Request) (*url.URL, error)) {
+	DefaultHTTPClient.Transport.(*http.Transport).Proxy = proxy
+}
+
+// SetTLSConfig sets the TLS settings DefaultHTTPClient uses (e.g., an internal CA for
+// GitHub Enterprise). nil restores the Go defaults. It must be called before any requests are made.
+func SetTLSConfig(cfg *tls.Config) {
+	DefaultHTTPClient.Transport.(*http.Transport).TLSClientConfig = cfg
+}
+
 // RetryConfig configures the retry behavior for HTTP requests.
 type RetryConfig struct {
 	// MaxRetries is the maximum number of retry attempts (0 = no retries)
@@ -40,6 +72,10 @@ type RetryConfig struct {
 
 	// BackoffMultiplier increases the backoff time after each retry
 	BackoffMultiplier float64
+
+	// Budget, if set, counts every attempt against an hourly request limit. Once it is
+	// used up, the request fails with a *BudgetExceededError instead of being sent.
+	Budget *Budget
 }
 
 // DefaultRetryConfig provides sensible defaults for retry behavior.
@@ -87,7 +123,8 @@ func isRetryableStatusCode(statusCode int) bool {
 //
 // Returns:
 //   - The HTTP response if successful
-//   - An error if all retries are exhausted or a non-retryable error occurs
+//   - An error if all retries are exhausted or a non-retryable error occurs. If no response
+//     was received, errors.Is(err, ErrNetwork) holds.
 func DoWithRetry(ctx context.Context, client *http.Client, req *http.Request, config RetryConfig) (*http.Response, error) {
 	var lastErr error
 	var resp *http.Response
@@ -100,36 +137,56 @@ func DoWithRetry(ctx context.Context, client *http.Client, req *http.Request, co
 		default:
 		}
 
+		if err := config.Budget.Take(); err != nil {
+			return nil, err
+		}
+
 		// Clone the request to ensure fresh body for retries
 		reqClone := req.Clone(ctx)
+		if req.GetBody != nil && attempt > 0 {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to reset request body: %v", err)
+			}
+			reqClone.Body = body
+		}
+		logRequest(reqClone)
 
 		// Execute the request
+		start := time.Now()
 		resp, lastErr = client.Do(reqClone)
+		logResponse(reqClone, resp, lastErr, time.Since(start))
 
 		// Success - return the response
 		if lastErr == nil && !isRetryableStatusCode(resp.StatusCode) {
+			if err := decodeResponseBody(resp); err != nil {
+				return nil, err
+			}
 			return resp, nil
 		}
 
 		// Check if we should retry
-		shouldRetry := false
-		if lastErr != nil && isRetryableError(lastErr) {
-			shouldRetry = true
-		} else if resp != nil && isRetryableStatusCode(resp.StatusCode) {
-			shouldRetry = true
-			// Close the response body before retrying to prevent resource leak
-			_, _ = io.Copy(io.Discard, resp.Body)
-			_ = resp.Body.Close()
-		}
+		shouldRetry := (lastErr != nil && isRetryableError(lastErr)) ||
+			(lastErr == nil && isRetryableStatusCode(resp.StatusCode))
 
-		// If not retryable or out of retries, return
+		// If not retryable or out of retries, return. The response of the last attempt is
+		// returned with its body intact, so callers can report the error it describes.
 		if !shouldRetry || attempt >= config.MaxRetries {
 			if lastErr != nil {
-				return nil, lastErr
+				return nil, &networkError{err: lastErr}
+			}
+			if err := decodeResponseBody(resp); err != nil {
+				return nil, err
 			}
 			return resp, nil
 		}
 
+		// Another attempt follows: close the response body to prevent a resource leak
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
 		// Calculate backoff with exponential increase
 		backoff := float64(config.InitialBackoff) * math.Pow(config.BackoffMultiplier, float64(attempt))
 		if backoff > float64(config.MaxBackoff) {
@@ -152,3 +209,128 @@ func DoWithRetry(ctx context.Context, client *http.Client, req *http.Request, co
 
 	return resp, lastErr
 }
+
+// gzipBody closes both the gzip reader and the underlying response body.
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (g *gzipBody) Close() error {
+	_ = g.Reader.Close()
+	return g.body.Close()
+}
+
+// decodeResponseBody transparently decompresses gzip-encoded responses.
+// Go's transport only does this itself when it added Accept-Encoding; clients that
+// request gzip explicitly (like GitHubAPI) get the raw stream, so it's unwrapped here.
+// A malformed gzip header is reported immediately; corruption later in the stream
+// surfaces as an error when the body is read.
+func decodeResponseBody(resp *http.Response) error {
+	if resp.Uncompressed || !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return nil
+	}
+
+	reader, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		_ = resp.Body.Close()
+		return fmt.Errorf("failed to decode gzip response: %v", err)
+	}
+
+	resp.Body = &gzipBody{Reader: reader, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return nil
+}
+
+// redactedHeaders are request headers whose values carry credentials.
+var redactedHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"X-Api-Key":     true,
+}
+
+// redactedQueryParams are URL query parameters whose values carry credentials.
+var redactedQueryParams = []string{"token", "key", "secret", "password", "signature"}
+
+// redactURL returns u with any password and credential-like query parameters
+// masked, suitable for logging.
+func redactURL(u *url.URL) string {
+	redacted := *u
+	query := redacted.Query()
+	changed := false
+	for name := range query {
+		lower := strings.ToLower(name)
+		for _, sensitive := range redactedQueryParams {
+			if strings.Contains(lower, sensitive) {
+				query.Set(name, "***")
+				changed = true
+				break
+			}
+		}
+	}
+	if changed {
+		redacted.RawQuery = strings.ReplaceAll(query.Encode(), "%2A%2A%2A", "***")
+	}
+	return redacted.Redacted()
+}
+
+// redactHeaders returns a copy of the headers that is safe to log. Credential headers
+// keep only their scheme (e.g., "Bearer ***") so the auth type is still visible.
+func redactHeaders(headers http.Header) map[string]string {
+	safe := make(map[string]string, len(headers))
+	for name, values := range headers {
+		value := strings.Join(values, ", ")
+		if redactedHeaders[http.CanonicalHeaderKey(name)] {
+			if scheme, _, found := strings.Cut(value, " "); found {
+				value = scheme + " ***"
+			} else {
+				value = "***"
+			}
+		}
+		safe[name] = value
+	}
+	return safe
+}
+
+// logRequest logs an outgoing request at debug level with credentials redacted.
+// The body is logged only if it can be re-read (GetBody is set), so it is never consumed.
+func logRequest(req *http.Request) {
+	event := log.Debug()
+	if !event.Enabled() {
+		return
+	}
+
+	event = event.
+		Str("method", req.Method).
+		Str("url", redactURL(req.URL)).
+		Interface("headers", redactHeaders(req.Header))
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(body)
+			_ = body.Close()
+			event = event.Str("body", string(data))
+		}
+	}
+	event.Msg("Sending HTTP request")
+}
+
+// logResponse logs the outcome of a request at debug level.
+func logResponse(req *http.Request, resp *http.Response, err error, elapsed time.Duration) {
+	event := log.Debug()
+	if !event.Enabled() {
+		return
+	}
+
+	event = event.
+		Str("method", req.Method).
+		Str("url", redactURL(req.URL)).
+		Dur("elapsed", elapsed)
+	if err != nil {
+		event.Err(err).Msg("HTTP request failed")
+		return
+	}
+	event.Int("status_code", resp.StatusCode).Msg("Received HTTP response")
+}
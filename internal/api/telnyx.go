@@ -6,7 +6,12 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog/log"
 )
 
 // TelnyxBalanceResponse represents the JSON structure returned by the Telnyx balance API.
@@ -19,20 +24,58 @@ type TelnyxBalanceResponse struct {
 		Balance string `json:"balance"`
 
 		// Currency is the currency code (e.g., "USD")
-		// Currently not used but included for completeness
 		Currency string `json:"currency"`
 	} `json:"data"`
 }
 
+// Balance is an account balance along with the currency it is denominated in.
+type Balance struct {
+	// Amount is the balance (e.g., 25.50)
+	Amount float64
+
+	// Currency is the ISO currency code reported by Telnyx (e.g., "USD").
+	// Empty if the API didn't report one.
+	Currency string
+}
+
 // TelnyxAPI is a client for interacting with the Telnyx REST API.
 // It handles authentication and provides methods for checking account balance.
 type TelnyxAPI struct {
-	// APIURL is the Telnyx API endpoint (usually https://api.telnyx.com/v2/balance)
+	// APIURL is the Telnyx balance endpoint (usually https://api.telnyx.com/v2/balance).
+	// The API's base URL (https://api.telnyx.com/v2) works too: "/balance" is appended
+	// unless the path already ends with it.
 	APIURL string
 
 	// APIKey is your Telnyx API key for authentication (starts with "KEY...")
 	// This is sent as a Bearer token in the Authorization header
 	APIKey string
+
+	// Budget counts this client's requests against an hourly limit.
+	// Nil uses the budget shared by all clients (see SetRequestBudget).
+	Budget *Budget
+
+	// logURLOnce logs the balance endpoint used on the first request (at info level if
+	// "/balance" was appended to APIURL, so the fix-up isn't silent)
+	logURLOnce sync.Once
+}
+
+// balanceURL returns the endpoint GetBalance requests: APIURL if its path already ends
+// with "/balance", otherwise APIURL with "/balance" appended to its path (so the base URL
+// "https://api.telnyx.com/v2" doesn't 404). A query string is kept as is.
+func (t *TelnyxAPI) balanceURL() string {
+	u, err := url.Parse(t.APIURL)
+	if err != nil {
+		return t.APIURL // Reported by http.NewRequestWithContext
+	}
+	path := strings.TrimRight(u.Path, "/")
+	if strings.HasSuffix(path, "/balance") {
+		return t.APIURL
+	}
+	u.Path = path + "/balance"
+	if u.RawPath != "" {
+		u.RawPath = strings.TrimRight(u.RawPath, "/") + "/balance"
+	}
+	return u.String()
 }
 
 // NewTelnyxAPI creates a new Telnyx API client.
@@ -55,26 +98,42 @@ func NewTelnyxAPI(apiURL, apiKey string) *TelnyxAPI {
 //   - ctx: Context for cancellation and deadline propagation
 //
 // Returns:
-//   - The account balance as a float64 (e.g., 25.50)
-//   - An error if the request fails, authentication fails, or the response is invalid
+//   - The account balance (e.g., 25.50) and its currency code (e.g., "USD")
+//   - An error if the request fails, authentication fails, or the response is invalid.
+//     An unexpected status is an *APIError (or *RateLimitError), and errors.Is(err, ErrNetwork)
+//     holds if Telnyx couldn't be reached.
 //
-// The balance is returned as a float so it can be easily compared with the threshold
+// The amount is returned as a float so it can be easily compared with the threshold
 // configured in the application settings.
-func (t *TelnyxAPI) GetBalance(ctx context.Context) (float64, error) {
+func (t *TelnyxAPI) GetBalance(ctx context.Context) (Balance, error) {
 	// Create GET request to the balance endpoint
-	req, err := http.NewRequestWithContext(ctx, "GET", t.APIURL, nil)
+	endpoint := t.balanceURL()
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
 	if err != nil {
-		return 0, fmt.Errorf("failed to create request: %v", err)
+		return Balance{}, fmt.Errorf("failed to create request: %v", err)
 	}
+	t.logURLOnce.Do(func() {
+		event := log.Debug()
+		if endpoint != t.APIURL {
+			event = log.Info().Str("api_url", t.APIURL)
+		}
+		event.Str("url", redactURL(req.URL)).Msg("Using Telnyx balance endpoint")
+	})
 
 	// Add authentication header - Telnyx uses Bearer token authentication
 	req.Header.Add("Authorization", "Bearer "+t.APIKey)
 	req.Header.Add("Accept", "application/json")
+	req.Header.Add("User-Agent", UserAgent)
 
 	// Execute the request with retry logic
-	resp, err := DoWithRetry(ctx, DefaultHTTPClient, req, DefaultRetryConfig)
+	retry := DefaultRetryConfig
+	retry.Budget = t.Budget
+	if retry.Budget == nil {
+		retry.Budget = requestBudget
+	}
+	resp, err := DoWithRetry(ctx, DefaultHTTPClient, req, retry)
 	if err != nil {
-		return 0, fmt.Errorf("failed to fetch balance: %v", err)
+		return Balance{}, fmt.Errorf("failed to fetch balance: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
@@ -82,28 +141,28 @@ func (t *TelnyxAPI) GetBalance(ctx context.Context) (float64, error) {
 	// Non-200 status could indicate authentication failure or API issues
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return 0, fmt.Errorf("api request failed with status %d: %s", resp.StatusCode, string(body))
+		return Balance{}, newStatusError("telnyx", resp, body)
 	}
 
 	// Read the response body
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return 0, fmt.Errorf("failed to read response body: %v", err)
+		return Balance{}, fmt.Errorf("failed to read response body: %v", err)
 	}
 
 	// Parse the JSON response
 	var balanceResponse TelnyxBalanceResponse
 	err = json.Unmarshal(body, &balanceResponse)
 	if err != nil {
-		return 0, fmt.Errorf("failed to unmarshal response: %v", err)
+		return Balance{}, fmt.Errorf("failed to unmarshal response: %v", err)
 	}
 
 	// Convert the balance string to a float
 	// Telnyx returns balance as a string, so we need to parse it
 	balance, err := strconv.ParseFloat(balanceResponse.Data.Balance, 64)
 	if err != nil {
-		return 0, fmt.Errorf("failed to parse balance string '%s': %v", balanceResponse.Data.Balance, err)
+		return Balance{}, fmt.Errorf("failed to parse balance string '%s': %v", balanceResponse.Data.Balance, err)
 	}
 
-	return balance, nil
+	return Balance{Amount: balance, Currency: balanceResponse.Data.Currency}, nil
 }
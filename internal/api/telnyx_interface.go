@@ -5,7 +5,7 @@ import "context"
 // TelnyxClient defines the interface for Telnyx API operations.
 // This allows for easy mocking in tests.
 type TelnyxClient interface {
-	GetBalance(ctx context.Context) (float64, error)
+	GetBalance(ctx context.Context) (Balance, error)
 }
 
 // Ensure TelnyxAPI implements TelnyxClient interface
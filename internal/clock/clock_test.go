@@ -0,0 +1,34 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFake_Advance(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	fake := NewFake(start)
+	assert.Equal(t, start, fake.Now())
+
+	fake.Advance(90 * time.Minute)
+	assert.Equal(t, start.Add(90*time.Minute), fake.Now())
+	assert.Equal(t, 90*time.Minute, Since(fake, start))
+
+	fake.Set(start)
+	assert.Equal(t, start, fake.Now())
+}
+
+func TestReal_Now(t *testing.T) {
+	before := time.Now()
+	now := Real{}.Now()
+	assert.False(t, now.Before(before))
+	assert.Less(t, Since(Real{}, before), time.Minute)
+}
+
+func TestNow_NilUsesSystemTime(t *testing.T) {
+	before := time.Now()
+	assert.False(t, Now(nil).Before(before))
+	assert.Less(t, Since(nil, before), time.Minute)
+}
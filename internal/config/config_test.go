@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseDurationWithDefault(t *testing.T) {
@@ -106,6 +107,177 @@ func TestGitHubConfig_GetNotificationCooldown(t *testing.T) {
 	}
 }
 
+func TestGitHubConfig_GetIncludeReviewers(t *testing.T) {
+	enabled, disabled := true, false
+
+	assert.True(t, GitHubConfig{}.GetIncludeReviewers())
+	assert.True(t, GitHubConfig{IncludeReviewers: &enabled}.GetIncludeReviewers())
+	assert.False(t, GitHubConfig{IncludeReviewers: &disabled}.GetIncludeReviewers())
+}
+
+func TestGitHubConfig_GetMergeConflicts(t *testing.T) {
+	assert.Equal(t, MergeConflictsNotify, GitHubConfig{}.GetMergeConflicts())
+	assert.Equal(t, MergeConflictsSkip, GitHubConfig{MergeConflicts: "Skip"}.GetMergeConflicts())
+	assert.Equal(t, MergeConflictsLabel, GitHubConfig{MergeConflicts: "label"}.GetMergeConflicts())
+	assert.Equal(t, MergeConflictsNotify, GitHubConfig{MergeConflicts: "ignore"}.GetMergeConflicts())
+}
+
+func TestGitHubConfig_GetLastUpdatedFormat(t *testing.T) {
+	assert.Equal(t, LastUpdatedAbsolute, GitHubConfig{}.GetLastUpdatedFormat())
+	assert.Equal(t, LastUpdatedRelative, GitHubConfig{LastUpdatedFormat: "Relative"}.GetLastUpdatedFormat())
+	assert.Equal(t, LastUpdatedBoth, GitHubConfig{LastUpdatedFormat: " both "}.GetLastUpdatedFormat())
+	assert.Equal(t, LastUpdatedAbsolute, GitHubConfig{LastUpdatedFormat: "human"}.GetLastUpdatedFormat())
+}
+
+func TestGitHubConfig_GetAPI(t *testing.T) {
+	assert.Equal(t, GitHubAPIREST, GitHubConfig{}.GetAPI())
+	assert.Equal(t, GitHubAPIGraphQL, GitHubConfig{API: " GraphQL "}.GetAPI())
+	assert.Equal(t, GitHubAPIREST, GitHubConfig{API: "soap"}.GetAPI())
+}
+
+func TestRepositoryConfig_GetBusinessHours(t *testing.T) {
+	repo := RepositoryConfig{Timezone: "Asia/Tokyo", BusinessHours: BusinessHoursConfig{Start: "09:00", End: "17:30"}}
+	hours, err := repo.GetBusinessHours()
+	require.NoError(t, err)
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	assert.Equal(t, tokyo, hours.Location)
+
+	// Wednesday 2024-01-10; weekdays by default
+	assert.True(t, hours.Contains(time.Date(2024, 1, 10, 9, 0, 0, 0, tokyo)))
+	assert.True(t, hours.Contains(time.Date(2024, 1, 10, 17, 29, 0, 0, tokyo)))
+	assert.False(t, hours.Contains(time.Date(2024, 1, 10, 17, 30, 0, 0, tokyo)))
+	assert.False(t, hours.Contains(time.Date(2024, 1, 10, 8, 59, 0, 0, tokyo)))
+	assert.True(t, hours.Contains(time.Date(2024, 1, 10, 1, 0, 0, 0, time.UTC)), "10:00 in Tokyo")
+	assert.False(t, hours.Contains(time.Date(2024, 1, 13, 10, 0, 0, 0, tokyo)), "Saturday")
+
+	_, err = RepositoryConfig{BusinessHours: BusinessHoursConfig{Start: "9am", End: "17:00"}}.GetBusinessHours()
+	assert.ErrorContains(t, err, "business_hours.start")
+	_, err = RepositoryConfig{BusinessHours: BusinessHoursConfig{Start: "09:00", End: "09:00"}}.GetBusinessHours()
+	assert.ErrorContains(t, err, "must differ")
+	_, err = RepositoryConfig{BusinessHours: BusinessHoursConfig{Start: "09:00", End: "17:00", Days: []string{"funday"}}}.GetBusinessHours()
+	assert.ErrorContains(t, err, `unknown day "funday"`)
+	_, err = RepositoryConfig{Timezone: "Mars/Olympus", BusinessHours: BusinessHoursConfig{Start: "09:00", End: "17:00"}}.GetBusinessHours()
+	assert.ErrorContains(t, err, "timezone")
+}
+
+func TestBusinessHours_Contains_CrossingMidnight(t *testing.T) {
+	// A night shift from Friday 22:00 to Saturday 06:00, on Fridays only
+	repo := RepositoryConfig{Timezone: "UTC", BusinessHours: BusinessHoursConfig{Start: "22:00", End: "06:00", Days: []string{"Friday"}}}
+	hours, err := repo.GetBusinessHours()
+	require.NoError(t, err)
+
+	assert.True(t, hours.Contains(time.Date(2024, 1, 12, 23, 0, 0, 0, time.UTC)), "Friday 23:00")
+	assert.True(t, hours.Contains(time.Date(2024, 1, 13, 5, 0, 0, 0, time.UTC)), "Saturday 05:00, Friday's shift")
+	assert.False(t, hours.Contains(time.Date(2024, 1, 12, 5, 0, 0, 0, time.UTC)), "Friday 05:00, Thursday's shift")
+	assert.False(t, hours.Contains(time.Date(2024, 1, 13, 23, 0, 0, 0, time.UTC)), "Saturday 23:00")
+}
+
+func TestBusinessHoursConfig_GetMode(t *testing.T) {
+	assert.Equal(t, BusinessHoursDrop, BusinessHoursConfig{}.GetMode())
+	assert.Equal(t, BusinessHoursQueue, BusinessHoursConfig{Mode: " Queue "}.GetMode())
+	assert.Equal(t, BusinessHoursDrop, BusinessHoursConfig{Mode: "later"}.GetMode())
+}
+
+func TestWorkflowConfig_GetBranch(t *testing.T) {
+	assert.Equal(t, "main", WorkflowConfig{}.GetBranch())
+	assert.Equal(t, "release", WorkflowConfig{Branch: " release "}.GetBranch())
+}
+
+func TestGitHubConfig_GetConcurrency(t *testing.T) {
+	assert.Equal(t, 4, GitHubConfig{}.GetConcurrency())
+	assert.Equal(t, 4, GitHubConfig{Concurrency: -1}.GetConcurrency())
+	assert.Equal(t, 10, GitHubConfig{Concurrency: 10}.GetConcurrency())
+}
+
+func TestGitHubConfig_GetMaxRetries(t *testing.T) {
+	zero, five, negative := 0, 5, -2
+
+	assert.Equal(t, 3, GitHubConfig{}.GetMaxRetries())
+	assert.Equal(t, 0, GitHubConfig{MaxRetries: &zero}.GetMaxRetries())
+	assert.Equal(t, 5, GitHubConfig{MaxRetries: &five}.GetMaxRetries())
+	assert.Equal(t, 0, GitHubConfig{MaxRetries: &negative}.GetMaxRetries())
+}
+
+func TestConfig_GetNotifier(t *testing.T) {
+	cfg := Config{
+		Notifier:  NotifierConfig{Backend: BackendApprise},
+		Notifiers: map[string]NotifierConfig{"ops": {Backend: BackendSlack}},
+	}
+
+	notifier, err := cfg.GetNotifier("")
+	require.NoError(t, err)
+	assert.Equal(t, BackendApprise, notifier.Backend)
+
+	notifier, err = cfg.GetNotifier("Ops")
+	require.NoError(t, err)
+	assert.Equal(t, BackendSlack, notifier.Backend)
+
+	_, err = cfg.GetNotifier("dev")
+	assert.EqualError(t, err, `unknown notifier "dev" (define it under notifiers)`)
+}
+
+func TestTimeoutDefaults(t *testing.T) {
+	assert.Equal(t, 30*time.Second, GitHubConfig{}.GetTimeout())
+	assert.Equal(t, 30*time.Second, GitHubConfig{Timeout: "soon"}.GetTimeout())
+	assert.Equal(t, 10*time.Second, GitHubConfig{Timeout: "10s"}.GetTimeout())
+
+	assert.Equal(t, 30*time.Second, TelnyxConfig{}.GetTimeout())
+	assert.Equal(t, time.Minute, TelnyxConfig{Timeout: "1m"}.GetTimeout())
+
+	assert.Equal(t, 30*time.Second, NotifierConfig{}.GetTimeout())
+	assert.Equal(t, 5*time.Second, NotifierConfig{Timeout: "5s"}.GetTimeout())
+}
+
+func TestNotifierConfig_RetryDefaults(t *testing.T) {
+	defaults := NotifierConfig{}
+	assert.Equal(t, 3, defaults.GetMaxRetries())
+	assert.Equal(t, 500*time.Millisecond, defaults.GetInitialBackoff())
+	assert.Equal(t, 10*time.Second, defaults.GetMaxBackoff())
+	assert.Equal(t, 2.0, defaults.GetBackoffMultiplier())
+
+	zero, negative := 0, -1
+	custom := NotifierConfig{MaxRetries: &zero, InitialBackoff: "1s", MaxBackoff: "30s", BackoffMultiplier: 1.5}
+	assert.Equal(t, 0, custom.GetMaxRetries())
+	assert.Equal(t, time.Second, custom.GetInitialBackoff())
+	assert.Equal(t, 30*time.Second, custom.GetMaxBackoff())
+	assert.Equal(t, 1.5, custom.GetBackoffMultiplier())
+
+	assert.Equal(t, 0, NotifierConfig{MaxRetries: &negative}.GetMaxRetries())
+	assert.Equal(t, 2.0, NotifierConfig{BackoffMultiplier: 0.5}.GetBackoffMultiplier())
+}
+
+func TestTelnyxConfig_GetMinBalanceChange(t *testing.T) {
+	tests := []struct {
+		value   string
+		amount  float64
+		percent bool
+		wantErr bool
+	}{
+		{value: "", amount: 0},
+		{value: "0.50", amount: 0.5},
+		{value: " 10% ", amount: 10, percent: true},
+		{value: "2.5 %", amount: 2.5, percent: true},
+		{value: "-1", wantErr: true},
+		{value: "lots", wantErr: true},
+		{value: "%", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			amount, percent, err := TelnyxConfig{MinBalanceChange: tt.value}.GetMinBalanceChange()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.amount, amount)
+			assert.Equal(t, tt.percent, percent)
+		})
+	}
+}
+
 func TestGitHubConfig_GetStaleDays(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -215,6 +387,22 @@ func TestTelnyxConfig_GetInterval(t *testing.T) {
 	}
 }
 
+func TestGitHubConfig_GetStaleThreshold(t *testing.T) {
+	assert.Equal(t, 4*24*time.Hour, GitHubConfig{}.GetStaleThreshold())
+	assert.Equal(t, 2*24*time.Hour, GitHubConfig{StaleDays: 2}.GetStaleThreshold())
+	assert.Equal(t, 12*time.Hour, GitHubConfig{StaleDuration: "12h"}.GetStaleThreshold())
+	// stale_duration takes precedence over stale_days
+	assert.Equal(t, 36*time.Hour, GitHubConfig{StaleDays: 7, StaleDuration: " 36h "}.GetStaleThreshold())
+	// An invalid stale_duration falls back to stale_days
+	assert.Equal(t, 7*24*time.Hour, GitHubConfig{StaleDays: 7, StaleDuration: "1.5 days"}.GetStaleThreshold())
+}
+
+func TestGitHubConfig_GetGracePeriod(t *testing.T) {
+	assert.Equal(t, time.Duration(0), GitHubConfig{}.GetGracePeriod())
+	assert.Equal(t, 12*time.Hour, GitHubConfig{GracePeriod: "12h"}.GetGracePeriod())
+	assert.Equal(t, time.Duration(0), GitHubConfig{GracePeriod: "soon"}.GetGracePeriod())
+}
+
 func TestTelnyxConfig_GetNotificationCooldown(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -308,6 +496,57 @@ func TestNotifierConfig_GetServiceURLs(t *testing.T) {
 	}
 }
 
+func TestNotifierConfig_GetFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		expected string
+		valid    bool
+	}{
+		{name: "empty defaults to text", format: "", expected: "text", valid: true},
+		{name: "text", format: "text", expected: "text", valid: true},
+		{name: "markdown", format: "markdown", expected: "markdown", valid: true},
+		{name: "html", format: "html", expected: "html", valid: true},
+		{name: "mixed case with spaces", format: " Markdown ", expected: "markdown", valid: true},
+		{name: "unsupported", format: "rtf", expected: "rtf", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NotifierConfig{Format: tt.format}
+			assert.Equal(t, tt.expected, cfg.GetFormat())
+			assert.Equal(t, tt.valid, cfg.IsValidFormat())
+		})
+	}
+}
+
+func TestNotifierConfig_GetBackends(t *testing.T) {
+	tests := []struct {
+		name     string
+		backend  string
+		expected []string
+	}{
+		{name: "empty defaults to apprise", backend: "", expected: []string{"apprise"}},
+		{name: "single", backend: "slack", expected: []string{"slack"}},
+		{name: "multiple with spaces and case", backend: " Slack , apprise", expected: []string{"slack", "apprise"}},
+		{name: "duplicates and empty entries removed", backend: "discord,,discord,", expected: []string{"discord"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NotifierConfig{Backend: tt.backend}
+			assert.Equal(t, tt.expected, cfg.GetBackends())
+		})
+	}
+}
+
+func TestLogConfig_Defaults(t *testing.T) {
+	assert.Equal(t, "console", LogConfig{}.GetFormat())
+	assert.Equal(t, "info", LogConfig{}.GetLevel())
+	assert.Equal(t, "json", LogConfig{Format: " JSON "}.GetFormat())
+	assert.Equal(t, "debug", LogConfig{Level: "Debug"}.GetLevel())
+}
+
 func TestSchedulerConfig_GetInterval(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -352,6 +591,119 @@ func TestSchedulerConfig_GetInterval(t *testing.T) {
 	}
 }
 
+func TestSchedulerConfig_GetMaxBackoff(t *testing.T) {
+	assert.Zero(t, SchedulerConfig{}.GetMaxBackoff())
+	assert.Zero(t, SchedulerConfig{MaxBackoff: "soon"}.GetMaxBackoff())
+	assert.Equal(t, time.Hour, SchedulerConfig{MaxBackoff: "1h"}.GetMaxBackoff())
+}
+
+func TestSchedulerConfig_GetShutdownTimeout(t *testing.T) {
+	assert.Equal(t, 30*time.Second, SchedulerConfig{}.GetShutdownTimeout())
+	assert.Equal(t, 30*time.Second, SchedulerConfig{ShutdownTimeout: "soon"}.GetShutdownTimeout())
+	assert.Equal(t, 2*time.Minute, SchedulerConfig{ShutdownTimeout: "2m"}.GetShutdownTimeout())
+}
+
+func TestQuietHoursConfig_GetWindow(t *testing.T) {
+	q := QuietHoursConfig{Start: "22:00", End: "07:30", Timezone: "America/New_York"}
+	start, end, loc, err := q.GetWindow()
+	require.NoError(t, err)
+	assert.Equal(t, 22*time.Hour, start)
+	assert.Equal(t, 7*time.Hour+30*time.Minute, end)
+	assert.Equal(t, "America/New_York", loc.String())
+	assert.True(t, q.IsEnabled())
+	assert.Equal(t, QuietHoursSuppress, q.GetMode())
+
+	_, _, loc, err = QuietHoursConfig{Start: "22:00", End: "07:00"}.GetWindow()
+	require.NoError(t, err)
+	assert.Equal(t, time.Local, loc)
+
+	for _, invalid := range []QuietHoursConfig{
+		{Start: "25:00", End: "07:00"},
+		{Start: "22:00"},
+		{Start: "22:00", End: "22:00"},
+		{Start: "22:00", End: "07:00", Timezone: "Nowhere/Special"},
+	} {
+		_, _, _, err := invalid.GetWindow()
+		assert.Error(t, err, invalid)
+	}
+
+	assert.False(t, QuietHoursConfig{}.IsEnabled())
+}
+
+func TestRateLimitConfig(t *testing.T) {
+	assert.False(t, RateLimitConfig{}.IsEnabled())
+	assert.True(t, RateLimitConfig{MaxPerMinute: 5}.IsEnabled())
+	assert.Equal(t, RateLimitDrop, RateLimitConfig{}.GetMode())
+	assert.Equal(t, RateLimitDelay, RateLimitConfig{Mode: " Delay "}.GetMode())
+}
+
+func TestRepositoryConfig_GetStaleMetric(t *testing.T) {
+	tests := []struct {
+		name     string
+		metric   string
+		expected string
+	}{
+		{name: "empty defaults to updated", metric: "", expected: StaleMetricUpdated},
+		{name: "updated", metric: "updated", expected: StaleMetricUpdated},
+		{name: "created", metric: "created", expected: StaleMetricCreated},
+		{name: "case insensitive", metric: " Created ", expected: StaleMetricCreated},
+		{name: "unknown falls back to updated", metric: "merged", expected: StaleMetricUpdated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := RepositoryConfig{StaleMetric: tt.metric}
+			assert.Equal(t, tt.expected, repo.GetStaleMetric())
+		})
+	}
+}
+
+func TestRepositoryConfig_GetFilterMode(t *testing.T) {
+	assert.Equal(t, FilterModeAnd, RepositoryConfig{}.GetFilterMode())
+	assert.Equal(t, FilterModeOr, RepositoryConfig{FilterMode: " OR "}.GetFilterMode())
+	assert.Equal(t, FilterModeAnd, RepositoryConfig{FilterMode: "xor"}.GetFilterMode())
+}
+
+func TestGitHubConfig_GetRepositories(t *testing.T) {
+	cfg := GitHubConfig{Repositories: []RepositoryConfig{
+		{Owner: "zeta", Repo: "web"},
+		{Owner: "Acme", Repo: "API", Authors: []string{"Alice", "bob"}, IncludeLabels: []string{"ready"}},
+		{Owner: "acme", Repo: "api", Authors: []string{"bob", "alice", "alice"}, IncludeLabels: []string{"Ready"}},
+		{Owner: " acme ", Repo: "Api"},
+	}}
+	// The last entry has no filters, so it conflicts with the filtered ones
+	_, err := cfg.GetRepositories()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "repositories[3] duplicates repositories[1] (acme/api)")
+
+	cfg.Repositories = cfg.Repositories[:3]
+	repos, err := cfg.GetRepositories()
+	require.NoError(t, err)
+	require.Len(t, repos, 2)
+	assert.Equal(t, "acme", repos[0].Owner)
+	assert.Equal(t, "api", repos[0].Repo)
+	assert.Equal(t, []string{"Alice", "bob"}, repos[0].Authors)
+	assert.Equal(t, "zeta", repos[1].Owner)
+}
+
+func TestGitHubConfig_GetRepositories_ConflictingSettings(t *testing.T) {
+	cfg := GitHubConfig{Repositories: []RepositoryConfig{
+		{Owner: "acme", Repo: "api", StaleMetric: "created"},
+		{Owner: "acme", Repo: "api"},
+	}}
+	_, err := cfg.GetRepositories()
+	assert.Error(t, err)
+
+	cfg.Repositories[1].StaleMetric = "Created"
+	repos, err := cfg.GetRepositories()
+	require.NoError(t, err)
+	assert.Len(t, repos, 1)
+
+	cfg.Repositories[1].BusinessHours = BusinessHoursConfig{Start: "09:00", End: "17:00"}
+	_, err = cfg.GetRepositories()
+	assert.Error(t, err)
+}
+
 func TestRepositoryConfig_Fields(t *testing.T) {
 	repo := RepositoryConfig{
 		Owner:   "testowner",
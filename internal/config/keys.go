@@ -0,0 +1,46 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Keys returns the dotted config keys (e.g., "tasks.telnyx.api_key") of every scalar
+// setting in Config, derived from the mapstructure tags.
+//
+// Viper's AutomaticEnv only resolves keys it already knows about, so these keys are
+// bound explicitly to let every setting be supplied through the environment
+// (e.g., TASKS_TELNYX_API_KEY) without a config file.
+// Lists of structs (such as tasks.github.repositories) can't be expressed as a
+// single environment variable and are skipped.
+func Keys() []string {
+	return collectKeys(reflect.TypeOf(Config{}), "")
+}
+
+// collectKeys walks a struct type recursively and returns the dotted keys of its leaf fields.
+func collectKeys(t reflect.Type, prefix string) []string {
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := strings.Split(field.Tag.Get("mapstructure"), ",")[0]
+		if tag == "" || tag == "-" {
+			continue
+		}
+
+		key := tag
+		if prefix != "" {
+			key = prefix + "." + tag
+		}
+
+		switch {
+		case field.Type.Kind() == reflect.Struct:
+			keys = append(keys, collectKeys(field.Type, key)...)
+		case field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.Struct:
+			// Lists of structs can't be bound to a single env var
+			continue
+		default:
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,21 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeys(t *testing.T) {
+	keys := Keys()
+
+	assert.Contains(t, keys, "tasks.telnyx.api_key")
+	assert.Contains(t, keys, "tasks.telnyx.threshold")
+	assert.Contains(t, keys, "tasks.github.token")
+	assert.Contains(t, keys, "notifier.apprise_api_url")
+	assert.Contains(t, keys, "notifier.apprise_service_url")
+	assert.Contains(t, keys, "scheduler.interval")
+
+	// Lists of structs can't come from a single env var
+	assert.NotContains(t, keys, "tasks.github.repositories")
+}
@@ -0,0 +1,113 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"watchdog/internal/scheduler"
+
+	"github.com/rs/zerolog/log"
+)
+
+// StatusProvider reports scheduler liveness and per-task run outcomes.
+// *scheduler.Scheduler implements this interface.
+type StatusProvider interface {
+	// Running reports whether the scheduler is started and not shutting down
+	Running() bool
+
+	// TaskNames returns the names of all scheduled tasks
+	TaskNames() []string
+
+	// TaskStatuses returns the most recent run outcome of each task that has run
+	TaskStatuses() map[string]scheduler.TaskStatus
+}
+
+// Ensure Scheduler implements StatusProvider interface
+var _ StatusProvider = (*scheduler.Scheduler)(nil)
+
+// readyResponse is the JSON body returned by /readyz.
+type readyResponse struct {
+	Ready bool                            `json:"ready"`
+	Tasks map[string]scheduler.TaskStatus `json:"tasks"`
+	// Pending lists tasks that haven't completed a run yet
+	Pending []string `json:"pending,omitempty"`
+}
+
+// Handler returns an HTTP handler serving the health endpoints:
+//   - /healthz: 200 while the scheduler is running, 503 otherwise (e.g. during shutdown)
+//   - /readyz: 200 if the last run of every task succeeded, 503 if any failed or hasn't run yet
+func Handler(provider StatusProvider) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		if !provider.Running() {
+			http.Error(w, "shutting down", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		statuses := provider.TaskStatuses()
+		resp := readyResponse{Ready: provider.Running(), Tasks: statuses}
+
+		for _, name := range provider.TaskNames() {
+			status, ok := statuses[name]
+			if !ok {
+				resp.Pending = append(resp.Pending, name)
+				resp.Ready = false
+				continue
+			}
+			if !status.Success {
+				resp.Ready = false
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if resp.Ready {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+
+	return mux
+}
+
+// Server exposes the health endpoints over HTTP.
+type Server struct {
+	httpServer *http.Server
+}
+
+// NewServer creates a health server listening on addr (e.g., ":8080").
+// Call Start to begin serving.
+func NewServer(addr string, provider StatusProvider) *Server {
+	return &Server{
+		httpServer: &http.Server{
+			Addr:              addr,
+			Handler:           Handler(provider),
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+	}
+}
+
+// Start begins serving health checks in a background goroutine.
+// Listen errors are logged; they don't stop the rest of watchdog.
+func (s *Server) Start() {
+	go func() {
+		log.Info().Str("listen_addr", s.httpServer.Addr).Msg("Health server listening")
+		if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error().Err(err).Msg("Health server failed")
+		}
+	}()
+}
+
+// Shutdown gracefully stops the health server.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.httpServer.Shutdown(ctx)
+}
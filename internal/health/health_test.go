@@ -0,0 +1,127 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"watchdog/internal/scheduler"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProvider is a StatusProvider with fixed values
+type fakeProvider struct {
+	running  bool
+	names    []string
+	statuses map[string]scheduler.TaskStatus
+}
+
+func (f *fakeProvider) Running() bool                                 { return f.running }
+func (f *fakeProvider) TaskNames() []string                           { return f.names }
+func (f *fakeProvider) TaskStatuses() map[string]scheduler.TaskStatus { return f.statuses }
+
+func get(t *testing.T, handler http.Handler, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func TestHealthz(t *testing.T) {
+	tests := []struct {
+		name     string
+		running  bool
+		expected int
+	}{
+		{name: "running", running: true, expected: http.StatusOK},
+		{name: "shutting down", running: false, expected: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := Handler(&fakeProvider{running: tt.running})
+			assert.Equal(t, tt.expected, get(t, handler, "/healthz").Code)
+		})
+	}
+}
+
+func TestReadyz(t *testing.T) {
+	ok := scheduler.TaskStatus{LastRun: time.Now(), Success: true}
+	failed := scheduler.TaskStatus{LastRun: time.Now(), Success: false, Error: "boom"}
+
+	tests := []struct {
+		name     string
+		provider *fakeProvider
+		expected int
+		pending  []string
+	}{
+		{
+			name: "all tasks succeeded",
+			provider: &fakeProvider{
+				running:  true,
+				names:    []string{"a", "b"},
+				statuses: map[string]scheduler.TaskStatus{"a": ok, "b": ok},
+			},
+			expected: http.StatusOK,
+		},
+		{
+			name: "one task failed",
+			provider: &fakeProvider{
+				running:  true,
+				names:    []string{"a", "b"},
+				statuses: map[string]scheduler.TaskStatus{"a": ok, "b": failed},
+			},
+			expected: http.StatusServiceUnavailable,
+		},
+		{
+			name: "task has not run yet",
+			provider: &fakeProvider{
+				running:  true,
+				names:    []string{"a", "b"},
+				statuses: map[string]scheduler.TaskStatus{"a": ok},
+			},
+			expected: http.StatusServiceUnavailable,
+			pending:  []string{"b"},
+		},
+		{
+			name: "scheduler stopped",
+			provider: &fakeProvider{
+				running:  false,
+				names:    []string{"a"},
+				statuses: map[string]scheduler.TaskStatus{"a": ok},
+			},
+			expected: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := get(t, Handler(tt.provider), "/readyz")
+			assert.Equal(t, tt.expected, rec.Code)
+
+			var resp readyResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, tt.expected == http.StatusOK, resp.Ready)
+			assert.Equal(t, tt.pending, resp.Pending)
+		})
+	}
+}
+
+func TestHandler_WithScheduler(t *testing.T) {
+	sched := scheduler.NewScheduler()
+	handler := Handler(sched)
+
+	// Not started yet
+	assert.Equal(t, http.StatusServiceUnavailable, get(t, handler, "/healthz").Code)
+
+	sched.Start()
+	assert.Equal(t, http.StatusOK, get(t, handler, "/healthz").Code)
+	assert.Equal(t, http.StatusOK, get(t, handler, "/readyz").Code)
+
+	sched.Stop()
+	assert.Equal(t, http.StatusServiceUnavailable, get(t, handler, "/healthz").Code)
+}
@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog/log"
+)
+
+// Registry holds all watchdog metrics.
+// We use a dedicated registry (rather than the global default) so tests can
+// scrape a predictable set of metrics.
+var Registry = prometheus.NewRegistry()
+
+var (
+	// StalePRs is the number of stale PRs found in the last run, per repository whose PRs were fetched.
+	StalePRs = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "watchdog_stale_prs",
+		Help: "Number of stale pull requests found in the last check, per repository.",
+	}, []string{"repository"})
+
+	// NotificationsSent counts notification attempts by outcome ("success" or "failure").
+	NotificationsSent = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "watchdog_notifications_sent_total",
+		Help: "Total number of notifications sent, by status.",
+	}, []string{"status"})
+
+	// TaskRunDuration observes how long each task run takes, per task.
+	TaskRunDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "watchdog_task_run_duration_seconds",
+		Help:    "Duration of task runs in seconds.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"task"})
+
+	// TelnyxBalance is the last observed Telnyx account balance.
+	TelnyxBalance = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "watchdog_telnyx_balance",
+		Help: "Last observed Telnyx account balance.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		StalePRs,
+		NotificationsSent,
+		TaskRunDuration,
+		TelnyxBalance,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// ObserveTaskRun records the duration of a task run started at start. task is the task's
+// Name(), so metrics and logs identify a task the same way.
+// Typical usage: defer metrics.ObserveTaskRun(t.Name(), time.Now())
+func ObserveTaskRun(task string, start time.Time) {
+	TaskRunDuration.WithLabelValues(task).Observe(time.Since(start).Seconds())
+}
+
+// RecordNotification increments the notification counter for the given send result.
+func RecordNotification(err error) {
+	if err != nil {
+		NotificationsSent.WithLabelValues("failure").Inc()
+		return
+	}
+	NotificationsSent.WithLabelValues("success").Inc()
+}
+
+// Handler returns an HTTP handler that serves the watchdog metrics in Prometheus format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// Server exposes the metrics endpoint over HTTP.
+type Server struct {
+	httpServer *http.Server
+}
+
+// NewServer creates a metrics server listening on addr (e.g., ":9090").
+// Metrics are served at /metrics. Call Start to begin serving.
+func NewServer(addr string) *Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	return &Server{
+		httpServer: &http.Server{
+			Addr:              addr,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+	}
+}
+
+// Start begins serving metrics in a background goroutine.
+// Listen errors are logged; they don't stop the rest of watchdog.
+func (s *Server) Start() {
+	go func() {
+		log.Info().Str("listen_addr", s.httpServer.Addr).Msg("Metrics server listening")
+		if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error().Err(err).Msg("Metrics server failed")
+		}
+	}()
+}
+
+// Shutdown gracefully stops the metrics server.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.httpServer.Shutdown(ctx)
+}
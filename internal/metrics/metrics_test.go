@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scrape fetches the metrics endpoint and returns the body as a string
+func scrape(t *testing.T) string {
+	t.Helper()
+	server := httptest.NewServer(Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestHandler_ExposesMetrics(t *testing.T) {
+	StalePRs.WithLabelValues("owner/repo").Set(3)
+	TelnyxBalance.Set(12.5)
+	RecordNotification(nil)
+	RecordNotification(errors.New("boom"))
+	// The registry is global, so each run (e.g., with -count=2) observes its own task
+	task := fmt.Sprintf("test_task_%d", time.Now().UnixNano())
+	ObserveTaskRun(task, time.Now().Add(-time.Second))
+
+	body := scrape(t)
+
+	assert.Contains(t, body, `watchdog_stale_prs{repository="owner/repo"} 3`)
+	assert.Contains(t, body, `watchdog_telnyx_balance 12.5`)
+	assert.Contains(t, body, `watchdog_notifications_sent_total{status="success"}`)
+	assert.Contains(t, body, `watchdog_notifications_sent_total{status="failure"}`)
+	assert.Contains(t, body, `watchdog_task_run_duration_seconds_count{task="`+task+`"} 1`)
+}
+
+func TestNewServer(t *testing.T) {
+	server := NewServer(":0")
+
+	assert.NotNil(t, server)
+	assert.Equal(t, ":0", server.httpServer.Addr)
+}
@@ -0,0 +1,116 @@
+// Package money formats monetary amounts for notifications, with the currency symbol,
+// decimal places and separators appropriate for a currency and locale.
+package money
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// currencyFormat describes how amounts in a currency are written.
+type currencyFormat struct {
+	symbol   string
+	decimals int
+}
+
+// currencies lists the currencies with a known symbol and number of decimal places.
+// Other currencies are written with their ISO code and two decimals.
+var currencies = map[string]currencyFormat{
+	"USD": {symbol: "$", decimals: 2},
+	"EUR": {symbol: "€", decimals: 2},
+	"GBP": {symbol: "£", decimals: 2},
+	"JPY": {symbol: "¥", decimals: 0},
+	"INR": {symbol: "₹", decimals: 2},
+	"CAD": {symbol: "CA$", decimals: 2},
+	"AUD": {symbol: "A$", decimals: 2},
+	"CHF": {symbol: "CHF", decimals: 2},
+}
+
+// localeFormat describes how a locale writes numbers and where the symbol goes.
+type localeFormat struct {
+	group   string
+	decimal string
+	// symbolAfter places the symbol after the amount, separated by a space ("1.234,56 €")
+	symbolAfter bool
+}
+
+// locales maps language codes to their number format. Unknown languages use "en".
+var locales = map[string]localeFormat{
+	"en": {group: ",", decimal: "."},
+	"ja": {group: ",", decimal: "."},
+	"zh": {group: ",", decimal: "."},
+	"de": {group: ".", decimal: ",", symbolAfter: true},
+	"es": {group: ".", decimal: ",", symbolAfter: true},
+	"it": {group: ".", decimal: ",", symbolAfter: true},
+	"nl": {group: ".", decimal: ",", symbolAfter: true},
+	"fr": {group: "\u202f", decimal: ",", symbolAfter: true}, // narrow no-break space
+}
+
+// Format renders amount in currency (an ISO 4217 code such as "USD"; empty means USD)
+// for locale (e.g., "en-US", "de_DE" or just "de"), with thousands separators and the
+// currency's symbol and decimal places: Format(1234.5, "EUR", "de-DE") is "1.234,50 €".
+//
+// An empty locale keeps the plain format: "$5.00" for USD, otherwise the amount followed
+// by the code ("5.00 EUR").
+func Format(amount float64, currency, locale string) string {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		currency = "USD"
+	}
+
+	if strings.TrimSpace(locale) == "" {
+		if currency == "USD" {
+			return fmt.Sprintf("$%.2f", amount)
+		}
+		return fmt.Sprintf("%.2f %s", amount, currency)
+	}
+
+	cur, ok := currencies[currency]
+	if !ok {
+		cur = currencyFormat{symbol: currency, decimals: 2}
+	}
+	loc := lookupLocale(locale)
+
+	number := formatNumber(math.Abs(amount), cur.decimals, loc)
+	sign := ""
+	if amount < 0 && number != formatNumber(0, cur.decimals, loc) {
+		sign = "-"
+	}
+	if loc.symbolAfter {
+		return sign + number + " " + cur.symbol
+	}
+	return sign + cur.symbol + number
+}
+
+// lookupLocale returns the number format for locale's language, falling back to "en".
+func lookupLocale(locale string) localeFormat {
+	lang := strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	if loc, ok := locales[lang]; ok {
+		return loc
+	}
+	return locales["en"]
+}
+
+// formatNumber writes a non-negative amount rounded to decimals places, grouping the
+// integer digits in thousands.
+func formatNumber(amount float64, decimals int, loc localeFormat) string {
+	s := fmt.Sprintf("%.*f", decimals, amount)
+	integer, fraction, _ := strings.Cut(s, ".")
+
+	var b strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteString(loc.group)
+		}
+		b.WriteRune(digit)
+	}
+	if fraction != "" {
+		b.WriteString(loc.decimal)
+		b.WriteString(fraction)
+	}
+	return b.String()
+}
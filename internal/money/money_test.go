@@ -0,0 +1,43 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   float64
+		currency string
+		locale   string
+		expected string
+	}{
+		{name: "usd en-US", amount: 1234567.891, currency: "USD", locale: "en-US", expected: "$1,234,567.89"},
+		{name: "usd small", amount: 5, currency: "usd", locale: "en", expected: "$5.00"},
+		{name: "usd negative", amount: -1234.5, currency: "USD", locale: "en-US", expected: "-$1,234.50"},
+		{name: "eur de-DE", amount: 1234.5, currency: "EUR", locale: "de-DE", expected: "1.234,50 €"},
+		{name: "eur fr_FR", amount: 1234.5, currency: "EUR", locale: "fr_FR", expected: "1\u202f234,50 €"},
+		{name: "eur en-IE", amount: 1234.5, currency: "EUR", locale: "en-IE", expected: "€1,234.50"},
+		{name: "jpy ja-JP has no decimals", amount: 1234567.6, currency: "JPY", locale: "ja-JP", expected: "¥1,234,568"},
+		{name: "jpy de-DE", amount: 1500, currency: "JPY", locale: "de", expected: "1.500 ¥"},
+		{name: "unknown currency uses its code", amount: 99.5, currency: "SEK", locale: "en-US", expected: "SEK99.50"},
+		{name: "unknown locale uses en", amount: 1000, currency: "USD", locale: "xx-YY", expected: "$1,000.00"},
+		{name: "empty currency is usd", amount: 12, currency: "", locale: "en-US", expected: "$12.00"},
+		{name: "rounds to zero without sign", amount: -0.001, currency: "USD", locale: "en-US", expected: "$0.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Format(tt.amount, tt.currency, tt.locale))
+		})
+	}
+}
+
+func TestFormat_NoLocaleKeepsPlainFormat(t *testing.T) {
+	assert.Equal(t, "$1234.50", Format(1234.5, "USD", ""))
+	assert.Equal(t, "$5.00", Format(5, "", ""))
+	assert.Equal(t, "1234.50 EUR", Format(1234.5, "eur", ""))
+	assert.Equal(t, "1500.00 JPY", Format(1500, "JPY", ""))
+}
@@ -0,0 +1,87 @@
+package notifier
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DedupNotifier wraps another Notifier and suppresses identical notifications
+// (same subject and body) sent again within DedupWindow.
+//
+// This complements the per-task cooldowns: balance alerts and multi-repo setups
+// can still produce the same message several times in quick succession.
+type DedupNotifier struct {
+	// Next is the notifier that delivers notifications that aren't duplicates
+	Next Notifier
+
+	// DedupWindow is how long an identical notification is suppressed after being sent
+	DedupWindow time.Duration
+
+	// now returns the current time (overridable in tests)
+	now func() time.Time
+
+	mu   sync.Mutex
+	sent map[string]time.Time // content hash -> time of last successful send
+}
+
+// NewDedupNotifier creates a notifier that forwards to next, dropping identical
+// notifications sent within window.
+func NewDedupNotifier(next Notifier, window time.Duration) *DedupNotifier {
+	return &DedupNotifier{
+		Next:        next,
+		DedupWindow: window,
+		now:         time.Now,
+		sent:        make(map[string]time.Time),
+	}
+}
+
+// SendNotification forwards the notification unless an identical one was sent within
+// the window, in which case it is logged and dropped (returning nil).
+// Only successful sends are remembered, so a failed notification can be retried right away.
+func (d *DedupNotifier) SendNotification(ctx context.Context, subject, message string) error {
+	key := contentHash(subject, message)
+	now := d.now()
+
+	d.mu.Lock()
+	d.evict(now)
+	if last, ok := d.sent[key]; ok {
+		d.mu.Unlock()
+		log.Info().
+			Str("subject", subject).
+			Time("last_sent", last).
+			Dur("window", d.DedupWindow).
+			Msg("Suppressing duplicate notification")
+		return nil
+	}
+	d.mu.Unlock()
+
+	if err := d.Next.SendNotification(ctx, subject, message); err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	d.sent[key] = now
+	d.mu.Unlock()
+	return nil
+}
+
+// evict drops entries older than the window so the cache doesn't grow forever.
+// Must be called with mu held.
+func (d *DedupNotifier) evict(now time.Time) {
+	for key, sentAt := range d.sent {
+		if now.Sub(sentAt) >= d.DedupWindow {
+			delete(d.sent, key)
+		}
+	}
+}
+
+// contentHash returns a SHA-256 hash identifying a notification's subject and body.
+func contentHash(subject, message string) string {
+	sum := sha256.Sum256([]byte(subject + "\x00" + message))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,82 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestDedupNotifier returns a DedupNotifier with a controllable clock.
+func newTestDedupNotifier(next Notifier, window time.Duration) (*DedupNotifier, *time.Time) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	d := NewDedupNotifier(next, window)
+	d.now = func() time.Time { return now }
+	return d, &now
+}
+
+func TestDedupNotifier_SuppressesDuplicateWithinWindow(t *testing.T) {
+	next := &mockNotifier{}
+	next.On("SendNotification", mock.Anything, "Subject", "Message").Return(nil).Once()
+	d, now := newTestDedupNotifier(next, time.Minute)
+
+	require.NoError(t, d.SendNotification(context.Background(), "Subject", "Message"))
+	*now = now.Add(30 * time.Second)
+	require.NoError(t, d.SendNotification(context.Background(), "Subject", "Message"))
+
+	next.AssertNumberOfCalls(t, "SendNotification", 1)
+}
+
+func TestDedupNotifier_DeliversAfterWindow(t *testing.T) {
+	next := &mockNotifier{}
+	next.On("SendNotification", mock.Anything, "Subject", "Message").Return(nil)
+	d, now := newTestDedupNotifier(next, time.Minute)
+
+	require.NoError(t, d.SendNotification(context.Background(), "Subject", "Message"))
+	*now = now.Add(time.Minute)
+	require.NoError(t, d.SendNotification(context.Background(), "Subject", "Message"))
+
+	next.AssertNumberOfCalls(t, "SendNotification", 2)
+}
+
+func TestDedupNotifier_DifferentContentIsDelivered(t *testing.T) {
+	next := &mockNotifier{}
+	next.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	d, _ := newTestDedupNotifier(next, time.Minute)
+
+	require.NoError(t, d.SendNotification(context.Background(), "Subject", "Message A"))
+	require.NoError(t, d.SendNotification(context.Background(), "Subject", "Message B"))
+	require.NoError(t, d.SendNotification(context.Background(), "Other subject", "Message A"))
+
+	next.AssertNumberOfCalls(t, "SendNotification", 3)
+}
+
+func TestDedupNotifier_FailedSendIsNotRemembered(t *testing.T) {
+	next := &mockNotifier{}
+	next.On("SendNotification", mock.Anything, "Subject", "Message").Return(errors.New("boom")).Once()
+	next.On("SendNotification", mock.Anything, "Subject", "Message").Return(nil).Once()
+	d, _ := newTestDedupNotifier(next, time.Minute)
+
+	require.Error(t, d.SendNotification(context.Background(), "Subject", "Message"))
+	require.NoError(t, d.SendNotification(context.Background(), "Subject", "Message"))
+
+	next.AssertNumberOfCalls(t, "SendNotification", 2)
+}
+
+func TestDedupNotifier_EvictsExpiredEntries(t *testing.T) {
+	next := &mockNotifier{}
+	next.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	d, now := newTestDedupNotifier(next, time.Minute)
+
+	require.NoError(t, d.SendNotification(context.Background(), "Subject", "Message A"))
+	require.NoError(t, d.SendNotification(context.Background(), "Subject", "Message B"))
+	assert.Len(t, d.sent, 2)
+
+	*now = now.Add(2 * time.Minute)
+	require.NoError(t, d.SendNotification(context.Background(), "Subject", "Message C"))
+	assert.Len(t, d.sent, 1)
+}
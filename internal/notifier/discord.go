@@ -0,0 +1,125 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"watchdog/internal/metrics"
+)
+
+// discordMaxContentLength is Discord's message content limit.
+// Longer notification bodies are truncated with an ellipsis.
+const discordMaxContentLength = 2000
+
+// Embed colors (decimal RGB) used for each severity.
+const (
+	discordColorInfo    = 0x3498DB // blue
+	discordColorSuccess = 0x2ECC71 // green
+	discordColorWarning = 0xF39C12 // orange
+	discordColorFailure = 0xE74C3C // red
+)
+
+// DiscordNotifier implements the Notifier interface by posting directly to a Discord
+// webhook, for users who don't run an Apprise server.
+type DiscordNotifier struct {
+	// WebhookURL is the Discord webhook URL (e.g., "https://discord.com/api/webhooks/ID/TOKEN")
+	WebhookURL string
+
+	// Timeout bounds each request. 0 uses the shared client's 30 seconds.
+	Timeout time.Duration
+}
+
+// DiscordPayload is the JSON body sent to a Discord webhook.
+type DiscordPayload struct {
+	Embeds []DiscordEmbed `json:"embeds"`
+}
+
+// DiscordEmbed is a rich message embed.
+type DiscordEmbed struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Color       int    `json:"color"`
+}
+
+// NewDiscordNotifier creates a notifier that posts to the given Discord webhook URL.
+func NewDiscordNotifier(webhookURL string) *DiscordNotifier {
+	return &DiscordNotifier{
+		WebhookURL: webhookURL,
+	}
+}
+
+// SendNotification posts the notification to Discord as an embed.
+// The subject becomes the embed title and the message its description, truncated to
+// Discord's 2000-character limit. The embed color follows the severity attached to ctx
+// (see WithSeverity): red for failures, orange for warnings, green for success, blue otherwise.
+func (d *DiscordNotifier) SendNotification(ctx context.Context, subject, message string) error {
+	err := d.send(ctx, subject, message)
+	metrics.RecordNotification(err)
+	return err
+}
+
+// send builds the Discord payload and POSTs it.
+func (d *DiscordNotifier) send(ctx context.Context, subject, message string) error {
+	payload := DiscordPayload{
+		Embeds: []DiscordEmbed{{
+			Title:       subject,
+			Description: truncate(message, discordMaxContentLength),
+			Color:       discordColor(SeverityFromContext(ctx)),
+		}},
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal discord payload: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", d.WebhookURL, bytes.NewBuffer(data))
+	if err != nil {
+		return fmt.Errorf("failed to create discord request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", UserAgent)
+
+	resp, err := httpClient(d.Timeout).Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send discord request: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// Discord replies 204 No Content on success
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("discord webhook failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+// discordColor maps a severity to an embed color.
+func discordColor(severity Severity) int {
+	switch severity {
+	case SeverityFailure:
+		return discordColorFailure
+	case SeverityWarning:
+		return discordColorWarning
+	case SeveritySuccess:
+		return discordColorSuccess
+	default:
+		return discordColorInfo
+	}
+}
+
+// truncate shortens s to at most max characters (runes), replacing the tail with an ellipsis.
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max-1]) + "…"
+}
@@ -0,0 +1,94 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// discordServer starts a fake Discord webhook that records the last payload
+func discordServer(t *testing.T, status int, received *DiscordPayload) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(body, received))
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDiscordNotifier_SendNotification_Embed(t *testing.T) {
+	var received DiscordPayload
+	server := discordServer(t, http.StatusNoContent, &received)
+
+	err := NewDiscordNotifier(server.URL).SendNotification(context.Background(), "Stale PR: Fix bug", "PR #1 is pending review")
+
+	require.NoError(t, err)
+	require.Len(t, received.Embeds, 1)
+	assert.Equal(t, "Stale PR: Fix bug", received.Embeds[0].Title)
+	assert.Equal(t, "PR #1 is pending review", received.Embeds[0].Description)
+	assert.Equal(t, discordColorInfo, received.Embeds[0].Color)
+}
+
+func TestDiscordNotifier_SendNotification_SeverityColor(t *testing.T) {
+	tests := []struct {
+		severity Severity
+		color    int
+	}{
+		{SeverityInfo, discordColorInfo},
+		{SeveritySuccess, discordColorSuccess},
+		{SeverityWarning, discordColorWarning},
+		{SeverityFailure, discordColorFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.severity), func(t *testing.T) {
+			var received DiscordPayload
+			server := discordServer(t, http.StatusNoContent, &received)
+
+			ctx := WithSeverity(context.Background(), tt.severity)
+			require.NoError(t, NewDiscordNotifier(server.URL).SendNotification(ctx, "Subject", "Message"))
+
+			require.Len(t, received.Embeds, 1)
+			assert.Equal(t, tt.color, received.Embeds[0].Color)
+		})
+	}
+}
+
+func TestDiscordNotifier_SendNotification_TruncatesLongBody(t *testing.T) {
+	var received DiscordPayload
+	server := discordServer(t, http.StatusNoContent, &received)
+
+	longMessage := strings.Repeat("é", 2500)
+	require.NoError(t, NewDiscordNotifier(server.URL).SendNotification(context.Background(), "Subject", longMessage))
+
+	description := received.Embeds[0].Description
+	assert.Equal(t, discordMaxContentLength, utf8.RuneCountInString(description))
+	assert.True(t, strings.HasSuffix(description, "…"))
+}
+
+func TestDiscordNotifier_SendNotification_ErrorStatus(t *testing.T) {
+	var received DiscordPayload
+	server := discordServer(t, http.StatusBadRequest, &received)
+
+	err := NewDiscordNotifier(server.URL).SendNotification(context.Background(), "Subject", "Message")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 400")
+}
+
+func TestSeverityFromContext_Default(t *testing.T) {
+	assert.Equal(t, SeverityInfo, SeverityFromContext(context.Background()))
+	assert.Equal(t, SeverityFailure, SeverityFromContext(WithSeverity(context.Background(), SeverityFailure)))
+}
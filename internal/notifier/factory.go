@@ -0,0 +1,141 @@
+package notifier
+
+import (
+	"fmt"
+
+	"watchdog/internal/config"
+)
+
+// NewFromConfig creates the notifier for the backends selected by cfg.Backend.
+//
+// When more than one backend is listed, notifications fan out to all of them
+// through a MultiNotifier. If message_prefix, message_suffix or subject_prefix is set,
+// a PrefixNotifier adds them to every notification. If dedup_window is set, the result is wrapped in a
+// DedupNotifier so identical notifications are sent at most once per window.
+// If rate_limit is set, a RateLimitNotifier then caps the notifications per minute.
+// If quiet_hours is set, the outermost layer holds notifications back during that window.
+//
+// It returns an error if a backend is unknown or missing its required settings
+// (see ValidateBackend), or if the quiet hours can't be parsed.
+func NewFromConfig(cfg config.NotifierConfig) (Notifier, error) {
+	backends := cfg.GetBackends()
+	notifiers := make([]Notifier, 0, len(backends))
+	for _, backend := range backends {
+		if err := ValidateBackend(cfg, backend); err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, newBackend(cfg, backend))
+	}
+
+	var notif Notifier
+	if len(notifiers) == 1 {
+		notif = notifiers[0]
+	} else {
+		notif = NewMultiNotifier(notifiers...)
+	}
+
+	if cfg.MessagePrefix != "" || cfg.MessageSuffix != "" || cfg.SubjectPrefix != "" {
+		prefixed := NewPrefixNotifier(notif, cfg.MessagePrefix, cfg.MessageSuffix)
+		prefixed.SubjectPrefix = cfg.SubjectPrefix
+		notif = prefixed
+	}
+
+	if window := cfg.GetDedupWindow(); window > 0 {
+		notif = NewDedupNotifier(notif, window)
+	}
+
+	if cfg.RateLimit.IsEnabled() {
+		limited := NewRateLimitNotifier(notif, cfg.RateLimit.MaxPerMinute)
+		limited.Delay = cfg.RateLimit.GetMode() == config.RateLimitDelay
+		notif = limited
+	}
+
+	if cfg.QuietHours.IsEnabled() {
+		start, end, loc, err := cfg.QuietHours.GetWindow()
+		if err != nil {
+			return nil, fmt.Errorf("notifier.quiet_hours.%v", err)
+		}
+		quiet := NewQuietHoursNotifier(notif, start, end, loc)
+		quiet.Queue = cfg.QuietHours.GetMode() == config.QuietHoursQueue
+		notif = quiet
+	}
+	return notif, nil
+}
+
+// ValidateBackend checks the settings required by a single notifier backend.
+func ValidateBackend(cfg config.NotifierConfig, backend string) error {
+	switch backend {
+	case config.BackendApprise:
+		if cfg.AppriseAPIURL == "" {
+			return fmt.Errorf("notifier.apprise_api_url is required but not set")
+		}
+		if len(cfg.GetServiceURLs()) == 0 {
+			return fmt.Errorf("notifier.apprise_service_url is required but not set")
+		}
+		for _, serviceURL := range cfg.GetServiceURLs() {
+			if _, err := CheckServiceURL(serviceURL); err != nil {
+				return fmt.Errorf("notifier.apprise_service_url: %v", err)
+			}
+		}
+	case config.BackendSlack:
+		if cfg.SlackWebhookURL == "" {
+			return fmt.Errorf("notifier.slack_webhook_url is required when backend is slack")
+		}
+	case config.BackendDiscord:
+		if cfg.DiscordWebhookURL == "" {
+			return fmt.Errorf("notifier.discord_webhook_url is required when backend is discord")
+		}
+	case config.BackendTelegram:
+		if cfg.TelegramBotToken == "" || cfg.TelegramChatID == "" {
+			return fmt.Errorf("notifier.telegram_bot_token and notifier.telegram_chat_id are required when backend is telegram")
+		}
+		switch cfg.TelegramParseMode {
+		case "", "Markdown", "MarkdownV2", "HTML":
+		default:
+			return fmt.Errorf("notifier.telegram_parse_mode must be one of Markdown, MarkdownV2 or HTML (got %q)", cfg.TelegramParseMode)
+		}
+	default:
+		return fmt.Errorf("notifier.backend must be one of apprise, slack, discord or telegram (got %q)", backend)
+	}
+	return nil
+}
+
+// newBackend creates the notifier for a single, already validated backend.
+//   - apprise: sends via an Apprise API server, which supports Telegram, Discord, email, and more
+//   - slack: posts directly to a Slack incoming webhook
+//   - discord: posts an embed directly to a Discord webhook
+//   - telegram: calls the Telegram Bot API sendMessage method
+func newBackend(cfg config.NotifierConfig, backend string) Notifier {
+	switch backend {
+	case config.BackendSlack:
+		notif := NewSlackNotifier(cfg.SlackWebhookURL)
+		notif.Timeout = cfg.GetTimeout()
+		return notif
+	case config.BackendDiscord:
+		notif := NewDiscordNotifier(cfg.DiscordWebhookURL)
+		notif.Timeout = cfg.GetTimeout()
+		return notif
+	case config.BackendTelegram:
+		notif := NewTelegramNotifier(cfg.TelegramBotToken, cfg.TelegramChatID)
+		notif.ParseMode = cfg.TelegramParseMode
+		notif.Timeout = cfg.GetTimeout()
+		return notif
+	default:
+		notif := NewWebhookNotifier(cfg.AppriseAPIURL, cfg.GetServiceURLs())
+		notif.Format = cfg.GetFormat()
+		notif.Timeout = cfg.GetTimeout()
+		notif.MaxInFlight = cfg.MaxInFlight
+		notif.Auth = WebhookAuth{
+			Username: cfg.Auth.Username,
+			Password: cfg.Auth.Password,
+			Headers:  cfg.Auth.Headers,
+		}
+		notif.RetryConfig = &RetryConfig{
+			MaxRetries:        cfg.GetMaxRetries(),
+			InitialBackoff:    cfg.GetInitialBackoff(),
+			MaxBackoff:        cfg.GetMaxBackoff(),
+			BackoffMultiplier: cfg.GetBackoffMultiplier(),
+		}
+		return notif
+	}
+}
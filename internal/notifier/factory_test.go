@@ -0,0 +1,170 @@
+package notifier
+
+import (
+	"testing"
+	"time"
+
+	"watchdog/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFromConfig_Backends(t *testing.T) {
+	slack, err := NewFromConfig(config.NotifierConfig{Backend: "slack", SlackWebhookURL: "https://hooks.slack.com/x"})
+	require.NoError(t, err)
+	assert.IsType(t, &SlackNotifier{}, slack)
+
+	discord, err := NewFromConfig(config.NotifierConfig{Backend: "discord", DiscordWebhookURL: "https://discord.com/api/webhooks/1/x"})
+	require.NoError(t, err)
+	assert.IsType(t, &DiscordNotifier{}, discord)
+
+	telegram, err := NewFromConfig(config.NotifierConfig{Backend: "telegram", TelegramBotToken: "123:ABC", TelegramChatID: "42", TelegramParseMode: "HTML"})
+	require.NoError(t, err)
+	require.IsType(t, &TelegramNotifier{}, telegram)
+	assert.Equal(t, "HTML", telegram.(*TelegramNotifier).ParseMode)
+
+	apprise, err := NewFromConfig(config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com", AppriseServiceURL: "tgram://t/c", Format: "markdown"})
+	require.NoError(t, err)
+	require.IsType(t, &WebhookNotifier{}, apprise)
+	assert.Equal(t, "markdown", apprise.(*WebhookNotifier).Format)
+	assert.Equal(t, DefaultRetryConfig, *apprise.(*WebhookNotifier).RetryConfig)
+
+	noRetries := 0
+	failFast, err := NewFromConfig(config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com", AppriseServiceURL: "tgram://t/c", MaxRetries: &noRetries, InitialBackoff: "1s"})
+	require.NoError(t, err)
+	require.IsType(t, &WebhookNotifier{}, failFast)
+	assert.Equal(t, 0, failFast.(*WebhookNotifier).RetryConfig.MaxRetries)
+	assert.Equal(t, time.Second, failFast.(*WebhookNotifier).RetryConfig.InitialBackoff)
+
+	limited, err := NewFromConfig(config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com", AppriseServiceURL: "tgram://t/c", MaxInFlight: 4})
+	require.NoError(t, err)
+	assert.Equal(t, 4, limited.(*WebhookNotifier).MaxInFlight)
+
+	authenticated, err := NewFromConfig(config.NotifierConfig{
+		AppriseAPIURL:     "https://apprise.example.com",
+		AppriseServiceURL: "tgram://t/c",
+		Auth:              config.WebhookAuthConfig{Username: "watchdog", Password: "s3cret", Headers: map[string]string{"x-api-key": "key123"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, WebhookAuth{Username: "watchdog", Password: "s3cret", Headers: map[string]string{"x-api-key": "key123"}}, authenticated.(*WebhookNotifier).Auth)
+}
+
+func TestNewFromConfig_MultipleBackends(t *testing.T) {
+	multi, err := NewFromConfig(config.NotifierConfig{
+		Backend:           "slack,apprise,slack",
+		SlackWebhookURL:   "https://hooks.slack.com/x",
+		AppriseAPIURL:     "https://apprise.example.com/notify",
+		AppriseServiceURL: "tgram://t/c",
+	})
+	require.NoError(t, err)
+	require.IsType(t, &MultiNotifier{}, multi)
+
+	notifiers := multi.(*MultiNotifier).Notifiers
+	require.Len(t, notifiers, 2)
+	assert.IsType(t, &SlackNotifier{}, notifiers[0])
+	assert.IsType(t, &WebhookNotifier{}, notifiers[1])
+}
+
+func TestNewFromConfig_Wrappers(t *testing.T) {
+	deduped, err := NewFromConfig(config.NotifierConfig{Backend: "slack", SlackWebhookURL: "https://hooks.slack.com/x", DedupWindow: "10m"})
+	require.NoError(t, err)
+	require.IsType(t, &DedupNotifier{}, deduped)
+	assert.Equal(t, 10*time.Minute, deduped.(*DedupNotifier).DedupWindow)
+	assert.IsType(t, &SlackNotifier{}, deduped.(*DedupNotifier).Next)
+
+	quiet, err := NewFromConfig(config.NotifierConfig{Backend: "slack", SlackWebhookURL: "https://hooks.slack.com/x", DedupWindow: "10m",
+		QuietHours: config.QuietHoursConfig{Start: "22:00", End: "07:00", Timezone: "UTC", Mode: "queue"}})
+	require.NoError(t, err)
+	require.IsType(t, &QuietHoursNotifier{}, quiet)
+	assert.Equal(t, 22*time.Hour, quiet.(*QuietHoursNotifier).Start)
+	assert.Equal(t, 7*time.Hour, quiet.(*QuietHoursNotifier).End)
+	assert.True(t, quiet.(*QuietHoursNotifier).Queue)
+	assert.IsType(t, &DedupNotifier{}, quiet.(*QuietHoursNotifier).Next)
+
+	limited, err := NewFromConfig(config.NotifierConfig{Backend: "slack", SlackWebhookURL: "https://hooks.slack.com/x", DedupWindow: "10m",
+		RateLimit: config.RateLimitConfig{MaxPerMinute: 10, Mode: "Delay"}})
+	require.NoError(t, err)
+	require.IsType(t, &RateLimitNotifier{}, limited)
+	assert.Equal(t, 10, limited.(*RateLimitNotifier).MaxPerMinute)
+	assert.True(t, limited.(*RateLimitNotifier).Delay)
+	assert.IsType(t, &DedupNotifier{}, limited.(*RateLimitNotifier).Next)
+
+	prefixed, err := NewFromConfig(config.NotifierConfig{Backend: "slack", SlackWebhookURL: "https://hooks.slack.com/x", DedupWindow: "10m",
+		MessagePrefix: "[staging]", MessageSuffix: "-- watchdog", SubjectPrefix: "[stg]"})
+	require.NoError(t, err)
+	require.IsType(t, &DedupNotifier{}, prefixed)
+	require.IsType(t, &PrefixNotifier{}, prefixed.(*DedupNotifier).Next)
+	prefix := prefixed.(*DedupNotifier).Next.(*PrefixNotifier)
+	assert.Equal(t, "[staging]", prefix.MessagePrefix)
+	assert.Equal(t, "-- watchdog", prefix.MessageSuffix)
+	assert.Equal(t, "[stg]", prefix.SubjectPrefix)
+	assert.IsType(t, &SlackNotifier{}, prefix.Next)
+}
+
+func TestNewFromConfig_MissingRequiredFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.NotifierConfig
+		wantErr string
+	}{
+		{
+			name:    "apprise missing api url",
+			cfg:     config.NotifierConfig{AppriseServiceURL: "tgram://t/c"},
+			wantErr: "notifier.apprise_api_url is required",
+		},
+		{
+			name:    "apprise missing service url",
+			cfg:     config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com"},
+			wantErr: "notifier.apprise_service_url is required",
+		},
+		{
+			name:    "apprise malformed service url",
+			cfg:     config.NotifierConfig{AppriseAPIURL: "https://apprise.example.com", AppriseServiceURL: "tgram://t/c, discord://webhook_id"},
+			wantErr: `notifier.apprise_service_url: "discord://webhook_id" is missing path segments`,
+		},
+		{
+			name:    "slack missing webhook",
+			cfg:     config.NotifierConfig{Backend: "slack"},
+			wantErr: "notifier.slack_webhook_url is required",
+		},
+		{
+			name:    "discord missing webhook",
+			cfg:     config.NotifierConfig{Backend: "discord"},
+			wantErr: "notifier.discord_webhook_url is required",
+		},
+		{
+			name:    "telegram missing chat id",
+			cfg:     config.NotifierConfig{Backend: "telegram", TelegramBotToken: "123:ABC"},
+			wantErr: "notifier.telegram_bot_token and notifier.telegram_chat_id are required",
+		},
+		{
+			name:    "telegram invalid parse mode",
+			cfg:     config.NotifierConfig{Backend: "telegram", TelegramBotToken: "123:ABC", TelegramChatID: "42", TelegramParseMode: "markdown"},
+			wantErr: "notifier.telegram_parse_mode must be one of",
+		},
+		{
+			name:    "second backend incomplete",
+			cfg:     config.NotifierConfig{Backend: "slack,discord", SlackWebhookURL: "https://hooks.slack.com/x"},
+			wantErr: "notifier.discord_webhook_url is required",
+		},
+		{
+			name:    "unknown backend",
+			cfg:     config.NotifierConfig{Backend: "pigeon"},
+			wantErr: "notifier.backend must be one of",
+		},
+		{
+			name:    "invalid quiet hours",
+			cfg:     config.NotifierConfig{Backend: "slack", SlackWebhookURL: "https://hooks.slack.com/x", QuietHours: config.QuietHoursConfig{Start: "late", End: "07:00"}},
+			wantErr: "notifier.quiet_hours.start",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notif, err := NewFromConfig(tt.cfg)
+			assert.Nil(t, notif)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// MultiNotifier implements the Notifier interface by fanning each notification out
+// to several backends (e.g., a Slack webhook and email via Apprise).
+type MultiNotifier struct {
+	// Notifiers are the backends every notification is sent to
+	Notifiers []Notifier
+}
+
+// NewMultiNotifier creates a notifier that sends to all of the given notifiers.
+func NewMultiNotifier(notifiers ...Notifier) *MultiNotifier {
+	return &MultiNotifier{
+		Notifiers: notifiers,
+	}
+}
+
+// SendNotification sends the notification to every backend concurrently.
+// A failing backend doesn't prevent delivery to the others; all failures are
+// returned together as a single joined error, or nil if every backend succeeded.
+func (m *MultiNotifier) SendNotification(ctx context.Context, subject, message string) error {
+	errs := make([]error, len(m.Notifiers))
+
+	var wg sync.WaitGroup
+	for i, n := range m.Notifiers {
+		wg.Add(1)
+		go func(i int, n Notifier) {
+			defer wg.Done()
+			if err := n.SendNotification(ctx, subject, message); err != nil {
+				errs[i] = fmt.Errorf("%T: %w", n, err)
+			}
+		}(i, n)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
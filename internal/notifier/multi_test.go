@@ -0,0 +1,62 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockNotifier struct {
+	mock.Mock
+}
+
+func (m *mockNotifier) SendNotification(ctx context.Context, subject, message string) error {
+	args := m.Called(ctx, subject, message)
+	return args.Error(0)
+}
+
+func TestMultiNotifier_SendNotification_AllSucceed(t *testing.T) {
+	first := &mockNotifier{}
+	second := &mockNotifier{}
+	first.On("SendNotification", mock.Anything, "Subject", "Message").Return(nil)
+	second.On("SendNotification", mock.Anything, "Subject", "Message").Return(nil)
+
+	err := NewMultiNotifier(first, second).SendNotification(context.Background(), "Subject", "Message")
+
+	require.NoError(t, err)
+	first.AssertExpectations(t)
+	second.AssertExpectations(t)
+}
+
+func TestMultiNotifier_SendNotification_OneFails(t *testing.T) {
+	failing := &mockNotifier{}
+	working := &mockNotifier{}
+	failing.On("SendNotification", mock.Anything, "Subject", "Message").Return(errors.New("slack webhook failed"))
+	working.On("SendNotification", mock.Anything, "Subject", "Message").Return(nil)
+
+	err := NewMultiNotifier(failing, working).SendNotification(context.Background(), "Subject", "Message")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "slack webhook failed")
+	failing.AssertExpectations(t)
+	working.AssertExpectations(t)
+}
+
+func TestMultiNotifier_SendNotification_AggregatesErrors(t *testing.T) {
+	first := &mockNotifier{}
+	second := &mockNotifier{}
+	firstErr := errors.New("first failed")
+	first.On("SendNotification", mock.Anything, "Subject", "Message").Return(firstErr)
+	second.On("SendNotification", mock.Anything, "Subject", "Message").Return(errors.New("second failed"))
+
+	err := NewMultiNotifier(first, second).SendNotification(context.Background(), "Subject", "Message")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "first failed")
+	assert.Contains(t, err.Error(), "second failed")
+	assert.ErrorIs(t, err, firstErr)
+}
@@ -2,6 +2,55 @@ package notifier
 
 import "context"
 
+// Severity describes how serious a notification is.
+// The values match Apprise notification types.
+type Severity string
+
+// Supported notification severities.
+const (
+	SeverityInfo    Severity = "info"
+	SeveritySuccess Severity = "success"
+	SeverityWarning Severity = "warning"
+	SeverityFailure Severity = "failure"
+)
+
+// severityKey is the context key for a notification's severity.
+type severityKey struct{}
+
+// WithSeverity returns a copy of ctx carrying the given notification severity.
+// Backends that support it style the notification accordingly (e.g., the Apprise
+// notification type, so success renders green, or Discord embed colors).
+func WithSeverity(ctx context.Context, severity Severity) context.Context {
+	return context.WithValue(ctx, severityKey{}, severity)
+}
+
+// SeverityFromContext returns the severity attached with WithSeverity, or SeverityInfo if none.
+func SeverityFromContext(ctx context.Context) Severity {
+	if severity, ok := ctx.Value(severityKey{}).(Severity); ok {
+		return severity
+	}
+	return SeverityInfo
+}
+
+// tagsKey is the context key for a notification's routing tags.
+type tagsKey struct{}
+
+// WithTags returns a copy of ctx carrying routing tags for the notification.
+// Apprise only notifies the services carrying one of the tags (e.g., "billing" or "dev");
+// backends without tag support ignore them. No tags leaves ctx unchanged.
+func WithTags(ctx context.Context, tags ...string) context.Context {
+	if len(tags) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, tagsKey{}, tags)
+}
+
+// TagsFromContext returns the tags attached with WithTags, or nil if none.
+func TagsFromContext(ctx context.Context) []string {
+	tags, _ := ctx.Value(tagsKey{}).([]string)
+	return tags
+}
+
 // Notifier defines the interface for sending notifications.
 // This abstraction allows us to support multiple notification backends
 // (webhook/Apprise, Telegram, email, etc.) with a consistent interface.
@@ -0,0 +1,48 @@
+package notifier
+
+import (
+	"context"
+)
+
+// PrefixNotifier wraps another Notifier and tags every notification, e.g. with the
+// environment it comes from when several watchdog instances alert the same channel.
+//
+// MessagePrefix and MessageSuffix are added on their own lines before and after the
+// body. The subject is left untouched unless SubjectPrefix is set.
+type PrefixNotifier struct {
+	// Next is the notifier that delivers the tagged notifications
+	Next Notifier
+
+	// SubjectPrefix is added before the subject, separated by a space (empty = none)
+	SubjectPrefix string
+
+	// MessagePrefix is added on a line before the body (empty = none)
+	MessagePrefix string
+
+	// MessageSuffix is added on a line after the body (empty = none)
+	MessageSuffix string
+}
+
+// NewPrefixNotifier creates a notifier that wraps every body sent to next between
+// prefix and suffix, leaving subjects untouched.
+func NewPrefixNotifier(next Notifier, prefix, suffix string) *PrefixNotifier {
+	return &PrefixNotifier{
+		Next:          next,
+		MessagePrefix: prefix,
+		MessageSuffix: suffix,
+	}
+}
+
+// SendNotification forwards the notification with the prefixes and suffix added.
+func (p *PrefixNotifier) SendNotification(ctx context.Context, subject, message string) error {
+	if p.SubjectPrefix != "" {
+		subject = p.SubjectPrefix + " " + subject
+	}
+	if p.MessagePrefix != "" {
+		message = p.MessagePrefix + "\n" + message
+	}
+	if p.MessageSuffix != "" {
+		message = message + "\n" + p.MessageSuffix
+	}
+	return p.Next.SendNotification(ctx, subject, message)
+}
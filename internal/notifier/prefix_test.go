@@ -0,0 +1,50 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefixNotifier_WrapsBody(t *testing.T) {
+	next := &mockNotifier{}
+	next.On("SendNotification", mock.Anything, "Subject", "[staging]\nMessage\n-- watchdog").Return(nil).Once()
+
+	p := NewPrefixNotifier(next, "[staging]", "-- watchdog")
+	require.NoError(t, p.SendNotification(context.Background(), "Subject", "Message"))
+
+	next.AssertExpectations(t)
+}
+
+func TestPrefixNotifier_SubjectPrefix(t *testing.T) {
+	next := &mockNotifier{}
+	next.On("SendNotification", mock.Anything, "[prod] Subject", "Message").Return(nil).Once()
+
+	p := NewPrefixNotifier(next, "", "")
+	p.SubjectPrefix = "[prod]"
+	require.NoError(t, p.SendNotification(context.Background(), "Subject", "Message"))
+
+	next.AssertExpectations(t)
+}
+
+func TestPrefixNotifier_OnlySuffix(t *testing.T) {
+	next := &mockNotifier{}
+	next.On("SendNotification", mock.Anything, "Subject", "Message\nfrom staging").Return(nil).Once()
+
+	p := NewPrefixNotifier(next, "", "from staging")
+	require.NoError(t, p.SendNotification(context.Background(), "Subject", "Message"))
+
+	next.AssertExpectations(t)
+}
+
+func TestPrefixNotifier_ReturnsNextError(t *testing.T) {
+	next := &mockNotifier{}
+	next.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("boom"))
+
+	p := NewPrefixNotifier(next, "[staging]", "")
+	assert.EqualError(t, p.SendNotification(context.Background(), "Subject", "Message"), "boom")
+}
@@ -0,0 +1,158 @@
+package notifier
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// QuietHoursNotifier wraps another Notifier and holds back notifications during a
+// daily quiet window (e.g., 22:00-07:00), so nobody gets paged at 3am about a stale PR.
+//
+// Notifications sent during the window are dropped, or, with Queue enabled, kept and
+// delivered in order once the window ends.
+type QuietHoursNotifier struct {
+	// Next is the notifier that delivers notifications outside the quiet window
+	Next Notifier
+
+	// Start and End are the window's offsets from midnight in Location.
+	// If End is before Start, the window crosses midnight.
+	Start time.Duration
+	End   time.Duration
+
+	// Location is the time zone the window is expressed in
+	Location *time.Location
+
+	// Queue delivers notifications held back during the window once it ends,
+	// instead of dropping them
+	Queue bool
+
+	// now returns the current time (overridable in tests)
+	now func() time.Time
+
+	mu     sync.Mutex
+	queued []queuedNotification
+	timer  *time.Timer
+}
+
+// queuedNotification is a notification held back until the quiet window ends.
+type queuedNotification struct {
+	subject  string
+	message  string
+	severity Severity
+	tags     []string
+}
+
+// NewQuietHoursNotifier creates a notifier that forwards to next outside the window
+// [start, end) in loc. start and end are offsets from midnight (e.g., 22*time.Hour).
+func NewQuietHoursNotifier(next Notifier, start, end time.Duration, loc *time.Location) *QuietHoursNotifier {
+	return &QuietHoursNotifier{
+		Next:     next,
+		Start:    start,
+		End:      end,
+		Location: loc,
+		now:      time.Now,
+	}
+}
+
+// SendNotification forwards the notification, unless the current time is inside the quiet
+// window. Then it is dropped or queued (returning nil in both cases).
+func (q *QuietHoursNotifier) SendNotification(ctx context.Context, subject, message string) error {
+	now := q.now()
+	if !q.InWindow(now) {
+		// Deliver anything still held back before the new notification, to keep the order
+		q.Flush(ctx)
+		return q.Next.SendNotification(ctx, subject, message)
+	}
+
+	if !q.Queue {
+		log.Info().Str("subject", subject).Msg("Suppressing notification during quiet hours")
+		return nil
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.queued = append(q.queued, queuedNotification{
+		subject:  subject,
+		message:  message,
+		severity: SeverityFromContext(ctx),
+		tags:     TagsFromContext(ctx),
+	})
+	if q.timer == nil {
+		q.timer = time.AfterFunc(q.untilEnd(now), q.windowEnded)
+	}
+	log.Info().
+		Str("subject", subject).
+		Int("queued", len(q.queued)).
+		Msg("Queueing notification until quiet hours end")
+	return nil
+}
+
+// Flush delivers the notifications queued during the quiet window, in the order they
+// were sent. It does nothing while the window is still active.
+// Failed deliveries are logged and not retried.
+func (q *QuietHoursNotifier) Flush(ctx context.Context) {
+	if q.InWindow(q.now()) {
+		return
+	}
+
+	q.mu.Lock()
+	queued := q.queued
+	q.queued = nil
+	if q.timer != nil {
+		q.timer.Stop()
+		q.timer = nil
+	}
+	q.mu.Unlock()
+
+	for _, n := range queued {
+		sendCtx := WithTags(WithSeverity(ctx, n.severity), n.tags...)
+		if err := q.Next.SendNotification(sendCtx, n.subject, n.message); err != nil {
+			log.Error().Err(err).Str("subject", n.subject).Msg("Failed to deliver notification queued during quiet hours")
+		}
+	}
+}
+
+// windowEnded delivers the queue when the quiet window is over. If the clock says the
+// window is still active (e.g., across a DST change), it checks again when it should end.
+func (q *QuietHoursNotifier) windowEnded() {
+	now := q.now()
+	if q.InWindow(now) {
+		q.mu.Lock()
+		q.timer = time.AfterFunc(q.untilEnd(now), q.windowEnded)
+		q.mu.Unlock()
+		return
+	}
+	q.Flush(context.Background())
+}
+
+// InWindow reports whether t falls inside the quiet window.
+func (q *QuietHoursNotifier) InWindow(t time.Time) bool {
+	offset := q.sinceMidnight(t)
+	if q.Start <= q.End {
+		return offset >= q.Start && offset < q.End
+	}
+	// The window crosses midnight, e.g. 22:00-07:00
+	return offset >= q.Start || offset < q.End
+}
+
+// untilEnd returns how long until the quiet window containing t ends.
+func (q *QuietHoursNotifier) untilEnd(t time.Time) time.Duration {
+	wait := q.End - q.sinceMidnight(t)
+	if wait <= 0 {
+		wait += 24 * time.Hour
+	}
+	return wait
+}
+
+// sinceMidnight returns the time of day of t in the window's time zone.
+func (q *QuietHoursNotifier) sinceMidnight(t time.Time) time.Duration {
+	if q.Location != nil {
+		t = t.In(q.Location)
+	}
+	return time.Duration(t.Hour())*time.Hour +
+		time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second
+}
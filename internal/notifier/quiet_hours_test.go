@@ -0,0 +1,115 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestQuietHoursNotifier returns a 22:00-07:00 UTC QuietHoursNotifier with a controllable clock.
+func newTestQuietHoursNotifier(next Notifier, now time.Time) (*QuietHoursNotifier, *time.Time) {
+	q := NewQuietHoursNotifier(next, 22*time.Hour, 7*time.Hour, time.UTC)
+	q.now = func() time.Time { return now }
+	return q, &now
+}
+
+func TestQuietHoursNotifier_SuppressesInsideWindow(t *testing.T) {
+	next := &mockNotifier{}
+	q, _ := newTestQuietHoursNotifier(next, time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC))
+
+	require.NoError(t, q.SendNotification(context.Background(), "Stale PR", "PR #1 needs review"))
+
+	next.AssertNotCalled(t, "SendNotification", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestQuietHoursNotifier_DeliversOutsideWindow(t *testing.T) {
+	next := &mockNotifier{}
+	next.On("SendNotification", mock.Anything, "Stale PR", "PR #1 needs review").Return(nil).Once()
+	q, _ := newTestQuietHoursNotifier(next, time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	require.NoError(t, q.SendNotification(context.Background(), "Stale PR", "PR #1 needs review"))
+
+	next.AssertExpectations(t)
+}
+
+func TestQuietHoursNotifier_InWindow(t *testing.T) {
+	overnight := NewQuietHoursNotifier(nil, 22*time.Hour, 7*time.Hour, time.UTC)
+	daytime := NewQuietHoursNotifier(nil, 9*time.Hour, 17*time.Hour+30*time.Minute, time.UTC)
+
+	tests := []struct {
+		clock     string
+		overnight bool
+		daytime   bool
+	}{
+		{clock: "21:59", overnight: false, daytime: false},
+		{clock: "22:00", overnight: true, daytime: false},
+		{clock: "23:59", overnight: true, daytime: false},
+		{clock: "00:00", overnight: true, daytime: false},
+		{clock: "06:59", overnight: true, daytime: false},
+		{clock: "07:00", overnight: false, daytime: false},
+		{clock: "09:00", overnight: false, daytime: true},
+		{clock: "17:29", overnight: false, daytime: true},
+		{clock: "17:30", overnight: false, daytime: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.clock, func(t *testing.T) {
+			clock, err := time.Parse("15:04", tt.clock)
+			require.NoError(t, err)
+			at := time.Date(2024, 1, 1, clock.Hour(), clock.Minute(), 0, 0, time.UTC)
+
+			assert.Equal(t, tt.overnight, overnight.InWindow(at))
+			assert.Equal(t, tt.daytime, daytime.InWindow(at))
+		})
+	}
+}
+
+func TestQuietHoursNotifier_UsesLocation(t *testing.T) {
+	// 22:00-07:00 in UTC+2 is 20:00-05:00 UTC
+	q := NewQuietHoursNotifier(nil, 22*time.Hour, 7*time.Hour, time.FixedZone("UTC+2", 2*60*60))
+
+	assert.True(t, q.InWindow(time.Date(2024, 1, 1, 20, 30, 0, 0, time.UTC)))
+	assert.False(t, q.InWindow(time.Date(2024, 1, 1, 5, 30, 0, 0, time.UTC)))
+}
+
+func TestQuietHoursNotifier_QueuesUntilWindowEnds(t *testing.T) {
+	next := &mockNotifier{}
+	var delivered []string
+	var severities []Severity
+	next.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			delivered = append(delivered, args.String(1))
+			severities = append(severities, SeverityFromContext(args.Get(0).(context.Context)))
+		}).
+		Return(nil)
+	q, now := newTestQuietHoursNotifier(next, time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC))
+	q.Queue = true
+
+	ctx := WithSeverity(context.Background(), SeverityWarning)
+	require.NoError(t, q.SendNotification(ctx, "First", "queued"))
+	require.NoError(t, q.SendNotification(context.Background(), "Second", "queued"))
+
+	// Nothing goes out while the window is active, even when flushed
+	q.Flush(context.Background())
+	assert.Empty(t, delivered)
+
+	// After the window, the queue is delivered in order before the new notification
+	*now = time.Date(2024, 1, 2, 7, 0, 0, 0, time.UTC)
+	require.NoError(t, q.SendNotification(context.Background(), "Third", "live"))
+
+	assert.Equal(t, []string{"First", "Second", "Third"}, delivered)
+	assert.Equal(t, SeverityWarning, severities[0])
+	assert.Empty(t, q.queued)
+	assert.Nil(t, q.timer)
+}
+
+func TestQuietHoursNotifier_UntilEnd(t *testing.T) {
+	q := NewQuietHoursNotifier(nil, 22*time.Hour, 7*time.Hour, time.UTC)
+
+	assert.Equal(t, 8*time.Hour, q.untilEnd(time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 2*time.Hour, q.untilEnd(time.Date(2024, 1, 1, 5, 0, 0, 0, time.UTC)))
+}
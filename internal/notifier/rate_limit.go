@@ -0,0 +1,117 @@
+package notifier
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// RateLimitNotifier wraps another Notifier and caps how many notifications it delivers,
+// so an alert storm (e.g., after a misconfiguration) doesn't flood the notification channel.
+//
+// It is a token bucket: up to MaxPerMinute notifications can be sent at once, and the
+// bucket refills at MaxPerMinute per minute. Notifications over the limit are dropped,
+// or, with Delay enabled, held until the rate allows them.
+type RateLimitNotifier struct {
+	// Next is the notifier that delivers notifications within the limit
+	Next Notifier
+
+	// MaxPerMinute is the sustained rate and the burst size
+	MaxPerMinute int
+
+	// Delay waits for the rate to allow a notification over the limit, instead of dropping it
+	Delay bool
+
+	// now returns the current time and after waits for a duration (overridable in tests)
+	now   func() time.Time
+	after func(time.Duration) <-chan time.Time
+
+	mu     sync.Mutex
+	tokens float64   // available notifications; negative while delayed ones are waiting
+	last   time.Time // when tokens was last refilled
+}
+
+// NewRateLimitNotifier creates a notifier that forwards at most perMinute notifications
+// per minute to next, dropping the excess.
+func NewRateLimitNotifier(next Notifier, perMinute int) *RateLimitNotifier {
+	return &RateLimitNotifier{
+		Next:         next,
+		MaxPerMinute: perMinute,
+		now:          time.Now,
+		after:        time.After,
+		tokens:       float64(perMinute),
+	}
+}
+
+// SendNotification forwards the notification if the rate allows it. Over the limit, it is
+// logged and dropped (returning nil), or, with Delay, sent once the rate allows it.
+// A delayed notification returns ctx's error if ctx is done before then.
+func (r *RateLimitNotifier) SendNotification(ctx context.Context, subject, message string) error {
+	wait, ok := r.reserve()
+	if !ok {
+		log.Warn().
+			Str("subject", subject).
+			Int("max_per_minute", r.MaxPerMinute).
+			Msg("Dropping notification over the rate limit")
+		return nil
+	}
+
+	if wait > 0 {
+		log.Info().
+			Str("subject", subject).
+			Dur("delay", wait).
+			Msg("Delaying notification over the rate limit")
+		select {
+		case <-r.after(wait):
+		case <-ctx.Done():
+			r.release()
+			return ctx.Err()
+		}
+	}
+	return r.Next.SendNotification(ctx, subject, message)
+}
+
+// reserve takes a token for a notification. It returns how long the notification must
+// wait for its token, and false if it is over the limit and should be dropped.
+func (r *RateLimitNotifier) reserve() (time.Duration, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.refill(r.now())
+	if r.tokens >= 1 {
+		r.tokens--
+		return 0, true
+	}
+	if !r.Delay {
+		return 0, false
+	}
+
+	// Queue behind the notifications already waiting by taking a token in advance
+	r.tokens--
+	wait := time.Duration(math.Ceil(-r.tokens / r.perNanosecond()))
+	return wait, true
+}
+
+// release returns the token of a delayed notification that was given up.
+func (r *RateLimitNotifier) release() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokens++
+}
+
+// refill adds the tokens earned since the last refill, up to MaxPerMinute.
+// Must be called with mu held.
+func (r *RateLimitNotifier) refill(now time.Time) {
+	if !r.last.IsZero() && now.After(r.last) {
+		r.tokens = math.Min(float64(r.MaxPerMinute), r.tokens+float64(now.Sub(r.last))*r.perNanosecond())
+	}
+	r.last = now
+}
+
+// perNanosecond returns the refill rate in tokens per nanosecond.
+func (r *RateLimitNotifier) perNanosecond() float64 {
+	return float64(r.MaxPerMinute) / float64(time.Minute)
+}
@@ -0,0 +1,109 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRateLimitNotifier returns a RateLimitNotifier with a controllable clock. Waits
+// for delayed notifications are recorded and return immediately.
+func newTestRateLimitNotifier(next Notifier, perMinute int) (*RateLimitNotifier, *time.Time, *[]time.Duration) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	var waits []time.Duration
+	r := NewRateLimitNotifier(next, perMinute)
+	r.now = func() time.Time { return now }
+	r.after = func(d time.Duration) <-chan time.Time {
+		waits = append(waits, d)
+		ch := make(chan time.Time, 1)
+		ch <- now.Add(d)
+		return ch
+	}
+	return r, &now, &waits
+}
+
+func TestRateLimitNotifier_DropsExcess(t *testing.T) {
+	next := &mockNotifier{}
+	next.On("SendNotification", mock.Anything, "Subject", "Message").Return(nil)
+	r, now, waits := newTestRateLimitNotifier(next, 3)
+
+	for range 5 {
+		require.NoError(t, r.SendNotification(context.Background(), "Subject", "Message"))
+	}
+	next.AssertNumberOfCalls(t, "SendNotification", 3)
+
+	// A third of a minute later, one more notification is allowed
+	*now = now.Add(20 * time.Second)
+	require.NoError(t, r.SendNotification(context.Background(), "Subject", "Message"))
+	require.NoError(t, r.SendNotification(context.Background(), "Subject", "Message"))
+	next.AssertNumberOfCalls(t, "SendNotification", 4)
+	assert.Empty(t, *waits)
+}
+
+func TestRateLimitNotifier_RefillsUpToLimit(t *testing.T) {
+	next := &mockNotifier{}
+	next.On("SendNotification", mock.Anything, "Subject", "Message").Return(nil)
+	r, now, _ := newTestRateLimitNotifier(next, 2)
+
+	require.NoError(t, r.SendNotification(context.Background(), "Subject", "Message"))
+	*now = now.Add(time.Hour)
+	for range 4 {
+		require.NoError(t, r.SendNotification(context.Background(), "Subject", "Message"))
+	}
+
+	// A quiet hour doesn't allow a burst beyond the limit
+	next.AssertNumberOfCalls(t, "SendNotification", 3)
+}
+
+func TestRateLimitNotifier_DelaysExcess(t *testing.T) {
+	next := &mockNotifier{}
+	next.On("SendNotification", mock.Anything, "Subject", "Message").Return(nil)
+	r, _, waits := newTestRateLimitNotifier(next, 2)
+	r.Delay = true
+
+	for range 5 {
+		require.NoError(t, r.SendNotification(context.Background(), "Subject", "Message"))
+	}
+
+	next.AssertNumberOfCalls(t, "SendNotification", 5)
+	assert.Equal(t, []time.Duration{30 * time.Second, time.Minute, 90 * time.Second}, *waits)
+}
+
+func TestRateLimitNotifier_DelayCanceled(t *testing.T) {
+	next := &mockNotifier{}
+	next.On("SendNotification", mock.Anything, "Subject", "Message").Return(nil)
+	r, now, _ := newTestRateLimitNotifier(next, 1)
+	r.Delay = true
+	r.after = func(time.Duration) <-chan time.Time { return nil }
+
+	require.NoError(t, r.SendNotification(context.Background(), "Subject", "Message"))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, r.SendNotification(ctx, "Subject", "Message"), context.Canceled)
+	next.AssertNumberOfCalls(t, "SendNotification", 1)
+
+	// The canceled notification gave its token back
+	*now = now.Add(time.Minute)
+	r.after = time.After
+	require.NoError(t, r.SendNotification(context.Background(), "Subject", "Message"))
+	next.AssertNumberOfCalls(t, "SendNotification", 2)
+}
+
+func TestRateLimitNotifier_DelayWaitsForRealTime(t *testing.T) {
+	next := &mockNotifier{}
+	next.On("SendNotification", mock.Anything, "Subject", "Message").Return(nil)
+	r := NewRateLimitNotifier(next, 1200) // one token every 50ms
+	r.Delay = true
+	r.tokens = 1
+
+	start := time.Now()
+	require.NoError(t, r.SendNotification(context.Background(), "Subject", "Message"))
+	require.NoError(t, r.SendNotification(context.Background(), "Subject", "Message"))
+
+	next.AssertNumberOfCalls(t, "SendNotification", 2)
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+}
@@ -0,0 +1,101 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"watchdog/internal/metrics"
+)
+
+// SlackNotifier implements the Notifier interface by posting directly to a Slack
+// incoming webhook, for users who don't run an Apprise server.
+type SlackNotifier struct {
+	// WebhookURL is the Slack incoming webhook URL (e.g., "https://hooks.slack.com/services/T000/B000/XXXX")
+	WebhookURL string
+
+	// Timeout bounds each request. 0 uses the shared client's 30 seconds.
+	Timeout time.Duration
+}
+
+// SlackPayload is the JSON body sent to a Slack incoming webhook.
+// Text is the fallback shown in push notifications; Blocks is the rich message layout.
+type SlackPayload struct {
+	Text   string       `json:"text"`
+	Blocks []SlackBlock `json:"blocks"`
+}
+
+// SlackBlock is a Slack Block Kit layout block (we only use "header" and "section").
+type SlackBlock struct {
+	Type string     `json:"type"`
+	Text *SlackText `json:"text,omitempty"`
+}
+
+// SlackText is a Block Kit text object ("plain_text" or "mrkdwn").
+type SlackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// NewSlackNotifier creates a notifier that posts to the given Slack incoming webhook URL.
+func NewSlackNotifier(webhookURL string) *SlackNotifier {
+	return &SlackNotifier{
+		WebhookURL: webhookURL,
+	}
+}
+
+// SendNotification posts the notification to Slack.
+// The subject becomes a header block and the message a section block.
+//
+// Slack replies 200 with the body "ok" on success. Any other status, or a 200
+// with a different body, is returned as an error including Slack's response text
+// (e.g., "invalid_payload", "no_text").
+func (s *SlackNotifier) SendNotification(ctx context.Context, subject, message string) error {
+	err := s.send(ctx, subject, message)
+	metrics.RecordNotification(err)
+	return err
+}
+
+// send builds the Slack payload and POSTs it.
+func (s *SlackNotifier) send(ctx context.Context, subject, message string) error {
+	payload := SlackPayload{
+		Text: subject,
+		Blocks: []SlackBlock{
+			{Type: "header", Text: &SlackText{Type: "plain_text", Text: subject}},
+			{Type: "section", Text: &SlackText{Type: "mrkdwn", Text: message}},
+		},
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal slack payload: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.WebhookURL, bytes.NewBuffer(data))
+	if err != nil {
+		return fmt.Errorf("failed to create slack request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", UserAgent)
+
+	resp, err := httpClient(s.Timeout).Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send slack request: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("slack webhook failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if strings.TrimSpace(string(body)) != "ok" {
+		return fmt.Errorf("slack webhook returned unexpected response: %s", strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSlackNotifier(t *testing.T) {
+	notifier := NewSlackNotifier("https://hooks.slack.com/services/T/B/X")
+
+	assert.NotNil(t, notifier)
+	assert.Equal(t, "https://hooks.slack.com/services/T/B/X", notifier.WebhookURL)
+}
+
+func TestSlackNotifier_SendNotification_Success(t *testing.T) {
+	var receivedPayload SlackPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(body, &receivedPayload))
+
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	notifier := NewSlackNotifier(server.URL)
+	err := notifier.SendNotification(context.Background(), "Stale PR: Fix bug", "PR #1 is pending review")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Stale PR: Fix bug", receivedPayload.Text)
+	require.Len(t, receivedPayload.Blocks, 2)
+	assert.Equal(t, "header", receivedPayload.Blocks[0].Type)
+	assert.Equal(t, "plain_text", receivedPayload.Blocks[0].Text.Type)
+	assert.Equal(t, "Stale PR: Fix bug", receivedPayload.Blocks[0].Text.Text)
+	assert.Equal(t, "section", receivedPayload.Blocks[1].Type)
+	assert.Equal(t, "mrkdwn", receivedPayload.Blocks[1].Text.Type)
+	assert.Equal(t, "PR #1 is pending review", receivedPayload.Blocks[1].Text.Text)
+}
+
+func TestSlackNotifier_SendNotification_UserAgent(t *testing.T) {
+	original := UserAgent
+	UserAgent = "watchdog/v9.9.9"
+	defer func() { UserAgent = original }()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "watchdog/v9.9.9", r.Header.Get("User-Agent"))
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	require.NoError(t, NewSlackNotifier(server.URL).SendNotification(context.Background(), "Subject", "Body"))
+}
+
+func TestSlackNotifier_SendNotification_Non200(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("invalid_payload"))
+	}))
+	defer server.Close()
+
+	err := NewSlackNotifier(server.URL).SendNotification(context.Background(), "Subject", "Message")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 400")
+	assert.Contains(t, err.Error(), "invalid_payload")
+}
+
+func TestSlackNotifier_SendNotification_200WithoutOK(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("no_text"))
+	}))
+	defer server.Close()
+
+	err := NewSlackNotifier(server.URL).SendNotification(context.Background(), "Subject", "Message")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no_text")
+}
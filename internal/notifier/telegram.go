@@ -0,0 +1,129 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"watchdog/internal/metrics"
+)
+
+// DefaultTelegramAPIBaseURL is the Telegram Bot API endpoint.
+const DefaultTelegramAPIBaseURL = "https://api.telegram.org"
+
+// TelegramNotifier implements the Notifier interface by calling the Telegram Bot API
+// sendMessage method directly, for users who don't run an Apprise server.
+type TelegramNotifier struct {
+	// BotToken is the token issued by @BotFather (e.g., "123456:ABC-DEF...")
+	BotToken string
+
+	// ChatID is the target chat, channel (e.g., "@mychannel") or user ID
+	ChatID string
+
+	// ParseMode is Telegram's optional parse_mode ("Markdown", "MarkdownV2" or "HTML").
+	// Empty sends plain text.
+	ParseMode string
+
+	// APIBaseURL is the Bot API base URL; defaults to DefaultTelegramAPIBaseURL.
+	// Overridable for tests or self-hosted Bot API servers.
+	APIBaseURL string
+
+	// Timeout bounds each request. 0 uses the shared client's 30 seconds.
+	Timeout time.Duration
+}
+
+// TelegramMessage is the JSON body sent to the sendMessage method.
+type TelegramMessage struct {
+	ChatID    string `json:"chat_id"`
+	Text      string `json:"text"`
+	ParseMode string `json:"parse_mode,omitempty"`
+}
+
+// telegramResponse is the envelope every Bot API response is wrapped in.
+type telegramResponse struct {
+	OK          bool   `json:"ok"`
+	ErrorCode   int    `json:"error_code"`
+	Description string `json:"description"`
+}
+
+// NewTelegramNotifier creates a notifier that sends messages to chatID using the given bot token.
+func NewTelegramNotifier(botToken, chatID string) *TelegramNotifier {
+	return &TelegramNotifier{
+		BotToken:   botToken,
+		ChatID:     chatID,
+		APIBaseURL: DefaultTelegramAPIBaseURL,
+	}
+}
+
+// SendNotification sends the subject and message as a single Telegram message.
+//
+// Telegram replies with a JSON envelope; a non-200 status or "ok": false is returned
+// as an error including Telegram's description (e.g., "Bad Request: chat not found").
+func (t *TelegramNotifier) SendNotification(ctx context.Context, subject, message string) error {
+	err := t.send(ctx, subject, message)
+	metrics.RecordNotification(err)
+	return err
+}
+
+// send builds the sendMessage request and checks Telegram's response.
+func (t *TelegramNotifier) send(ctx context.Context, subject, message string) error {
+	payload := TelegramMessage{
+		ChatID:    t.ChatID,
+		Text:      subject + "\n\n" + message,
+		ParseMode: t.ParseMode,
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal telegram payload: %v", err)
+	}
+
+	baseURL := t.APIBaseURL
+	if baseURL == "" {
+		baseURL = DefaultTelegramAPIBaseURL
+	}
+	url := fmt.Sprintf("%s/bot%s/sendMessage", strings.TrimRight(baseURL, "/"), t.BotToken)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(data))
+	if err != nil {
+		return fmt.Errorf("failed to create telegram request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", UserAgent)
+
+	resp, err := httpClient(t.Timeout).Do(req)
+	if err != nil {
+		// The URL contains the bot token; don't leak it into logs
+		return fmt.Errorf("failed to send telegram request: %v", redactToken(err, t.BotToken))
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, _ := io.ReadAll(resp.Body)
+
+	var result telegramResponse
+	if jsonErr := json.Unmarshal(body, &result); jsonErr != nil {
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("telegram request failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+		}
+		return fmt.Errorf("failed to parse telegram response: %v", jsonErr)
+	}
+
+	if resp.StatusCode != http.StatusOK || !result.OK {
+		return fmt.Errorf("telegram request failed with status %d: %s", resp.StatusCode, result.Description)
+	}
+
+	return nil
+}
+
+// redactToken replaces the bot token in an error message (e.g., a *url.Error) with a placeholder.
+func redactToken(err error, token string) string {
+	if token == "" {
+		return err.Error()
+	}
+	return strings.ReplaceAll(err.Error(), token, "REDACTED")
+}
@@ -0,0 +1,108 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTelegramNotifier_SendNotification_Success(t *testing.T) {
+	var received TelegramMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/bot123:ABC/sendMessage", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(body, &received))
+		_, _ = w.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+	}))
+	defer server.Close()
+
+	notif := NewTelegramNotifier("123:ABC", "-100200300")
+	notif.APIBaseURL = server.URL
+	notif.ParseMode = "HTML"
+
+	err := notif.SendNotification(context.Background(), "Low balance", "Balance is $5.00")
+
+	require.NoError(t, err)
+	assert.Equal(t, "-100200300", received.ChatID)
+	assert.Equal(t, "Low balance\n\nBalance is $5.00", received.Text)
+	assert.Equal(t, "HTML", received.ParseMode)
+}
+
+func TestTelegramNotifier_SendNotification_PlainTextOmitsParseMode(t *testing.T) {
+	var raw map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(body, &raw))
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	notif := NewTelegramNotifier("token", "42")
+	notif.APIBaseURL = server.URL
+
+	require.NoError(t, notif.SendNotification(context.Background(), "Subject", "Message"))
+	assert.NotContains(t, raw, "parse_mode")
+}
+
+func TestTelegramNotifier_SendNotification_OKFalse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: chat not found"}`))
+	}))
+	defer server.Close()
+
+	notif := NewTelegramNotifier("token", "42")
+	notif.APIBaseURL = server.URL
+
+	err := notif.SendNotification(context.Background(), "Subject", "Message")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "chat not found")
+}
+
+func TestTelegramNotifier_SendNotification_Non200(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"ok":false,"error_code":401,"description":"Unauthorized"}`))
+	}))
+	defer server.Close()
+
+	notif := NewTelegramNotifier("token", "42")
+	notif.APIBaseURL = server.URL
+
+	err := notif.SendNotification(context.Background(), "Subject", "Message")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 401")
+	assert.Contains(t, err.Error(), "Unauthorized")
+}
+
+func TestTelegramNotifier_SendNotification_ContextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	notif := NewTelegramNotifier("secret-token", "42")
+	notif.APIBaseURL = server.URL
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := notif.SendNotification(ctx, "Subject", "Message")
+
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret-token")
+}
@@ -3,21 +3,36 @@ package notifier
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math"
 	"net"
 	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"watchdog/internal/metrics"
+
 	"github.com/rs/zerolog/log"
 )
 
+// UserAgent is sent with every notification request.
+// The CLI sets it to "watchdog/<version>" at startup.
+var UserAgent = "watchdog/dev"
+
 // webhookHTTPClient is a shared HTTP client for webhook requests.
 var webhookHTTPClient = &http.Client{
 	Timeout: 30 * time.Second,
 	Transport: &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
 		MaxIdleConns:        50,
 		MaxIdleConnsPerHost: 10,
 		IdleConnTimeout:     90 * time.Second,
@@ -28,6 +43,28 @@ var webhookHTTPClient = &http.Client{
 	},
 }
 
+// httpClient returns webhookHTTPClient, or a client sharing its transport (and so its
+// connection pool, proxy and TLS settings) with a different per-request timeout.
+func httpClient(timeout time.Duration) *http.Client {
+	if timeout <= 0 || timeout == webhookHTTPClient.Timeout {
+		return webhookHTTPClient
+	}
+	return &http.Client{Transport: webhookHTTPClient.Transport, Timeout: timeout}
+}
+
+// SetProxy sets how the client shared by all notifiers picks a proxy for each request
+// (see proxy.Func). It must be called before any notifications are sent.
+func SetProxy(proxy func(*http.Request) (*url.URL, error)) {
+	webhookHTTPClient.Transport.(*http.Transport).Proxy = proxy
+}
+
+// SetTLSConfig sets the TLS settings of the client shared by all notifiers (e.g., an
+// internal CA for a self-hosted Apprise). nil restores the Go defaults.
+// It must be called before any notifications are sent.
+func SetTLSConfig(cfg *tls.Config) {
+	webhookHTTPClient.Transport.(*http.Transport).TLSClientConfig = cfg
+}
+
 // WebhookPayload represents the JSON structure sent to the Apprise API.
 // Apprise is a universal notification library that supports 70+ notification services
 // including Telegram, Discord, Slack, email, SMS, and many more.
@@ -52,13 +89,21 @@ type WebhookPayload struct {
 	// Body is the main notification message content
 	Body string `json:"body"`
 
-	// Type indicates the notification severity/type
-	// Common values: "info", "success", "warning", "failure"
+	// Type indicates the notification severity/type, taken from the context (see WithSeverity)
+	// Values: "info", "success", "warning", "failure"
 	Type string `json:"type"`
 
 	// Format specifies how the body should be interpreted
 	// Common values: "text", "markdown", "html"
 	Format string `json:"format"`
+
+	// Tags routes the notification to only the Apprise services carrying one of these tags.
+	// Omitted when empty, so every service is notified.
+	Tags []string `json:"tag,omitempty"`
+
+	// Attachments are files Apprise attaches to the notification: http(s):// URLs it
+	// downloads, or absolute paths on the Apprise server. Omitted when empty.
+	Attachments []string `json:"attach,omitempty"`
 }
 
 // WebhookNotifier implements the Notifier interface using Apprise webhooks.
@@ -66,6 +111,9 @@ type WebhookPayload struct {
 // which then forwards the notifications to configured services (Telegram, Discord, etc.)
 //
 // This is the primary notification backend used by watchdog.
+//
+// A WebhookNotifier is safe for concurrent use once configured: its fields are only
+// read while sending, and the shared HTTP client is safe for concurrent use.
 type WebhookNotifier struct {
 	// WebhookURL is the Apprise API endpoint (e.g., "https://apprise.example.com/notify")
 	WebhookURL string
@@ -73,8 +121,87 @@ type WebhookNotifier struct {
 	// TargetURLs is a list of Apprise service URLs to send notifications to.
 	// These are parsed from the comma-separated apprise_service_url config value.
 	TargetURLs []string
+
+	// Format is the body format sent to Apprise ("text", "markdown" or "html").
+	// Defaults to "text" when empty.
+	Format string
+
+	// RetryConfig controls retries of transient failures. Nil uses DefaultRetryConfig.
+	RetryConfig *RetryConfig
+
+	// Timeout bounds each request attempt. 0 uses the shared client's 30 seconds.
+	Timeout time.Duration
+
+	// MaxInFlight caps how many requests this notifier has open at once, so concurrent
+	// tasks don't overwhelm the Apprise server; further sends wait for a free slot.
+	// 0 means no limit. It must be set before the first notification is sent.
+	MaxInFlight int
+
+	// Auth holds credentials added to every request (none by default)
+	Auth WebhookAuth
+
+	// inFlight holds a token per open request when MaxInFlight is set (created on first use)
+	inFlight     chan struct{}
+	inFlightOnce sync.Once
+}
+
+// WebhookAuth holds credentials for an Apprise server that requires them, e.g. behind
+// a proxy. They are added to every request and never logged.
+type WebhookAuth struct {
+	// Username and Password are sent as HTTP Basic Auth. An empty Username disables it.
+	Username string
+	Password string
+
+	// Headers are extra request headers (e.g., {"X-API-Key": "secret"})
+	Headers map[string]string
+}
+
+// apply adds the credentials to req.
+func (a WebhookAuth) apply(req *http.Request) {
+	for name, value := range a.Headers {
+		req.Header.Set(name, value)
+	}
+	if a.Username != "" {
+		req.SetBasicAuth(a.Username, a.Password)
+	}
 }
 
+// redacted lists the headers the credentials add, with their values hidden
+// (e.g., ["Authorization: ***", "X-Api-Key: ***"]), for logging.
+func (a WebhookAuth) redacted() []string {
+	var headers []string
+	for name := range a.Headers {
+		headers = append(headers, http.CanonicalHeaderKey(name)+": ***")
+	}
+	sort.Strings(headers)
+	if a.Username != "" {
+		headers = append([]string{"Authorization: ***"}, headers...)
+	}
+	return headers
+}
+
+// ErrNoServiceURLs is returned when a WebhookNotifier has no (non-blank) target service
+// URLs, instead of asking Apprise to notify nobody.
+var ErrNoServiceURLs = errors.New("no target service URLs configured")
+
+// targets returns TargetURLs without blank entries, trimmed of surrounding whitespace.
+func (w *WebhookNotifier) targets() []string {
+	targets := make([]string, 0, len(w.TargetURLs))
+	for _, target := range w.TargetURLs {
+		if target = strings.TrimSpace(target); target != "" {
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+// Supported notification body formats understood by Apprise.
+const (
+	FormatText     = "text"
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
 // NewWebhookNotifier creates a new webhook-based notifier.
 // Parameters:
 //   - webhookURL: The Apprise API endpoint URL (e.g., "https://apprise.example.com/notify")
@@ -93,19 +220,65 @@ func NewWebhookNotifier(webhookURL string, targetURLs []string) *WebhookNotifier
 	}
 }
 
-// webhookRetryConfig defines retry behavior for webhook requests.
-var webhookRetryConfig = struct {
-	MaxRetries        int
-	InitialBackoff    time.Duration
-	MaxBackoff        time.Duration
+// RetryConfig configures how webhook requests are retried after transient failures
+// (timeouts, 429 and 5xx responses).
+type RetryConfig struct {
+	// MaxRetries is the maximum number of retry attempts (0 = no retries)
+	MaxRetries int
+
+	// InitialBackoff is the wait time before the first retry
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the maximum wait time between retries
+	MaxBackoff time.Duration
+
+	// BackoffMultiplier increases the backoff time after each retry
 	BackoffMultiplier float64
-}{
+}
+
+// DefaultRetryConfig is the retry behavior used when a WebhookNotifier has no RetryConfig.
+var DefaultRetryConfig = RetryConfig{
 	MaxRetries:        3,
 	InitialBackoff:    500 * time.Millisecond,
 	MaxBackoff:        10 * time.Second,
 	BackoffMultiplier: 2.0,
 }
 
+// backoff computes the wait before retrying after the given (zero-based) attempt.
+func (c RetryConfig) backoff(attempt int) time.Duration {
+	backoff := float64(c.InitialBackoff) * math.Pow(c.BackoffMultiplier, float64(attempt))
+	if backoff > float64(c.MaxBackoff) {
+		backoff = float64(c.MaxBackoff)
+	}
+	return time.Duration(backoff)
+}
+
+// acquire waits for a free request slot under MaxInFlight and returns the function that
+// frees it. It returns ctx's error if ctx is done first.
+func (w *WebhookNotifier) acquire(ctx context.Context) (func(), error) {
+	if w.MaxInFlight <= 0 {
+		return func() {}, nil
+	}
+	w.inFlightOnce.Do(func() {
+		w.inFlight = make(chan struct{}, w.MaxInFlight)
+	})
+
+	select {
+	case w.inFlight <- struct{}{}:
+		return func() { <-w.inFlight }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// retryConfig returns the retry settings for this notifier.
+func (w *WebhookNotifier) retryConfig() RetryConfig {
+	if w.RetryConfig != nil {
+		return *w.RetryConfig
+	}
+	return DefaultRetryConfig
+}
+
 // SendNotification sends a notification via the Apprise webhook.
 // It constructs a WebhookPayload, marshals it to JSON, and POSTs it to the Apprise API.
 //
@@ -121,13 +294,119 @@ var webhookRetryConfig = struct {
 // The Apprise API will then forward the notification to all configured services
 // (Telegram, Discord, etc.) specified in the TargetURLs.
 func (w *WebhookNotifier) SendNotification(ctx context.Context, subject, message string) error {
+	err := w.send(ctx, subject, message, nil)
+	metrics.RecordNotification(err)
+	return err
+}
+
+// SendNotificationWithAttachments sends a notification like SendNotification, asking Apprise
+// to attach the given files (e.g., a full CI summary). Each attachment must be an
+// http(s):// or file:// URL, or an absolute path on the Apprise server.
+// Invalid attachments are rejected before anything is sent.
+func (w *WebhookNotifier) SendNotificationWithAttachments(ctx context.Context, subject, message string, attachURLs []string) error {
+	for _, attachment := range attachURLs {
+		if err := validateAttachment(attachment); err != nil {
+			return err
+		}
+	}
+
+	err := w.send(ctx, subject, message, attachURLs)
+	metrics.RecordNotification(err)
+	return err
+}
+
+// validateAttachment checks that attachment is something Apprise can attach:
+// an http(s):// or file:// URL, or an absolute file path.
+func validateAttachment(attachment string) error {
+	if strings.TrimSpace(attachment) == "" {
+		return fmt.Errorf("invalid attachment: empty value")
+	}
+
+	if !strings.Contains(attachment, "://") {
+		if !path.IsAbs(attachment) {
+			return fmt.Errorf("invalid attachment %q: file paths must be absolute", attachment)
+		}
+		return nil
+	}
+
+	u, err := url.Parse(attachment)
+	if err != nil {
+		return fmt.Errorf("invalid attachment %q: %v", attachment, err)
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+		if u.Host == "" {
+			return fmt.Errorf("invalid attachment %q: missing host", attachment)
+		}
+	case "file":
+		if u.Path == "" {
+			return fmt.Errorf("invalid attachment %q: missing path", attachment)
+		}
+	default:
+		return fmt.Errorf("invalid attachment %q: scheme must be http, https or file", attachment)
+	}
+	return nil
+}
+
+// logPayload logs the outgoing Apprise payload at debug level. Service URLs embed
+// credentials (bot tokens, webhook secrets), so only their scheme is kept ("tgram://***");
+// of the Auth headers only the names are logged.
+func (w *WebhookNotifier) logPayload(payload WebhookPayload) {
+	event := log.Debug()
+	if !event.Enabled() {
+		return
+	}
+
+	payload.URLs = make([]string, len(w.TargetURLs))
+	for i, serviceURL := range w.TargetURLs {
+		payload.URLs[i] = redactServiceURL(serviceURL)
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+
+	webhookURL := "***"
+	if u, err := url.Parse(w.WebhookURL); err == nil {
+		webhookURL = u.Redacted()
+	}
+	if headers := w.Auth.redacted(); len(headers) > 0 {
+		event.Strs("auth_headers", headers)
+	}
+	event.Str("url", webhookURL).RawJSON("payload", data).Msg("Sending webhook request")
+}
+
+// redactServiceURL hides everything but the scheme of an Apprise service URL,
+// e.g. "tgram://123:ABC/456" becomes "tgram://***".
+func redactServiceURL(serviceURL string) string {
+	if scheme, _, found := strings.Cut(serviceURL, "://"); found {
+		return scheme + "://***"
+	}
+	return "***"
+}
+
+// send builds the Apprise payload and POSTs it, retrying transient failures.
+func (w *WebhookNotifier) send(ctx context.Context, subject, message string, attachments []string) error {
+	format := w.Format
+	if format == "" {
+		format = FormatText
+	}
+
+	// Apprise accepts an empty urls array and silently delivers nothing, so refuse to send
+	targets := w.targets()
+	if len(targets) == 0 {
+		return ErrNoServiceURLs
+	}
+
 	// Construct the payload for Apprise
 	payload := WebhookPayload{
-		URLs:   w.TargetURLs,
-		Title:  subject,
-		Body:   message,
-		Type:   "info", // Could be made configurable in the future
-		Format: "text", // Plain text format (could support markdown/html later)
+		URLs:        targets,
+		Title:       subject,
+		Body:        message,
+		Type:        string(SeverityFromContext(ctx)),
+		Format:      format,
+		Tags:        TagsFromContext(ctx),
+		Attachments: attachments,
 	}
 
 	// Marshal the payload to JSON
@@ -135,10 +414,12 @@ func (w *WebhookNotifier) SendNotification(ctx context.Context, subject, message
 	if err != nil {
 		return fmt.Errorf("failed to marshal webhook payload: %v", err)
 	}
+	w.logPayload(payload)
 
 	// Retry loop with exponential backoff
+	retry := w.retryConfig()
 	var lastErr error
-	for attempt := 0; attempt <= webhookRetryConfig.MaxRetries; attempt++ {
+	for attempt := 0; attempt <= retry.MaxRetries; attempt++ {
 		// Check context before attempting
 		select {
 		case <-ctx.Done():
@@ -152,15 +433,22 @@ func (w *WebhookNotifier) SendNotification(ctx context.Context, subject, message
 			return fmt.Errorf("failed to create webhook request: %v", err)
 		}
 		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", UserAgent)
+		w.Auth.apply(req)
 
-		// Send the request
-		resp, err := webhookHTTPClient.Do(req)
+		// Send the request, holding a slot until its response is read
+		release, err := w.acquire(ctx)
+		if err != nil {
+			return err
+		}
+		resp, err := httpClient(w.Timeout).Do(req)
 		if err != nil {
+			release()
 			lastErr = err
 			// Check if error is retryable (timeout)
 			if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
-				if attempt < webhookRetryConfig.MaxRetries {
-					backoff := calculateBackoff(attempt)
+				if attempt < retry.MaxRetries {
+					backoff := retry.backoff(attempt)
 					log.Warn().
 						Err(err).
 						Int("attempt", attempt+1).
@@ -180,15 +468,21 @@ func (w *WebhookNotifier) SendNotification(ctx context.Context, subject, message
 		// Ensure response body is closed
 		_, _ = io.Copy(io.Discard, resp.Body)
 		_ = resp.Body.Close()
+		release()
 
 		// Check if the request was successful (2xx status code)
 		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 			return nil
 		}
 
-		// Check if status code is retryable (5xx errors)
-		if resp.StatusCode >= 500 && attempt < webhookRetryConfig.MaxRetries {
-			backoff := calculateBackoff(attempt)
+		// Check if status code is retryable (429 rate limiting or 5xx errors)
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		if retryable && attempt < retry.MaxRetries {
+			backoff := retry.backoff(attempt)
+			// Honor the server's Retry-After, but never wait longer than MaxBackoff
+			if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				backoff = min(wait, retry.MaxBackoff)
+			}
 			log.Warn().
 				Int("status_code", resp.StatusCode).
 				Int("attempt", attempt+1).
@@ -211,11 +505,25 @@ func (w *WebhookNotifier) SendNotification(ctx context.Context, subject, message
 	return nil
 }
 
-// calculateBackoff computes the backoff duration for a given attempt.
-func calculateBackoff(attempt int) time.Duration {
-	backoff := float64(webhookRetryConfig.InitialBackoff) * math.Pow(webhookRetryConfig.BackoffMultiplier, float64(attempt))
-	if backoff > float64(webhookRetryConfig.MaxBackoff) {
-		backoff = float64(webhookRetryConfig.MaxBackoff)
+// parseRetryAfter parses a Retry-After header value, given either as a number of
+// seconds or as an HTTP date. Returns false if the header is missing or malformed.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
 	}
-	return time.Duration(backoff)
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		wait := at.Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
 }
@@ -0,0 +1,102 @@
+// Package proxy selects the HTTP proxy used for outbound requests, either from the
+// configured proxy URL or from the standard HTTP_PROXY/HTTPS_PROXY/NO_PROXY variables.
+package proxy
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Func returns the function transports use to pick a proxy for each request.
+//
+// With an empty proxyURL the standard environment variables apply (http.ProxyFromEnvironment).
+// Otherwise every request goes through proxyURL, except hosts matching noProxy, which uses
+// NO_PROXY syntax: comma-separated hosts, domains (matching subdomains, with or without a
+// leading dot), IPs, CIDR ranges, optional ":port" suffixes, or "*" to bypass everything.
+// An empty noProxy falls back to the NO_PROXY (or no_proxy) environment variable.
+func Func(proxyURL, noProxy string) (func(*http.Request) (*url.URL, error), error) {
+	if proxyURL == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy url: %v", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("invalid proxy url %q: scheme must be http, https or socks5", u.Redacted())
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy url %q: missing host", u.Redacted())
+	}
+
+	if noProxy == "" {
+		noProxy = os.Getenv("NO_PROXY")
+		if noProxy == "" {
+			noProxy = os.Getenv("no_proxy")
+		}
+	}
+	rules := strings.Split(noProxy, ",")
+
+	return func(req *http.Request) (*url.URL, error) {
+		if bypass(req.URL, rules) {
+			return nil, nil
+		}
+		return u, nil
+	}, nil
+}
+
+// bypass reports whether target matches any NO_PROXY rule.
+func bypass(target *url.URL, rules []string) bool {
+	host := strings.ToLower(target.Hostname())
+	port := target.Port()
+	if port == "" {
+		switch target.Scheme {
+		case "http":
+			port = "80"
+		case "https":
+			port = "443"
+		}
+	}
+
+	for _, rule := range rules {
+		rule = strings.ToLower(strings.TrimSpace(rule))
+		if rule == "" {
+			continue
+		}
+		if rule == "*" {
+			return true
+		}
+
+		// CIDR ranges match IP targets
+		if _, network, err := net.ParseCIDR(rule); err == nil {
+			if ip := net.ParseIP(host); ip != nil && network.Contains(ip) {
+				return true
+			}
+			continue
+		}
+
+		// An optional port must match exactly
+		ruleHost := rule
+		if h, p, err := net.SplitHostPort(rule); err == nil {
+			if p != port {
+				continue
+			}
+			ruleHost = h
+		}
+		ruleHost = strings.Trim(ruleHost, "[]")
+
+		// "example.com" and ".example.com" both match example.com and its subdomains
+		domain := strings.TrimPrefix(ruleHost, ".")
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
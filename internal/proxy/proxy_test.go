@@ -0,0 +1,110 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFunc_RoutesThroughProxy(t *testing.T) {
+	var proxiedHost, proxiedURL string
+	stubProxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A forward proxy receives the absolute target URL
+		proxiedHost = r.Host
+		proxiedURL = r.URL.String()
+		_, _ = w.Write([]byte("via proxy"))
+	}))
+	defer stubProxy.Close()
+
+	proxyFunc, err := Func(stubProxy.URL, "internal.example")
+	require.NoError(t, err)
+	client := &http.Client{Transport: &http.Transport{Proxy: proxyFunc}}
+
+	resp, err := client.Get("http://api.example.invalid/v2/balance")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "api.example.invalid", proxiedHost)
+	assert.Equal(t, "http://api.example.invalid/v2/balance", proxiedURL)
+}
+
+func TestFunc_NoProxyBypassesProxy(t *testing.T) {
+	proxyFunc, err := Func("http://proxy.corp:3128", "localhost, .internal.corp, 10.0.0.0/8, api.example.com:8443")
+	require.NoError(t, err)
+
+	tests := []struct {
+		target  string
+		proxied bool
+	}{
+		{target: "https://api.github.com/repos", proxied: true},
+		{target: "http://localhost:8000/notify", proxied: false},
+		{target: "https://apprise.internal.corp/notify", proxied: false},
+		{target: "https://internal.corp/notify", proxied: false},
+		{target: "https://notinternal.corp/notify", proxied: true},
+		{target: "http://10.1.2.3/health", proxied: false},
+		{target: "http://192.168.1.1/health", proxied: true},
+		{target: "https://api.example.com:8443/x", proxied: false},
+		{target: "https://api.example.com/x", proxied: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			req, err := http.NewRequest("GET", tt.target, nil)
+			require.NoError(t, err)
+
+			proxyURL, err := proxyFunc(req)
+			require.NoError(t, err)
+			if tt.proxied {
+				require.NotNil(t, proxyURL)
+				assert.Equal(t, "proxy.corp:3128", proxyURL.Host)
+			} else {
+				assert.Nil(t, proxyURL)
+			}
+		})
+	}
+}
+
+func TestFunc_NoProxyWildcardAndEnvironment(t *testing.T) {
+	req, err := http.NewRequest("GET", "https://api.github.com", nil)
+	require.NoError(t, err)
+
+	wildcard, err := Func("http://proxy.corp:3128", "*")
+	require.NoError(t, err)
+	proxyURL, err := wildcard(req)
+	require.NoError(t, err)
+	assert.Nil(t, proxyURL)
+
+	// Without an explicit list, NO_PROXY from the environment applies
+	t.Setenv("NO_PROXY", "github.com")
+	fromEnv, err := Func("http://proxy.corp:3128", "")
+	require.NoError(t, err)
+	proxyURL, err = fromEnv(req)
+	require.NoError(t, err)
+	assert.Nil(t, proxyURL)
+}
+
+func TestFunc_EmptyUsesEnvironment(t *testing.T) {
+	proxyFunc, err := Func("", "")
+	require.NoError(t, err)
+	assert.NotNil(t, proxyFunc)
+}
+
+func TestFunc_InvalidProxyURL(t *testing.T) {
+	for _, invalid := range []string{"ftp://proxy.corp", "http://", "://bad"} {
+		_, err := Func(invalid, "")
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestBypass_DefaultPorts(t *testing.T) {
+	target, err := url.Parse("https://api.example.com/x")
+	require.NoError(t, err)
+
+	assert.True(t, bypass(target, []string{"api.example.com:443"}))
+	assert.False(t, bypass(target, []string{"api.example.com:80"}))
+}
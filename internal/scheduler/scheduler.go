@@ -1,23 +1,39 @@
 package scheduler
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/rs/zerolog/log"
+
+	"watchdog/internal/notifier"
 )
 
 // Task defines the interface that all schedulable tasks must implement.
-// Any struct that implements the Run() method can be scheduled for periodic execution.
+// Any struct that implements the Run(ctx) and Name() methods can be scheduled for periodic execution.
 //
 // Examples of tasks in watchdog:
 //   - TelnyxBalanceCheckTask: Checks Telnyx account balance
 //   - PRReviewCheckTask: Monitors GitHub PRs for staleness
+//
+// Tasks that need final cleanup when they are stopped can also implement io.Closer;
+// Stop and RemoveTask call Close once the task's goroutine has exited.
 type Task interface {
 	// Run executes the task logic.
 	// It should return an error if the task fails, nil on success.
 	// Errors are logged but don't stop the scheduler from continuing.
-	Run() error
+	// ctx is cancelled when the scheduler is stopped; long-running work such as HTTP
+	// calls should use it so shutdown isn't held up by a slow or hung request.
+	Run(ctx context.Context) error
+
+	// Name returns a short, human-readable identifier for the task (e.g., "telnyx-balance").
+	// It appears in logs and status reports, so it should be unique among scheduled tasks.
+	Name() string
 }
 
 // Scheduler manages the periodic execution of multiple tasks.
@@ -32,6 +48,86 @@ type Scheduler struct {
 
 	// wg waits for all task goroutines to complete
 	wg sync.WaitGroup
+
+	// mu guards tasks, running and each task's last run result, which can change while
+	// tasks are running (config reloads) and are read by health checks
+	mu sync.Mutex
+
+	// running is true between Start() and Stop()
+	running bool
+
+	// ctx is passed to task runs started by the scheduler; cancel cancels it when the
+	// scheduler is stopped. Both are set by Start and guarded by mu.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// paused makes task goroutines drop their ticks (see Pause)
+	paused atomic.Bool
+
+	// alerts configures notifications about failing and recovered tasks. Guarded by mu.
+	alerts FailureAlerts
+
+	// backoff configures how failing tasks are slowed down. Guarded by mu.
+	backoff FailureBackoff
+}
+
+// FailureAlerts configures notifications about tasks whose runs keep failing
+// (e.g., GitHub unreachable). Use it with SetFailureAlerts.
+type FailureAlerts struct {
+	// Notifier sends the alerts. Nil disables them.
+	Notifier notifier.Notifier
+
+	// AlertAfter sends a "failing" alert when a task has failed this many runs in a row
+	// (once per failure streak). 0 disables it.
+	AlertAfter int
+
+	// RecoveryAfter sends a "recovered" notification when a task succeeds after failing
+	// at least this many runs in a row. 0 disables it.
+	RecoveryAfter int
+}
+
+// FailureBackoff slows down tasks whose runs keep failing, so a task doesn't retry a
+// broken service (and log about it) at its full rate. Use it with SetFailureBackoff.
+type FailureBackoff struct {
+	// MaxInterval caps how long a failing task waits between runs. Each failed run in a
+	// row doubles the task's interval, up to MaxInterval; a successful run restores it.
+	// 0 disables backoff, as does a MaxInterval no longer than the task's interval.
+	MaxInterval time.Duration
+}
+
+// backoffInterval returns how long a task scheduled every interval waits before its
+// next run after failures runs in a row: interval * 2^failures, capped at maxInterval.
+func backoffInterval(interval, maxInterval time.Duration, failures int) time.Duration {
+	if failures == 0 || maxInterval <= interval {
+		return interval
+	}
+	next := interval
+	for i := 0; i < failures && next < maxInterval; i++ {
+		next *= 2
+	}
+	return min(next, maxInterval)
+}
+
+// ErrUnknownTask is returned by LastRun when no task with the given name is scheduled.
+var ErrUnknownTask = errors.New("unknown task")
+
+// TaskStatus describes the outcome of a task's most recent run.
+type TaskStatus struct {
+	// LastRun is when the most recent run finished
+	LastRun time.Time `json:"last_run"`
+
+	// Success is true if the most recent run returned no error
+	Success bool `json:"success"`
+
+	// Error is the error message from the most recent run, if it failed
+	Error string `json:"error,omitempty"`
+
+	// Duration is how long the most recent run took
+	Duration time.Duration `json:"duration_ns"`
+
+	// ConsecutiveFailures is how many runs in a row have failed, up to and including the
+	// most recent one (0 if it succeeded)
+	ConsecutiveFailures int `json:"consecutive_failures,omitempty"`
 }
 
 // scheduledTask is an internal struct that wraps a Task with its scheduling metadata.
@@ -40,6 +136,9 @@ type scheduledTask struct {
 	// task is the actual task to execute
 	task Task
 
+	// name identifies the task in logs and status reports (from Task.Name, e.g. "telnyx-balance")
+	name string
+
 	// interval is how often to run the task (e.g., 5 minutes)
 	interval time.Duration
 
@@ -49,6 +148,31 @@ type scheduledTask struct {
 
 	// stopOnce guards the closing of the stop channel
 	stopOnce sync.Once
+
+	// done is closed when the task's goroutine exits (nil if it was never started)
+	done chan struct{}
+
+	// runImmediately runs the task once as soon as Start() is called,
+	// instead of waiting for the first interval to elapse
+	runImmediately bool
+
+	// lastStatus and lastErr record the outcome of the most recent run
+	// (lastStatus.LastRun is zero until the task has run). Guarded by Scheduler.mu.
+	lastStatus TaskStatus
+	lastErr    error
+
+	// inFlight is set while a run is in progress, so the task never runs concurrently
+	// with itself (e.g., RunOnce while the scheduler is running)
+	inFlight atomic.Bool
+}
+
+// TaskOptions customizes how a task is scheduled.
+// Use it with ScheduleTaskWithOptions.
+type TaskOptions struct {
+	// RunImmediately runs the task once right when Start() is called, before the
+	// first interval elapses. Without it, a task with a 1h interval would not run
+	// until an hour after startup. ScheduleTask enables this by default.
+	RunImmediately bool
 }
 
 // NewScheduler creates a new empty scheduler.
@@ -67,7 +191,8 @@ func NewScheduler() *Scheduler {
 }
 
 // ScheduleTask adds a task to the scheduler with the specified execution interval.
-// The task won't start running until Start() is called.
+// The task won't start running until Start() is called. If the scheduler is
+// already running, the task starts right away.
 //
 // Parameters:
 //   - task: The task to schedule (must implement the Task interface)
@@ -81,17 +206,126 @@ func NewScheduler() *Scheduler {
 //	sched.ScheduleTask(balanceTask, 5*time.Minute)  // Check balance every 5 minutes
 //	sched.ScheduleTask(prTask, 10*time.Minute)      // Check PRs every 10 minutes
 func (s *Scheduler) ScheduleTask(task Task, interval time.Duration) {
+	s.ScheduleTaskWithOptions(task, interval, TaskOptions{RunImmediately: true})
+}
+
+// ScheduleTaskWithOptions adds a task to the scheduler like ScheduleTask,
+// but lets the caller control scheduling behavior via opts.
+//
+// Example:
+//
+//	// Wait a full interval before the first run
+//	sched.ScheduleTaskWithOptions(reportTask, 1*time.Hour, TaskOptions{RunImmediately: false})
+func (s *Scheduler) ScheduleTaskWithOptions(task Task, interval time.Duration, opts TaskOptions) {
 	scheduledTask := &scheduledTask{
+		task:           task,
+		name:           task.Name(),
+		interval:       interval,
+		stop:           make(chan struct{}),
+		runImmediately: opts.RunImmediately,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tasks = append(s.tasks, scheduledTask)
+	if s.running {
+		s.startTask(scheduledTask)
+	}
+}
+
+// SetFailureAlerts sets how failing and recovered tasks are reported. It may be called
+// while the scheduler is running (e.g., after a config reload); failure streaks already
+// in progress are kept.
+func (s *Scheduler) SetFailureAlerts(alerts FailureAlerts) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.alerts = alerts
+}
+
+// SetFailureBackoff sets how failing tasks are slowed down. It may be called while the
+// scheduler is running; it applies from each task's next run.
+func (s *Scheduler) SetFailureBackoff(backoff FailureBackoff) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.backoff = backoff
+}
+
+// RemoveTask stops a scheduled task and removes it from the scheduler.
+// If the task is currently executing, RemoveTask waits for that run to finish,
+// then closes the task if it implements io.Closer (errors are logged).
+// It returns false if the task isn't scheduled.
+func (s *Scheduler) RemoveTask(task Task) bool {
+	s.mu.Lock()
+	index := s.indexOf(task)
+	if index < 0 {
+		s.mu.Unlock()
+		return false
+	}
+	st := s.tasks[index]
+	s.tasks = append(s.tasks[:index], s.tasks[index+1:]...)
+	s.mu.Unlock()
+
+	st.halt()
+	_ = closeTask(st)
+	return true
+}
+
+// Reschedule changes the execution interval of a scheduled task.
+// If the scheduler is running, the task's ticker is restarted with the new interval;
+// the task is not run again immediately.
+// It returns false if the task isn't scheduled.
+func (s *Scheduler) Reschedule(task Task, interval time.Duration) bool {
+	s.mu.Lock()
+	index := s.indexOf(task)
+	if index < 0 {
+		s.mu.Unlock()
+		return false
+	}
+	old := s.tasks[index]
+	if !s.running {
+		old.interval = interval
+		s.mu.Unlock()
+		return true
+	}
+
+	replacement := &scheduledTask{
 		task:     task,
+		name:     old.name,
 		interval: interval,
 		stop:     make(chan struct{}),
 	}
-	s.tasks = append(s.tasks, scheduledTask)
+	s.tasks[index] = replacement
+	s.mu.Unlock()
+
+	// Wait for the old goroutine to exit before starting the new one,
+	// so the task never runs twice concurrently
+	old.halt()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Keep reporting the last run until the replacement runs
+	replacement.lastStatus, replacement.lastErr = old.lastStatus, old.lastErr
+	if s.running && s.indexOf(task) == index {
+		s.startTask(replacement)
+	}
+	return true
+}
+
+// indexOf returns the position of task in s.tasks, or -1. Callers must hold s.mu.
+func (s *Scheduler) indexOf(task Task) int {
+	for i, st := range s.tasks {
+		if st.task == task {
+			return i
+		}
+	}
+	return -1
 }
 
 // HasTasks returns true if at least one task has been scheduled.
 // This is useful for checking if the scheduler has any work to do before starting it.
 func (s *Scheduler) HasTasks() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	return len(s.tasks) > 0
 }
 
@@ -100,31 +334,52 @@ func (s *Scheduler) HasTasks() bool {
 //
 // How it works:
 //  1. For each scheduled task, a goroutine is spawned
-//  2. The task is executed immediately (before the first ticker fires)
+//  2. The task is executed immediately (before the first ticker fires), unless
+//     it was scheduled with RunImmediately disabled
 //  3. Each goroutine creates a ticker that fires at the task's interval
-//  4. When the ticker fires, the task's Run() method is called
+//  4. When the ticker fires, the task's Run() method is called with a context that
+//     is cancelled when the scheduler is stopped
 //  5. If Run() returns an error, it's logged but execution continues
 //  6. The goroutine continues until Stop() is called or the program exits
 //
 // This method returns immediately after starting all goroutines.
 // The tasks will continue running in the background.
 //
-// Note: If a task's Run() method takes longer than the interval,
-// the next execution will be delayed (tickers don't queue up).
+// Note: A task never runs concurrently with itself. If a task's Run() method takes
+// longer than the interval, the ticks missed during the run are skipped (and logged)
+// and the next execution happens one interval after the run finished.
+//
+// A task that keeps failing waits longer between runs if SetFailureBackoff is used,
+// and its failures are logged less often the longer they go on (see execute).
 func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.running = true
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+
 	for _, st := range s.tasks {
-		s.wg.Add(1)
-		// Launch each task in its own goroutine
-		// We pass 'st' as a parameter to avoid closure issues
-		go func(task *scheduledTask) {
-			defer s.wg.Done()
-
-			// Run the task immediately on start
-			// This ensures we get immediate feedback rather than waiting for the first interval
-			log.Info().Msg("Running task immediately on start")
-			if err := task.task.Run(); err != nil {
-				log.Error().Err(err).Msg("Initial task execution failed")
-			}
+		s.startTask(st)
+	}
+}
+
+// startTask launches the execution loop for a single task in its own goroutine.
+// Callers must hold s.mu.
+func (s *Scheduler) startTask(task *scheduledTask) {
+	log.Info().Str("task", task.name).Dur("interval", task.interval).Msg("Starting task")
+	s.wg.Add(1)
+	task.done = make(chan struct{})
+	ctx := s.ctx
+
+	go func() {
+		defer s.wg.Done()
+		defer close(task.done)
+		defer func() { log.Info().Str("task", task.name).Msg("Task stopped") }()
+
+		// Run the task immediately on start
+		// This ensures we get immediate feedback rather than waiting for the first interval
+		if task.runImmediately && !s.skipPaused(task) {
+			log.Info().Str("task", task.name).Msg("Running task immediately on start")
+			_ = s.execute(ctx, task)
 
 			// Check for stop signal after initial run
 			select {
@@ -132,53 +387,333 @@ func (s *Scheduler) Start() {
 				return
 			default:
 			}
+		}
 
-			// Create a ticker that fires at the specified interval
-			ticker := time.NewTicker(task.interval)
-			defer ticker.Stop()
+		// Create a ticker that fires at the specified interval, or later if the
+		// immediate run failed and failing tasks are backed off
+		period := s.nextInterval(task)
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
 
-			// Infinite loop - runs until we receive a stop signal
-			for {
+		// Infinite loop - runs until we receive a stop signal
+		for {
+			select {
+			case <-ticker.C:
+				// Check for stop signal before running task
+				// This ensures we prioritize stopping if both ticker and stop are ready
 				select {
-				case <-ticker.C:
-					// Check for stop signal before running task
-					// This ensures we prioritize stopping if both ticker and stop are ready
-					select {
-					case <-task.stop:
-						return
-					default:
-					}
-
-					// Ticker fired - time to run the task
-					err := task.task.Run()
-					if err != nil {
-						// Log the error but continue running
-						// We don't want one task failure to stop the scheduler
-						log.Error().Err(err).Msg("Task execution failed")
-					}
 				case <-task.stop:
-					// Stop signal received - exit the goroutine
 					return
+				default:
+				}
+
+				// While paused, the tick is dropped rather than run once resumed
+				if s.skipPaused(task) {
+					continue
 				}
+
+				// Ticker fired - time to run the task
+				// Errors are logged by execute; we don't want one task failure to stop the scheduler
+				start := time.Now()
+				_ = s.execute(ctx, task)
+				next := s.nextInterval(task)
+
+				// Ticks that fired while the run was still in progress are skipped rather than
+				// starting another run right after it; the next run is a full interval away
+				if elapsed := time.Since(start); elapsed >= period {
+					log.Warn().
+						Str("task", task.name).
+						Dur("duration", elapsed).
+						Dur("interval", period).
+						Msg("Run took longer than the interval, skipping missed ticks")
+					ticker.Reset(next)
+				} else if next != period {
+					ticker.Reset(next)
+				}
+				if next > period {
+					log.Debug().Str("task", task.name).Dur("next_run_in", next).Msg("Task is failing, backing off")
+				}
+				period = next
+			case <-task.stop:
+				// Stop signal received - exit the goroutine
+				return
 			}
-		}(st)
+		}
+	}()
+}
+
+// nextInterval returns how long the task waits before its next run: its interval, or
+// longer while its runs keep failing (see FailureBackoff).
+func (s *Scheduler) nextInterval(task *scheduledTask) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return backoffInterval(task.interval, s.backoff.MaxInterval, task.lastStatus.ConsecutiveFailures)
+}
+
+// skipPaused reports whether the scheduler is paused, logging that the task's run is skipped.
+func (s *Scheduler) skipPaused(task *scheduledTask) bool {
+	if !s.paused.Load() {
+		return false
+	}
+	log.Debug().Str("task", task.name).Msg("Skipping run: scheduler is paused")
+	return true
+}
+
+// Pause stops tasks from running (e.g., during planned maintenance) without stopping
+// the scheduler. Ticks that fire while paused are dropped, not queued: after Resume,
+// each task next runs at its following tick. A run already in progress is not interrupted.
+// RunOnce is not affected.
+func (s *Scheduler) Pause() {
+	s.paused.Store(true)
+}
+
+// Resume lets tasks run again at their next tick after Pause.
+func (s *Scheduler) Resume() {
+	s.paused.Store(false)
+}
+
+// Paused reports whether the scheduler is paused (see Pause).
+func (s *Scheduler) Paused() bool {
+	return s.paused.Load()
+}
+
+// halt signals the task's goroutine to stop and waits for it to exit.
+func (st *scheduledTask) halt() {
+	st.stopOnce.Do(func() {
+		close(st.stop)
+	})
+	if st.done != nil {
+		<-st.done
 	}
 }
 
+// RunOnce executes every scheduled task exactly one time, sequentially, and then returns.
+// It does not start any goroutines or tickers, which makes it suitable for running
+// watchdog from an external scheduler such as cron or a Kubernetes CronJob.
+//
+// All tasks are run even if an earlier one fails. Any task errors are joined
+// together (see errors.Join) and returned; nil means every task succeeded.
+// ctx is passed to each task's Run; once it is cancelled, the remaining tasks are skipped.
+func (s *Scheduler) RunOnce(ctx context.Context) error {
+	s.mu.Lock()
+	tasks := append([]*scheduledTask(nil), s.tasks...)
+	s.mu.Unlock()
+
+	var errs []error
+	for _, st := range tasks {
+		if ctx.Err() != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", st.name, ctx.Err()))
+			continue
+		}
+		if err := s.execute(ctx, st); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // Stop halts all running tasks.
 // It closes the stop channel for each task's goroutine, causing them to exit.
 //
 // This is a graceful shutdown - it doesn't forcefully kill goroutines,
-// but rather signals them to stop. If a task is currently executing,
-// it will finish its current run before stopping.
+// but rather signals them to stop. If a task is currently executing, the context
+// passed to its Run is cancelled, and the task stops once that run returns.
+//
+// Stop waits for all task goroutines to fully exit before returning. Then tasks
+// that implement io.Closer are closed, and any Close errors are joined together
+// (see errors.Join) and returned. Use Shutdown to bound how long the wait may take.
+func (s *Scheduler) Stop() error {
+	return s.Shutdown(context.Background())
+}
+
+// Shutdown stops all running tasks like Stop, but gives up waiting for in-flight
+// runs once ctx is done. It returns ctx.Err() if some tasks were still running at
+// that point; their goroutines exit on their own when the current run finishes,
+// and no task is closed.
+//
+// Example:
 //
-// Stop waits for all task goroutines to fully exit before returning.
-func (s *Scheduler) Stop() {
-	for _, scheduledTask := range s.tasks {
+//	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+//	defer cancel()
+//	if err := sched.Shutdown(ctx); err != nil {
+//		log.Warn().Err(err).Msg("Tasks still running after shutdown timeout")
+//	}
+func (s *Scheduler) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.running = false
+	tasks := append([]*scheduledTask(nil), s.tasks...)
+	if s.cancel != nil {
+		// Abort in-flight runs (e.g., pending HTTP requests)
+		s.cancel()
+	}
+	s.mu.Unlock()
+
+	for _, scheduledTask := range tasks {
 		scheduledTask.stopOnce.Do(func() {
 			close(scheduledTask.stop)
 		})
 	}
-	// Wait for all goroutines to cleanup and exit
-	s.wg.Wait()
+
+	// Wait for all goroutines to cleanup and exit, or for ctx to expire
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	var errs []error
+	for _, st := range tasks {
+		if err := closeTask(st); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// closeTask runs the final cleanup of a task that implements io.Closer
+// (e.g., flushing state). Tasks without cleanup are left alone.
+func closeTask(st *scheduledTask) error {
+	closer, ok := st.task.(io.Closer)
+	if !ok {
+		return nil
+	}
+	if err := closer.Close(); err != nil {
+		log.Error().Err(err).Str("task", st.name).Msg("Task cleanup failed")
+		return fmt.Errorf("%s: %w", st.name, err)
+	}
+	return nil
+}
+
+// Running reports whether the scheduler has been started and not yet stopped.
+func (s *Scheduler) Running() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.running
+}
+
+// TaskStatuses returns a snapshot of the most recent run outcome of each task.
+// Tasks that haven't run yet are not included.
+func (s *Scheduler) TaskStatuses() map[string]TaskStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	statuses := make(map[string]TaskStatus, len(s.tasks))
+	for _, st := range s.tasks {
+		if !st.lastStatus.LastRun.IsZero() {
+			statuses[st.name] = st.lastStatus
+		}
+	}
+	return statuses
+}
+
+// TaskNames returns the names of all scheduled tasks, in scheduling order.
+func (s *Scheduler) TaskNames() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	names := make([]string, 0, len(s.tasks))
+	for _, st := range s.tasks {
+		names = append(names, st.name)
+	}
+	return names
+}
+
+// LastRun returns when the named task's most recent run finished and the error it
+// returned (nil if it succeeded). The time is zero if the task hasn't run yet.
+// It returns ErrUnknownTask if no task with that name is scheduled.
+func (s *Scheduler) LastRun(name string) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, st := range s.tasks {
+		if st.name == name {
+			return st.lastStatus.LastRun, st.lastErr
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: %q", ErrUnknownTask, name)
+}
+
+// execute runs a task once with ctx, logs how long it took and whether it succeeded,
+// and records the outcome for status reporting.
+// If the task is already running, the run is skipped and logged, and execute returns nil.
+//
+// So a task failing every run doesn't flood the logs, only the first failure of a streak
+// is logged in full; after that a "still failing" summary is logged at 2, 4, 8, ...
+// failures in a row, and the other failures only at debug level.
+func (s *Scheduler) execute(ctx context.Context, st *scheduledTask) error {
+	if !st.inFlight.CompareAndSwap(false, true) {
+		log.Warn().Str("task", st.name).Msg("Skipping run: previous run is still in progress")
+		return nil
+	}
+	defer st.inFlight.Store(false)
+
+	start := time.Now()
+	err := st.task.Run(ctx)
+	duration := time.Since(start)
+
+	status := TaskStatus{LastRun: time.Now(), Success: err == nil, Duration: duration}
+	if err != nil {
+		status.Error = err.Error()
+	}
+
+	s.mu.Lock()
+	previousFailures := st.lastStatus.ConsecutiveFailures
+	if err != nil {
+		status.ConsecutiveFailures = previousFailures + 1
+	}
+	st.lastStatus = status
+	st.lastErr = err
+	alerts := s.alerts
+	s.mu.Unlock()
+
+	failures := status.ConsecutiveFailures
+	switch {
+	case err == nil && previousFailures > 0:
+		log.Info().Str("task", st.name).Dur("duration", duration).Int("failures", previousFailures).Msg("Task recovered")
+	case err == nil:
+		log.Info().Str("task", st.name).Dur("duration", duration).Msg("Task run completed")
+	case failures == 1:
+		log.Error().Err(err).Str("task", st.name).Dur("duration", duration).Msg("Task execution failed")
+	case failures&(failures-1) == 0:
+		// A power of two: the summaries get rarer the longer the streak goes on
+		log.Error().Err(err).Str("task", st.name).Int("consecutive_failures", failures).Msg("Task still failing")
+	default:
+		log.Debug().Err(err).Str("task", st.name).Dur("duration", duration).Int("consecutive_failures", failures).Msg("Task execution failed")
+	}
+
+	alertFailures(ctx, alerts, st.name, previousFailures, status.ConsecutiveFailures, err)
+	return err
+}
+
+// alertFailures sends the failing or recovered notification configured by alerts, if
+// the run of the named task that just finished with err calls for one.
+// previousFailures and failures are the failure streak before and after the run.
+func alertFailures(ctx context.Context, alerts FailureAlerts, name string, previousFailures, failures int, err error) {
+	if alerts.Notifier == nil {
+		return
+	}
+
+	var subject, message string
+	var severity notifier.Severity
+	switch {
+	case err != nil && alerts.AlertAfter > 0 && failures == alerts.AlertAfter:
+		severity = notifier.SeverityFailure
+		subject = fmt.Sprintf("Task Failing: %s", name)
+		message = fmt.Sprintf("Task %s has failed %d times in a row.\nLast error: %v", name, failures, err)
+	case err == nil && alerts.RecoveryAfter > 0 && previousFailures >= alerts.RecoveryAfter:
+		severity = notifier.SeveritySuccess
+		subject = fmt.Sprintf("Task Recovered: %s", name)
+		message = fmt.Sprintf("Task %s recovered after %d failures.", name, previousFailures)
+	default:
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	if err := alerts.Notifier.SendNotification(notifier.WithSeverity(ctx, severity), subject, message); err != nil {
+		log.Error().Err(err).Str("task", name).Str("subject", subject).Msg("Failed to send task health notification")
+	}
 }
@@ -1,17 +1,26 @@
 package scheduler
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"watchdog/internal/notifier"
 )
 
 // MockTask is a mock implementation of the Task interface for testing
 type MockTask struct {
+	name       string
 	runCount   int
 	runError   error
 	runFunc    func() error
@@ -19,7 +28,7 @@ type MockTask struct {
 	runHistory []time.Time
 }
 
-func (m *MockTask) Run() error {
+func (m *MockTask) Run(context.Context) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -32,6 +41,13 @@ func (m *MockTask) Run() error {
 	return m.runError
 }
 
+func (m *MockTask) Name() string {
+	if m.name != "" {
+		return m.name
+	}
+	return "MockTask"
+}
+
 func (m *MockTask) GetRunCount() int {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -62,6 +78,18 @@ func TestScheduler_ScheduleTask(t *testing.T) {
 	assert.Equal(t, task, sched.tasks[0].task)
 	assert.Equal(t, 5*time.Minute, sched.tasks[0].interval)
 	assert.NotNil(t, sched.tasks[0].stop)
+	assert.True(t, sched.tasks[0].runImmediately)
+}
+
+func TestScheduler_ScheduleTaskWithOptions(t *testing.T) {
+	sched := NewScheduler()
+	task := &MockTask{}
+
+	sched.ScheduleTaskWithOptions(task, 5*time.Minute, TaskOptions{RunImmediately: false})
+
+	require.Len(t, sched.tasks, 1)
+	assert.Equal(t, task, sched.tasks[0].task)
+	assert.False(t, sched.tasks[0].runImmediately)
 }
 
 func TestScheduler_ScheduleMultipleTasks(t *testing.T) {
@@ -107,6 +135,32 @@ func TestScheduler_Start_ExecutesTasks(t *testing.T) {
 	sched.Stop()
 }
 
+func TestScheduler_Start_RunsImmediatelyWithLongInterval(t *testing.T) {
+	sched := NewScheduler()
+	task := &MockTask{}
+
+	sched.ScheduleTask(task, 1*time.Hour)
+	sched.Start()
+
+	time.Sleep(50 * time.Millisecond)
+	sched.Stop()
+
+	assert.Equal(t, 1, task.GetRunCount(), "Task should run once right after Start")
+}
+
+func TestScheduler_Start_RunImmediatelyDisabled(t *testing.T) {
+	sched := NewScheduler()
+	task := &MockTask{}
+
+	sched.ScheduleTaskWithOptions(task, 1*time.Hour, TaskOptions{RunImmediately: false})
+	sched.Start()
+
+	time.Sleep(50 * time.Millisecond)
+	sched.Stop()
+
+	assert.Equal(t, 0, task.GetRunCount(), "Task should wait for the first interval")
+}
+
 func TestScheduler_Start_MultipleTasksRunIndependently(t *testing.T) {
 	sched := NewScheduler()
 	task1 := &MockTask{}
@@ -165,6 +219,69 @@ func TestScheduler_Stop(t *testing.T) {
 	assert.Equal(t, countBeforeStop, countAfterStop)
 }
 
+// closingTask is a Task with a final cleanup step that may fail
+type closingTask struct {
+	MockTask
+	closeErr error
+	closed   atomic.Bool
+}
+
+func (c *closingTask) Close() error {
+	c.closed.Store(true)
+	return c.closeErr
+}
+
+func TestScheduler_Stop_BlocksUntilTasksReturn(t *testing.T) {
+	sched := NewScheduler()
+	started := make(chan struct{})
+	var finished atomic.Bool
+	task := &closingTask{MockTask: MockTask{name: "slow", runFunc: func() error {
+		close(started)
+		time.Sleep(100 * time.Millisecond)
+		finished.Store(true)
+		return nil
+	}}}
+	sched.ScheduleTask(task, time.Hour)
+	sched.Start()
+	<-started
+
+	require.NoError(t, sched.Stop())
+
+	assert.True(t, finished.Load(), "Stop returned before the in-flight run finished")
+	assert.True(t, task.closed.Load())
+}
+
+func TestScheduler_Stop_AggregatesCleanupErrors(t *testing.T) {
+	sched := NewScheduler()
+	errA := errors.New("flush failed")
+	errB := errors.New("connection close failed")
+	taskA := &closingTask{MockTask: MockTask{name: "a"}, closeErr: errA}
+	taskB := &closingTask{MockTask: MockTask{name: "b"}, closeErr: errB}
+	healthy := &closingTask{MockTask: MockTask{name: "healthy"}}
+	sched.ScheduleTask(taskA, time.Hour)
+	sched.ScheduleTask(healthy, time.Hour)
+	sched.ScheduleTask(taskB, time.Hour)
+	sched.Start()
+
+	err := sched.Stop()
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errA)
+	assert.ErrorIs(t, err, errB)
+	assert.Contains(t, err.Error(), "a: flush failed")
+	assert.Contains(t, err.Error(), "b: connection close failed")
+	assert.True(t, healthy.closed.Load())
+}
+
+func TestScheduler_RemoveTask_ClosesTask(t *testing.T) {
+	sched := NewScheduler()
+	task := &closingTask{MockTask: MockTask{name: "removed"}}
+	sched.ScheduleTask(task, time.Hour)
+
+	require.True(t, sched.RemoveTask(task))
+	assert.True(t, task.closed.Load())
+}
+
 func TestScheduler_Stop_MultipleTasks(t *testing.T) {
 	sched := NewScheduler()
 	task1 := &MockTask{}
@@ -187,6 +304,53 @@ func TestScheduler_Stop_MultipleTasks(t *testing.T) {
 	assert.Equal(t, count2Before, task2.GetRunCount())
 }
 
+func TestScheduler_Shutdown_WaitsForRunningTasks(t *testing.T) {
+	sched := NewScheduler()
+	task := &MockTask{
+		runFunc: func() error {
+			time.Sleep(100 * time.Millisecond)
+			return nil
+		},
+	}
+	sched.ScheduleTask(task, time.Hour)
+	sched.Start()
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	err := sched.Shutdown(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, task.GetRunCount())
+	assert.False(t, sched.Running())
+}
+
+func TestScheduler_Shutdown_GivesUpAfterTimeout(t *testing.T) {
+	sched := NewScheduler()
+	release := make(chan struct{})
+	started := make(chan struct{})
+	task := &MockTask{
+		runFunc: func() error {
+			// Simulates a notifier stuck on a hung HTTP call
+			close(started)
+			<-release
+			return nil
+		},
+	}
+	sched.ScheduleTask(task, time.Hour)
+	sched.Start()
+	<-started
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := sched.Shutdown(ctx)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
 func TestScheduler_Start_WithZeroTasks(t *testing.T) {
 	sched := NewScheduler()
 
@@ -242,6 +406,110 @@ func TestScheduler_TaskWithLongExecution(t *testing.T) {
 	assert.LessOrEqual(t, task.GetRunCount(), 3)
 }
 
+// overlapTask records whether Run was ever invoked while another Run was in progress
+type overlapTask struct {
+	active     atomic.Int32
+	overlapped atomic.Bool
+	runs       atomic.Int32
+	delay      time.Duration
+}
+
+func (o *overlapTask) Run(context.Context) error {
+	if o.active.Add(1) > 1 {
+		o.overlapped.Store(true)
+	}
+	defer o.active.Add(-1)
+	o.runs.Add(1)
+	time.Sleep(o.delay)
+	return nil
+}
+
+func (o *overlapTask) Name() string {
+	return "overlap"
+}
+
+func TestScheduler_SlowTaskNeverOverlaps(t *testing.T) {
+	sched := NewScheduler()
+	task := &overlapTask{delay: 80 * time.Millisecond}
+
+	// Ticks fire several times during each run
+	sched.ScheduleTask(task, 10*time.Millisecond)
+	sched.Start()
+	time.Sleep(400 * time.Millisecond)
+	sched.Stop()
+
+	assert.False(t, task.overlapped.Load())
+	assert.GreaterOrEqual(t, task.runs.Load(), int32(2))
+	// Ticks that fired mid-run are skipped, so runs are at least an interval apart
+	assert.LessOrEqual(t, task.runs.Load(), int32(5))
+}
+
+func TestScheduler_RunOnceSkipsTaskAlreadyRunning(t *testing.T) {
+	sched := NewScheduler()
+	task := &overlapTask{delay: 200 * time.Millisecond}
+	sched.ScheduleTask(task, time.Hour)
+	sched.Start()
+	defer sched.Stop()
+
+	require.Eventually(t, func() bool { return task.active.Load() == 1 }, time.Second, 5*time.Millisecond)
+	assert.NoError(t, sched.RunOnce(context.Background()))
+
+	assert.False(t, task.overlapped.Load())
+	assert.Equal(t, int32(1), task.runs.Load())
+}
+
+// ctxTask blocks in Run until its context is cancelled
+type ctxTask struct {
+	started chan struct{}
+	err     chan error
+}
+
+func (c *ctxTask) Run(ctx context.Context) error {
+	close(c.started)
+	<-ctx.Done()
+	c.err <- ctx.Err()
+	return ctx.Err()
+}
+
+func (c *ctxTask) Name() string {
+	return "ctx"
+}
+
+func TestScheduler_StopCancelsInFlightRun(t *testing.T) {
+	sched := NewScheduler()
+	task := &ctxTask{started: make(chan struct{}), err: make(chan error, 1)}
+	sched.ScheduleTask(task, time.Hour)
+	sched.Start()
+
+	<-task.started
+	stopped := make(chan error, 1)
+	go func() { stopped <- sched.Stop() }()
+
+	select {
+	case err := <-task.err:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("Stop did not cancel the in-flight run's context")
+	}
+	require.NoError(t, <-stopped)
+
+	_, err := sched.LastRun("ctx")
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestScheduler_RunOnceCancelledContext(t *testing.T) {
+	sched := NewScheduler()
+	task := &MockTask{}
+	sched.ScheduleTask(task, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := sched.RunOnce(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 0, task.GetRunCount())
+}
+
 func TestScheduledTask_StopChannel(t *testing.T) {
 	task := &MockTask{}
 	st := &scheduledTask{
@@ -327,3 +595,435 @@ func TestScheduler_RestartAfterStop(t *testing.T) {
 	// Note: Current implementation doesn't support restart
 	// This test documents the expected behavior
 }
+
+func TestScheduler_RunOnce(t *testing.T) {
+	sched := NewScheduler()
+	task1 := &MockTask{}
+	task2 := &MockTask{}
+
+	// Long intervals must not delay a one-shot run
+	sched.ScheduleTask(task1, 1*time.Hour)
+	sched.ScheduleTask(task2, 1*time.Hour)
+
+	err := sched.RunOnce(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, task1.GetRunCount())
+	assert.Equal(t, 1, task2.GetRunCount())
+}
+
+func TestScheduler_RunOnce_AggregatesErrors(t *testing.T) {
+	sched := NewScheduler()
+	err1 := errors.New("first failed")
+	err2 := errors.New("second failed")
+	task1 := &MockTask{runError: err1}
+	task2 := &MockTask{}
+	task3 := &MockTask{runError: err2}
+
+	sched.ScheduleTask(task1, time.Minute)
+	sched.ScheduleTask(task2, time.Minute)
+	sched.ScheduleTask(task3, time.Minute)
+
+	err := sched.RunOnce(context.Background())
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, err1)
+	assert.ErrorIs(t, err, err2)
+	// A failing task must not prevent the others from running
+	assert.Equal(t, 1, task2.GetRunCount())
+	assert.Equal(t, 1, task3.GetRunCount())
+}
+
+func TestScheduler_Running(t *testing.T) {
+	sched := NewScheduler()
+	sched.ScheduleTask(&MockTask{}, time.Hour)
+
+	assert.False(t, sched.Running())
+	sched.Start()
+	assert.True(t, sched.Running())
+	sched.Stop()
+	assert.False(t, sched.Running())
+}
+
+func TestScheduler_PauseDropsTicksUntilResume(t *testing.T) {
+	sched := NewScheduler()
+	task := &MockTask{}
+	sched.ScheduleTask(task, 20*time.Millisecond)
+	sched.Start()
+	defer func() { _ = sched.Stop() }()
+
+	require.Eventually(t, func() bool { return task.GetRunCount() >= 2 }, time.Second, 5*time.Millisecond)
+
+	sched.Pause()
+	assert.True(t, sched.Paused())
+	time.Sleep(30 * time.Millisecond) // let a run in progress at Pause finish
+	paused := task.GetRunCount()
+	time.Sleep(150 * time.Millisecond)
+	assert.Equal(t, paused, task.GetRunCount(), "task ran while paused")
+
+	sched.Resume()
+	assert.False(t, sched.Paused())
+	// Dropped ticks aren't replayed: runs resume at the normal pace
+	require.Eventually(t, func() bool { return task.GetRunCount() >= paused+2 }, time.Second, 5*time.Millisecond)
+}
+
+func TestScheduler_PausedBeforeStartSkipsImmediateRun(t *testing.T) {
+	sched := NewScheduler()
+	task := &MockTask{}
+	sched.ScheduleTask(task, time.Hour)
+
+	sched.Pause()
+	sched.Start()
+	time.Sleep(50 * time.Millisecond)
+	require.NoError(t, sched.Stop())
+
+	assert.Equal(t, 0, task.GetRunCount())
+}
+
+func TestScheduler_TaskStatuses(t *testing.T) {
+	sched := NewScheduler()
+	sched.ScheduleTask(&MockTask{}, time.Hour)
+	sched.ScheduleTask(&failingTask{}, time.Hour)
+
+	assert.Equal(t, []string{"MockTask", "failingTask"}, sched.TaskNames())
+	assert.Empty(t, sched.TaskStatuses())
+
+	_ = sched.RunOnce(context.Background())
+
+	statuses := sched.TaskStatuses()
+	require.Len(t, statuses, 2)
+	assert.True(t, statuses["MockTask"].Success)
+	assert.Empty(t, statuses["MockTask"].Error)
+	assert.False(t, statuses["failingTask"].Success)
+	assert.Equal(t, "always fails", statuses["failingTask"].Error)
+	assert.False(t, statuses["failingTask"].LastRun.IsZero())
+}
+
+// failingTask is a Task whose runs always fail
+type failingTask struct{}
+
+func (f *failingTask) Run(context.Context) error {
+	return errors.New("always fails")
+}
+
+func (f *failingTask) Name() string {
+	return "failingTask"
+}
+
+func TestScheduler_LogsTaskNameOnError(t *testing.T) {
+	var buf bytes.Buffer
+	original := log.Logger
+	log.Logger = zerolog.New(&buf)
+	t.Cleanup(func() { log.Logger = original })
+
+	sched := NewScheduler()
+	sched.ScheduleTask(&MockTask{name: "telnyx-balance", runError: errors.New("api down")}, time.Hour)
+
+	require.Error(t, sched.RunOnce(context.Background()))
+
+	assert.Contains(t, buf.String(), `"task":"telnyx-balance"`)
+	assert.Contains(t, buf.String(), `"error":"api down"`)
+	assert.Equal(t, []string{"telnyx-balance"}, sched.TaskNames())
+}
+
+func TestScheduler_LastRun(t *testing.T) {
+	sched := NewScheduler()
+	task := &MockTask{name: "flaky"}
+	sched.ScheduleTask(task, time.Hour)
+
+	lastRun, err := sched.LastRun("flaky")
+	assert.True(t, lastRun.IsZero())
+	assert.NoError(t, err)
+
+	task.runError = errors.New("api down")
+	require.Error(t, sched.RunOnce(context.Background()))
+	failedAt, err := sched.LastRun("flaky")
+	assert.False(t, failedAt.IsZero())
+	assert.EqualError(t, err, "api down")
+
+	task.runError = nil
+	require.NoError(t, sched.RunOnce(context.Background()))
+	succeededAt, err := sched.LastRun("flaky")
+	assert.NoError(t, err)
+	assert.False(t, succeededAt.Before(failedAt))
+
+	_, err = sched.LastRun("missing")
+	assert.ErrorIs(t, err, ErrUnknownTask)
+}
+
+func TestScheduler_RecordsRunDuration(t *testing.T) {
+	sched := NewScheduler()
+	sched.ScheduleTask(&MockTask{name: "slow", runFunc: func() error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	}}, time.Hour)
+
+	require.NoError(t, sched.RunOnce(context.Background()))
+
+	status := sched.TaskStatuses()["slow"]
+	assert.True(t, status.Success)
+	assert.GreaterOrEqual(t, status.Duration, 50*time.Millisecond)
+	assert.Less(t, status.Duration, time.Second)
+}
+
+func TestScheduler_LogsRunDuration(t *testing.T) {
+	var buf bytes.Buffer
+	original := log.Logger
+	log.Logger = zerolog.New(&buf)
+	t.Cleanup(func() { log.Logger = original })
+
+	sched := NewScheduler()
+	sched.ScheduleTask(&MockTask{name: "quick"}, time.Hour)
+	require.NoError(t, sched.RunOnce(context.Background()))
+
+	assert.Contains(t, buf.String(), `"task":"quick"`)
+	assert.Contains(t, buf.String(), `"duration":`)
+	assert.Contains(t, buf.String(), "Task run completed")
+}
+
+func TestScheduler_Reschedule_KeepsLastRun(t *testing.T) {
+	sched := NewScheduler()
+	task := &MockTask{name: "kept"}
+	sched.ScheduleTask(task, time.Hour)
+	sched.Start()
+	defer sched.Stop()
+
+	require.Eventually(t, func() bool { return task.GetRunCount() == 1 }, time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		lastRun, _ := sched.LastRun("kept")
+		return !lastRun.IsZero()
+	}, time.Second, 10*time.Millisecond)
+
+	require.True(t, sched.Reschedule(task, 2*time.Hour))
+	lastRun, err := sched.LastRun("kept")
+	assert.False(t, lastRun.IsZero())
+	assert.NoError(t, err)
+}
+
+func TestScheduler_ScheduleTask_WhileRunning(t *testing.T) {
+	sched := NewScheduler()
+	sched.Start()
+	defer sched.Stop()
+
+	task := &MockTask{}
+	sched.ScheduleTask(task, time.Hour)
+
+	assert.Eventually(t, func() bool { return task.GetRunCount() == 1 }, time.Second, 10*time.Millisecond,
+		"Task added to a running scheduler should start immediately")
+}
+
+func TestScheduler_RemoveTask(t *testing.T) {
+	sched := NewScheduler()
+	task1 := &MockTask{}
+	task2 := &MockTask{}
+	sched.ScheduleTask(task1, 20*time.Millisecond)
+	sched.ScheduleTask(task2, time.Hour)
+	sched.Start()
+	defer sched.Stop()
+
+	time.Sleep(50 * time.Millisecond)
+	assert.True(t, sched.RemoveTask(task1))
+	countAfterRemove := task1.GetRunCount()
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, countAfterRemove, task1.GetRunCount(), "Removed task should not run again")
+	assert.Len(t, sched.TaskNames(), 1)
+
+	// Removing an unknown task is a no-op
+	assert.False(t, sched.RemoveTask(&MockTask{}))
+}
+
+func TestScheduler_RemoveTask_NotStarted(t *testing.T) {
+	sched := NewScheduler()
+	task := &MockTask{}
+	sched.ScheduleTask(task, time.Minute)
+
+	assert.True(t, sched.RemoveTask(task))
+	assert.False(t, sched.HasTasks())
+}
+
+func TestScheduler_Reschedule(t *testing.T) {
+	sched := NewScheduler()
+	task := &MockTask{}
+	sched.ScheduleTask(task, time.Hour)
+	sched.Start()
+	defer sched.Stop()
+
+	require.Eventually(t, func() bool { return task.GetRunCount() == 1 }, time.Second, 10*time.Millisecond)
+
+	assert.True(t, sched.Reschedule(task, 30*time.Millisecond))
+
+	// The new, shorter interval takes effect without an immediate re-run
+	assert.Eventually(t, func() bool { return task.GetRunCount() >= 3 }, time.Second, 10*time.Millisecond)
+	assert.False(t, sched.Reschedule(&MockTask{}, time.Minute))
+}
+
+func TestScheduler_Reschedule_NotStarted(t *testing.T) {
+	sched := NewScheduler()
+	task := &MockTask{}
+	sched.ScheduleTask(task, time.Hour)
+
+	assert.True(t, sched.Reschedule(task, time.Minute))
+	assert.Equal(t, time.Minute, sched.tasks[0].interval)
+}
+
+// recordingNotifier records the subjects of the notifications it is asked to send.
+type recordingNotifier struct {
+	mu         sync.Mutex
+	subjects   []string
+	messages   []string
+	severities []notifier.Severity
+}
+
+func (n *recordingNotifier) SendNotification(ctx context.Context, subject, message string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.subjects = append(n.subjects, subject)
+	n.messages = append(n.messages, message)
+	n.severities = append(n.severities, notifier.SeverityFromContext(ctx))
+	return nil
+}
+
+// runSequence runs task once per outcome through RunOnce (true = success).
+func runSequence(t *testing.T, sched *Scheduler, task *MockTask, outcomes ...bool) {
+	t.Helper()
+	for _, ok := range outcomes {
+		task.runError = nil
+		if !ok {
+			task.runError = errors.New("github unreachable")
+		}
+		_ = sched.RunOnce(context.Background())
+	}
+}
+
+func TestScheduler_FailureAlerts(t *testing.T) {
+	sched := NewScheduler()
+	task := &MockTask{name: "github"}
+	sched.ScheduleTask(task, time.Hour)
+	notif := &recordingNotifier{}
+	sched.SetFailureAlerts(FailureAlerts{Notifier: notif, AlertAfter: 3, RecoveryAfter: 2})
+
+	runSequence(t, sched, task, false, false)
+	assert.Empty(t, notif.subjects, "no alert before the threshold")
+
+	runSequence(t, sched, task, false, false, false)
+	assert.Equal(t, []string{"Task Failing: github"}, notif.subjects, "alerted once per streak")
+	assert.Contains(t, notif.messages[0], "failed 3 times in a row")
+	assert.Contains(t, notif.messages[0], "github unreachable")
+	assert.Equal(t, 5, sched.TaskStatuses()["github"].ConsecutiveFailures)
+
+	runSequence(t, sched, task, true, true)
+	assert.Equal(t, []string{"Task Failing: github", "Task Recovered: github"}, notif.subjects)
+	assert.Equal(t, "Task github recovered after 5 failures.", notif.messages[1])
+	assert.Zero(t, sched.TaskStatuses()["github"].ConsecutiveFailures)
+	assert.Equal(t, []notifier.Severity{notifier.SeverityFailure, notifier.SeveritySuccess}, notif.severities)
+}
+
+func TestScheduler_FailureAlerts_ShortStreakNotReported(t *testing.T) {
+	sched := NewScheduler()
+	task := &MockTask{name: "github"}
+	sched.ScheduleTask(task, time.Hour)
+	notif := &recordingNotifier{}
+	sched.SetFailureAlerts(FailureAlerts{Notifier: notif, AlertAfter: 3, RecoveryAfter: 2})
+
+	// A single failure neither alerts nor counts as a recovery
+	runSequence(t, sched, task, false, true, false, true)
+	assert.Empty(t, notif.subjects)
+
+	// Recovery notifications can be used without failing alerts
+	sched.SetFailureAlerts(FailureAlerts{Notifier: notif, RecoveryAfter: 2})
+	runSequence(t, sched, task, false, false, false, true)
+	assert.Equal(t, []string{"Task Recovered: github"}, notif.subjects)
+	assert.Equal(t, "Task github recovered after 3 failures.", notif.messages[0])
+}
+
+func TestScheduler_FailureAlerts_DisabledWithoutNotifier(t *testing.T) {
+	sched := NewScheduler()
+	task := &MockTask{name: "github"}
+	sched.ScheduleTask(task, time.Hour)
+	sched.SetFailureAlerts(FailureAlerts{AlertAfter: 1, RecoveryAfter: 1})
+
+	assert.NotPanics(t, func() { runSequence(t, sched, task, false, true) })
+}
+
+func TestBackoffInterval(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxInterval time.Duration
+		failures    int
+		want        time.Duration
+	}{
+		{name: "no failures", maxInterval: time.Hour, failures: 0, want: 5 * time.Minute},
+		{name: "one failure", maxInterval: time.Hour, failures: 1, want: 10 * time.Minute},
+		{name: "three failures", maxInterval: time.Hour, failures: 3, want: 40 * time.Minute},
+		{name: "capped", maxInterval: time.Hour, failures: 4, want: time.Hour},
+		{name: "long streak", maxInterval: time.Hour, failures: 1000, want: time.Hour},
+		{name: "disabled", maxInterval: 0, failures: 3, want: 5 * time.Minute},
+		{name: "cap below interval", maxInterval: time.Minute, failures: 3, want: 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, backoffInterval(5*time.Minute, tt.maxInterval, tt.failures))
+		})
+	}
+}
+
+func TestScheduler_FailureBackoff_GrowsInterval(t *testing.T) {
+	var failing atomic.Bool
+	failing.Store(true)
+	task := &MockTask{name: "github", runFunc: func() error {
+		if failing.Load() {
+			return errors.New("github unreachable")
+		}
+		return nil
+	}}
+
+	sched := NewScheduler()
+	sched.SetFailureBackoff(FailureBackoff{MaxInterval: 160 * time.Millisecond})
+	sched.ScheduleTask(task, 20*time.Millisecond)
+	sched.Start()
+	defer sched.Stop()
+
+	// Without backoff the task would run about 25 times; with it, waits of
+	// 40, 80, 160, 160ms leave room for only a handful of runs
+	time.Sleep(500 * time.Millisecond)
+	history := task.GetRunHistory()
+	require.GreaterOrEqual(t, len(history), 4)
+	assert.LessOrEqual(t, len(history), 7)
+	for i := 2; i < 4; i++ {
+		assert.Greater(t, history[i].Sub(history[i-1]), history[i-1].Sub(history[i-2]), "wait %d should be longer than the one before", i)
+	}
+
+	// A successful run restores the interval
+	failing.Store(false)
+	assert.Eventually(t, func() bool {
+		history := task.GetRunHistory()
+		return len(history) >= 3 && history[len(history)-1].Sub(history[len(history)-2]) < 40*time.Millisecond
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestScheduler_FailureLogsAreThrottled(t *testing.T) {
+	var buf bytes.Buffer
+	original := log.Logger
+	log.Logger = zerolog.New(&buf).Level(zerolog.InfoLevel)
+	t.Cleanup(func() { log.Logger = original })
+
+	sched := NewScheduler()
+	task := &MockTask{name: "github", runError: errors.New("github unreachable")}
+	sched.ScheduleTask(task, time.Hour)
+
+	for i := 0; i < 20; i++ {
+		_ = sched.RunOnce(context.Background())
+	}
+
+	// The first failure, then summaries after 2, 4, 8 and 16 failures
+	logs := buf.String()
+	assert.Equal(t, 1, strings.Count(logs, "Task execution failed"))
+	assert.Equal(t, 4, strings.Count(logs, "Task still failing"))
+	assert.Contains(t, logs, `"consecutive_failures":16`)
+
+	task.runError = nil
+	require.NoError(t, sched.RunOnce(context.Background()))
+	assert.Contains(t, buf.String(), `"failures":20,"message":"Task recovered"`)
+}
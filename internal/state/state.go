@@ -0,0 +1,123 @@
+// Package state persists notification cooldown timestamps across restarts,
+// so a restarted watchdog (or a cron-driven --once run) doesn't re-alert about everything.
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// fileMu serializes read-modify-write cycles on state files.
+// Tasks share one file, each under its own namespace, and may save concurrently.
+var fileMu sync.Mutex
+
+// StateStore persists cooldown timestamps grouped by namespace (one namespace per task,
+// e.g. "github_prs" -> {"owner/repo#123": time}). FileStore is the only implementation
+// shipped; others (e.g. Redis or a database) only need to provide these two methods.
+//
+// Implementations must be safe for concurrent use: tasks share one store and may save
+// their namespaces concurrently.
+type StateStore interface {
+	// Load returns the timestamps saved under namespace, or an empty map if there are none.
+	// Storage errors are logged and treated as empty, so the task starts fresh.
+	Load(namespace string) map[string]time.Time
+
+	// Save replaces the timestamps stored under namespace, leaving other namespaces as they are.
+	Save(namespace string, entries map[string]time.Time) error
+}
+
+// FileStore is a StateStore backed by a JSON file.
+//
+// A nil *FileStore is valid and does nothing, so tasks work the same without persistence.
+type FileStore struct {
+	// Path is the location of the JSON state file
+	Path string
+}
+
+// Compile-time check that FileStore implements StateStore
+var _ StateStore = (*FileStore)(nil)
+
+// NewFileStore creates a store backed by the file at path. The file is created on first save.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{Path: path}
+}
+
+// Load returns the timestamps saved under namespace.
+// A missing or corrupt file is logged and treated as empty, so the task starts fresh.
+func (s *FileStore) Load(namespace string) map[string]time.Time {
+	if s == nil {
+		return map[string]time.Time{}
+	}
+
+	fileMu.Lock()
+	defer fileMu.Unlock()
+
+	entries := s.read()[namespace]
+	if entries == nil {
+		entries = map[string]time.Time{}
+	}
+	return entries
+}
+
+// Save replaces the timestamps stored under namespace and writes the file.
+// Other namespaces in the file are preserved. The file is written atomically
+// (temp file + rename) so a crash mid-write can't corrupt it.
+func (s *FileStore) Save(namespace string, entries map[string]time.Time) error {
+	if s == nil {
+		return nil
+	}
+
+	fileMu.Lock()
+	defer fileMu.Unlock()
+
+	data := s.read()
+	data[namespace] = entries
+
+	encoded, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %v", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary state file: %v", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(encoded); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write state file: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write state file: %v", err)
+	}
+	if err := os.Rename(tmp.Name(), s.Path); err != nil {
+		return fmt.Errorf("failed to replace state file: %v", err)
+	}
+	return nil
+}
+
+// read loads the whole state file. Must be called with fileMu held.
+func (s *FileStore) read() map[string]map[string]time.Time {
+	data := make(map[string]map[string]time.Time)
+
+	raw, err := os.ReadFile(s.Path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Warn().Err(err).Str("path", s.Path).Msg("Failed to read state file, starting fresh")
+		}
+		return data
+	}
+
+	if err := json.Unmarshal(raw, &data); err != nil {
+		log.Warn().Err(err).Str("path", s.Path).Msg("State file is corrupt, starting fresh")
+		return make(map[string]map[string]time.Time)
+	}
+	return data
+}
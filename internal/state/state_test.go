@@ -0,0 +1,106 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStore_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	store := NewFileStore(path)
+	require.NoError(t, store.Save("github_prs", map[string]time.Time{"owner/repo#1": now}))
+	require.NoError(t, store.Save("telnyx", map[string]time.Time{"low_balance": now.Add(time.Hour)}))
+
+	// A new store (as after a restart) sees both namespaces
+	reopened := NewFileStore(path)
+	assert.Equal(t, map[string]time.Time{"owner/repo#1": now}, reopened.Load("github_prs"))
+	assert.Equal(t, map[string]time.Time{"low_balance": now.Add(time.Hour)}, reopened.Load("telnyx"))
+	assert.Empty(t, reopened.Load("unknown"))
+}
+
+func TestFileStore_Load_MissingFile(t *testing.T) {
+	store := NewFileStore(filepath.Join(t.TempDir(), "missing.json"))
+
+	entries := store.Load("github_prs")
+
+	assert.NotNil(t, entries)
+	assert.Empty(t, entries)
+}
+
+func TestFileStore_Load_CorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	require.NoError(t, os.WriteFile(path, []byte("{not json"), 0o600))
+
+	store := NewFileStore(path)
+	assert.Empty(t, store.Load("github_prs"))
+
+	// Saving over a corrupt file starts fresh
+	require.NoError(t, store.Save("github_prs", map[string]time.Time{"owner/repo#1": time.Now()}))
+	assert.Len(t, store.Load("github_prs"), 1)
+}
+
+func TestFileStore_Nil(t *testing.T) {
+	var store *FileStore
+
+	assert.Empty(t, store.Load("github_prs"))
+	assert.NoError(t, store.Save("github_prs", map[string]time.Time{"x": time.Now()}))
+}
+
+// memoryStore is a StateStore kept in memory, standing in for a non-file backend.
+type memoryStore struct {
+	mu   sync.Mutex
+	data map[string]map[string]time.Time
+}
+
+func (s *memoryStore) Load(namespace string) map[string]time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := make(map[string]time.Time, len(s.data[namespace]))
+	for key, value := range s.data[namespace] {
+		entries[key] = value
+	}
+	return entries
+}
+
+func (s *memoryStore) Save(namespace string, entries map[string]time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.data == nil {
+		s.data = make(map[string]map[string]time.Time)
+	}
+	s.data[namespace] = entries
+	return nil
+}
+
+func TestStateStore_RoundTrip(t *testing.T) {
+	stores := map[string]func(t *testing.T) StateStore{
+		"file":   func(t *testing.T) StateStore { return NewFileStore(filepath.Join(t.TempDir(), "state.json")) },
+		"memory": func(t *testing.T) StateStore { return &memoryStore{} },
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			store := newStore(t)
+			now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+			assert.Empty(t, store.Load("github_prs"))
+
+			require.NoError(t, store.Save("github_prs", map[string]time.Time{"owner/repo#1": now, "owner/repo#2": now.Add(time.Minute)}))
+			require.NoError(t, store.Save("telnyx", map[string]time.Time{"low_balance": now}))
+			assert.Equal(t, map[string]time.Time{"owner/repo#1": now, "owner/repo#2": now.Add(time.Minute)}, store.Load("github_prs"))
+
+			// Saving a namespace replaces its entries and leaves the others alone
+			require.NoError(t, store.Save("github_prs", map[string]time.Time{"owner/repo#3": now}))
+			assert.Equal(t, map[string]time.Time{"owner/repo#3": now}, store.Load("github_prs"))
+			assert.Equal(t, map[string]time.Time{"low_balance": now}, store.Load("telnyx"))
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// Package tlsconfig builds the TLS settings for outbound requests, so self-hosted
+// endpoints (Apprise, GitHub Enterprise) using an internal CA can be trusted.
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// Load returns a TLS configuration trusting the system CAs plus the PEM-encoded CA
+// certificate(s) at caCertPath (if set). insecureSkipVerify disables certificate
+// verification entirely; only use it for testing.
+// Returns nil (the Go defaults) when neither option is set.
+func Load(caCertPath string, insecureSkipVerify bool) (*tls.Config, error) {
+	if caCertPath == "" && !insecureSkipVerify {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: insecureSkipVerify,
+	}
+
+	if caCertPath != "" {
+		pem, err := os.ReadFile(caCertPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %v", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("failed to parse CA certificate: no PEM certificates found in %s", caCertPath)
+		}
+		cfg.RootCAs = pool
+	}
+
+	return cfg, nil
+}
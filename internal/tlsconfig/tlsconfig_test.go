@@ -0,0 +1,83 @@
+package tlsconfig
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeServerCert saves the test server's self-signed certificate as a PEM CA file.
+func writeServerCert(t *testing.T, server *httptest.Server) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(path, data, 0600))
+	return path
+}
+
+func newTLSServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestLoad_TrustsConfiguredCA(t *testing.T) {
+	server := newTLSServer(t)
+
+	cfg, err := Load(writeServerCert(t, server), false)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+	assert.False(t, cfg.InsecureSkipVerify)
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}}
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestLoad_FailsWithoutCA(t *testing.T) {
+	server := newTLSServer(t)
+
+	cfg, err := Load("", false)
+	require.NoError(t, err)
+	assert.Nil(t, cfg, "no options should keep the Go defaults")
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}}
+	_, err = client.Get(server.URL)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "certificate")
+}
+
+func TestLoad_InsecureSkipVerify(t *testing.T) {
+	server := newTLSServer(t)
+
+	cfg, err := Load("", true)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+	assert.True(t, cfg.InsecureSkipVerify)
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}}
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+}
+
+func TestLoad_InvalidCAFile(t *testing.T) {
+	_, err := Load(filepath.Join(t.TempDir(), "missing.pem"), false)
+	assert.ErrorContains(t, err, "failed to read CA certificate")
+
+	garbage := filepath.Join(t.TempDir(), "garbage.pem")
+	require.NoError(t, os.WriteFile(garbage, []byte("not a certificate"), 0600))
+	_, err = Load(garbage, false)
+	assert.ErrorContains(t, err, "no PEM certificates found")
+}
@@ -0,0 +1,175 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"watchdog/internal/api"
+	"watchdog/internal/clock"
+	"watchdog/internal/config"
+	"watchdog/internal/metrics"
+	"watchdog/internal/notifier"
+	"watchdog/internal/state"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxHTTPCheckBodySize caps how much of a response body is read when looking for ExpectedBody.
+const maxHTTPCheckBodySize = 1 << 20 // 1 MiB
+
+// HTTPCheckTask monitors an arbitrary HTTP endpoint.
+// It sends an alert when the endpoint is unreachable, returns an unexpected status code,
+// or its body is missing the expected text.
+//
+// This implements the scheduler.Task interface via the Run() method.
+type HTTPCheckTask struct {
+	// config holds the endpoint, expectations and cooldown settings
+	config config.HTTPCheckConfig
+
+	// client is used to make the requests (shared pooled client by default)
+	client *http.Client
+
+	// retryConfig controls retries of transient failures (timeouts, 5xx) before alerting
+	retryConfig api.RetryConfig
+
+	// notifier is used to send alerts (via Apprise/Telegram/Discord/etc.)
+	notifier notifier.Notifier
+
+	// lastNotificationTime tracks when we last alerted about this endpoint
+	// Used to enforce the cooldown period while it stays unhealthy
+	lastNotificationTime time.Time
+
+	// state persists lastNotificationTime across restarts (nil = in-memory only)
+	state state.StateStore
+
+	// Clock tells the time for cooldowns and staleness checks (nil means the system clock)
+	Clock clock.Clock
+}
+
+// httpCheckStateKey is the key the alert cooldown is stored under in the check's namespace.
+const httpCheckStateKey = "alert"
+
+// stateNamespace returns the state file namespace for this check ("http_check:<name>").
+func (t *HTTPCheckTask) stateNamespace() string {
+	return "http_check:" + t.config.GetName()
+}
+
+// LoadState restores the alert cooldown saved by a previous process from store,
+// and saves it back whenever it changes. A nil store keeps it in memory only.
+func (t *HTTPCheckTask) LoadState(store state.StateStore) {
+	t.state = store
+	if store == nil {
+		return
+	}
+	if lastTime, ok := store.Load(t.stateNamespace())[httpCheckStateKey]; ok {
+		t.lastNotificationTime = lastTime
+	}
+}
+
+// NewHTTPCheckTask creates a new HTTP endpoint health-check task.
+func NewHTTPCheckTask(cfg config.HTTPCheckConfig, notifier notifier.Notifier) *HTTPCheckTask {
+	return &HTTPCheckTask{
+		config:      cfg,
+		client:      api.DefaultHTTPClient,
+		retryConfig: api.DefaultRetryConfig,
+		notifier:    notifier,
+		Clock:       clock.Real{},
+	}
+}
+
+// Name identifies the task in logs and status reports, e.g. "http-check:api".
+func (t *HTTPCheckTask) Name() string {
+	return "http-check:" + t.config.GetName()
+}
+
+// Run requests the endpoint and alerts if it is unhealthy.
+//
+// Returns:
+//   - An error if the alert fails to send
+//   - nil otherwise; an unhealthy endpoint is what this task reports, not a task failure
+func (t *HTTPCheckTask) Run(ctx context.Context) error {
+	checkCtx, cancel := context.WithTimeout(ctx, t.config.GetTimeout())
+	defer cancel()
+
+	defer metrics.ObserveTaskRun(t.Name(), time.Now())
+
+	problem := t.check(checkCtx)
+	if problem == "" {
+		log.Debug().Str("check", t.config.GetName()).Msg("HTTP check passed")
+		// The cooldown only covers an outage that continues; a new one alerts right away
+		if !t.lastNotificationTime.IsZero() {
+			t.lastNotificationTime = time.Time{}
+			t.saveState()
+		}
+		return nil
+	}
+
+	log.Warn().Str("check", t.config.GetName()).Str("problem", problem).Msg("HTTP check failed")
+
+	// Don't alert again while we're in the cooldown period
+	if !t.lastNotificationTime.IsZero() && clock.Since(t.Clock, t.lastNotificationTime) < t.config.GetNotificationCooldown() {
+		return nil
+	}
+
+	// Use a separate timeout so a timed-out check can still send its alert
+	notifyCtx, notifyCancel := context.WithTimeout(ctx, 30*time.Second)
+	defer notifyCancel()
+
+	subject := fmt.Sprintf("HTTP Check Failed: %s", t.config.GetName())
+	message := fmt.Sprintf("%s is unhealthy: %s", t.config.URL, problem)
+	if err := t.notifier.SendNotification(notifier.WithSeverity(notifyCtx, notifier.SeverityFailure), subject, message); err != nil {
+		return fmt.Errorf("failed to send notification: %v", err)
+	}
+
+	t.lastNotificationTime = clock.Now(t.Clock)
+	t.saveState()
+	return nil
+}
+
+// saveState persists the alert cooldown, if the task has a state store.
+func (t *HTTPCheckTask) saveState() {
+	if t.state == nil {
+		return
+	}
+	entries := map[string]time.Time{}
+	if !t.lastNotificationTime.IsZero() {
+		entries[httpCheckStateKey] = t.lastNotificationTime
+	}
+	if err := t.state.Save(t.stateNamespace(), entries); err != nil {
+		log.Error().Err(err).Str("check", t.config.GetName()).Msg("Failed to save notification state")
+	}
+}
+
+// check performs the request and returns a description of what's wrong, or "" if healthy.
+func (t *HTTPCheckTask) check(ctx context.Context) string {
+	req, err := http.NewRequestWithContext(ctx, "GET", t.config.URL, nil)
+	if err != nil {
+		return fmt.Sprintf("invalid request: %v", err)
+	}
+	req.Header.Set("User-Agent", api.UserAgent)
+
+	resp, err := api.DoWithRetry(ctx, t.client, req, t.retryConfig)
+	if err != nil {
+		return fmt.Sprintf("request failed: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if expected := t.config.GetExpectedStatus(); resp.StatusCode != expected {
+		return fmt.Sprintf("unexpected status %d (expected %d)", resp.StatusCode, expected)
+	}
+
+	if t.config.ExpectedBody != "" {
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPCheckBodySize))
+		if err != nil {
+			return fmt.Sprintf("failed to read response body: %v", err)
+		}
+		if !strings.Contains(string(body), t.config.ExpectedBody) {
+			return fmt.Sprintf("response body does not contain %q", t.config.ExpectedBody)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,236 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+	"watchdog/internal/api"
+	"watchdog/internal/clock"
+	"watchdog/internal/config"
+	"watchdog/internal/metrics"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestHTTPCheckTask returns an HTTPCheckTask that doesn't retry, to keep tests fast.
+func newTestHTTPCheckTask(cfg config.HTTPCheckConfig, notifier *MockNotifier) *HTTPCheckTask {
+	task := NewHTTPCheckTask(cfg, notifier)
+	task.retryConfig = api.RetryConfig{}
+	return task
+}
+
+func TestHTTPCheckTask_Run_Healthy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	mockNotifier := &MockNotifier{}
+	task := newTestHTTPCheckTask(config.HTTPCheckConfig{URL: server.URL, ExpectedBody: `"ok"`}, mockNotifier)
+
+	require.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertNotCalled(t, "SendNotification", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHTTPCheckTask_Run_MetricsPerCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	for _, name := range []string{"metrics-api", "metrics-web"} {
+		task := newTestHTTPCheckTask(config.HTTPCheckConfig{Name: name, URL: server.URL}, &MockNotifier{})
+		require.NoError(t, task.Run(context.Background()))
+	}
+
+	metricsServer := httptest.NewServer(metrics.Handler())
+	defer metricsServer.Close()
+	resp, err := http.Get(metricsServer.URL)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	// Each check gets its own series, labeled like its task name
+	assert.Contains(t, string(body), `watchdog_task_run_duration_seconds_count{task="http-check:metrics-api"}`)
+	assert.Contains(t, string(body), `watchdog_task_run_duration_seconds_count{task="http-check:metrics-web"}`)
+}
+
+func TestHTTPCheckTask_Run_Unhealthy(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		cfg      config.HTTPCheckConfig
+		contains string
+	}{
+		{
+			name:     "unexpected status",
+			status:   http.StatusNotFound,
+			contains: "unexpected status 404 (expected 200)",
+		},
+		{
+			name:     "custom expected status",
+			status:   http.StatusOK,
+			cfg:      config.HTTPCheckConfig{ExpectedStatus: http.StatusNoContent},
+			contains: "unexpected status 200 (expected 204)",
+		},
+		{
+			name:     "missing body text",
+			status:   http.StatusOK,
+			body:     `{"status":"degraded"}`,
+			cfg:      config.HTTPCheckConfig{ExpectedBody: `"ok"`},
+			contains: `response body does not contain "\"ok\""`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			cfg := tt.cfg
+			cfg.Name = "api"
+			cfg.URL = server.URL
+
+			mockNotifier := &MockNotifier{}
+			mockNotifier.On("SendNotification", mock.Anything, "HTTP Check Failed: api", mock.MatchedBy(func(msg string) bool {
+				return strings.Contains(msg, server.URL) && strings.Contains(msg, tt.contains)
+			})).Return(nil).Once()
+
+			task := newTestHTTPCheckTask(cfg, mockNotifier)
+
+			require.NoError(t, task.Run(context.Background()))
+			mockNotifier.AssertExpectations(t)
+			assert.False(t, task.lastNotificationTime.IsZero())
+		})
+	}
+}
+
+func TestHTTPCheckTask_Run_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close() // Nothing is listening anymore
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "HTTP Check Failed: "+url, mock.MatchedBy(func(msg string) bool {
+		return strings.Contains(msg, "request failed")
+	})).Return(nil).Once()
+
+	task := newTestHTTPCheckTask(config.HTTPCheckConfig{URL: url}, mockNotifier)
+
+	require.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertExpectations(t)
+}
+
+func TestHTTPCheckTask_Run_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	task := newTestHTTPCheckTask(config.HTTPCheckConfig{URL: server.URL, Timeout: "100ms"}, mockNotifier)
+
+	start := time.Now()
+	require.NoError(t, task.Run(context.Background()))
+	assert.Less(t, time.Since(start), 2*time.Second)
+	mockNotifier.AssertExpectations(t)
+}
+
+func TestHTTPCheckTask_Run_RespectsCooldown(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	task := newTestHTTPCheckTask(config.HTTPCheckConfig{URL: server.URL, NotificationCooldown: "1h"}, mockNotifier)
+
+	require.NoError(t, task.Run(context.Background()))
+	require.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 1)
+
+	// Once the cooldown has passed we alert again
+	task.lastNotificationTime = time.Now().Add(-2 * time.Hour)
+	mockNotifier.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	require.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 2)
+}
+
+func TestHTTPCheckTask_Run_RecoveryClearsCooldown(t *testing.T) {
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	store := &memoryStateStore{}
+	fake := clock.NewFake(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	task := newTestHTTPCheckTask(config.HTTPCheckConfig{Name: "api", URL: server.URL, NotificationCooldown: "1h"}, mockNotifier)
+	task.Clock = fake
+	task.LoadState(store)
+
+	// Down: alert
+	require.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 1)
+	assert.Contains(t, store.Load(task.stateNamespace()), httpCheckStateKey)
+
+	// Up: the cooldown is cleared, in memory and in the store
+	fake.Advance(5 * time.Minute)
+	healthy.Store(true)
+	require.NoError(t, task.Run(context.Background()))
+	assert.True(t, task.lastNotificationTime.IsZero())
+	assert.Empty(t, store.Load(task.stateNamespace()))
+
+	// Down again within the old cooldown: a new outage alerts right away
+	fake.Advance(5 * time.Minute)
+	healthy.Store(false)
+	require.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 2)
+}
+
+func TestHTTPCheckTask_Run_NotificationError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("webhook down"))
+
+	task := newTestHTTPCheckTask(config.HTTPCheckConfig{URL: server.URL}, mockNotifier)
+
+	err := task.Run(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "webhook down")
+	assert.True(t, task.lastNotificationTime.IsZero(), "cooldown should not start when the alert failed")
+}
+
+func TestTaskNames(t *testing.T) {
+	assert.Equal(t, "http-check:api", NewHTTPCheckTask(config.HTTPCheckConfig{Name: "api", URL: "https://example.com"}, nil).Name())
+	assert.Equal(t, "telnyx-balance", NewTelnyxBalanceCheckTask("", "", 10, time.Hour, nil).Name())
+	assert.Equal(t, "github-pr-review", NewPRReviewCheckTask(config.GitHubConfig{}, nil, "").Name())
+	assert.Equal(t, "github-issue-review", NewIssueReviewCheckTask(config.GitHubConfig{}, nil, "").Name())
+}
@@ -0,0 +1,52 @@
+package tasks
+
+import (
+	"fmt"
+	"time"
+	"watchdog/internal/config"
+)
+
+// formatLastUpdated renders a PR's or issue's last update time in the configured
+// last_updated_format: the RFC1123 timestamp, how long before now it was, or both.
+func formatLastUpdated(format string, updated, now time.Time) string {
+	switch format {
+	case config.LastUpdatedRelative:
+		return humanizeAge(now.Sub(updated))
+	case config.LastUpdatedBoth:
+		return fmt.Sprintf("%s (%s)", updated.Format(time.RFC1123), humanizeAge(now.Sub(updated)))
+	default:
+		return updated.Format(time.RFC1123)
+	}
+}
+
+// humanizeAge describes how long ago something happened in the largest whole unit,
+// e.g. "5 minutes ago", "3 hours ago", "3 days ago", "2 weeks ago" or "4 months ago".
+// Ages under a minute (or in the future, after clock skew) are "just now".
+func humanizeAge(age time.Duration) string {
+	const day = 24 * time.Hour
+
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		return plural(int(age/time.Minute), "minute") + " ago"
+	case age < day:
+		return plural(int(age/time.Hour), "hour") + " ago"
+	case age < 14*day:
+		return plural(int(age/day), "day") + " ago"
+	case age < 60*day:
+		return plural(int(age/(7*day)), "week") + " ago"
+	case age < 365*day:
+		return plural(int(age/(30*day)), "month") + " ago"
+	default:
+		return plural(int(age/(365*day)), "year") + " ago"
+	}
+}
+
+// plural returns n followed by unit, pluralized unless n is 1 (e.g. "1 day", "3 days").
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
@@ -0,0 +1,50 @@
+package tasks
+
+import (
+	"testing"
+	"time"
+	"watchdog/internal/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHumanizeAge(t *testing.T) {
+	const day = 24 * time.Hour
+
+	tests := []struct {
+		age  time.Duration
+		want string
+	}{
+		{-time.Hour, "just now"},
+		{30 * time.Second, "just now"},
+		{time.Minute, "1 minute ago"},
+		{45 * time.Minute, "45 minutes ago"},
+		{time.Hour, "1 hour ago"},
+		{5*time.Hour + 59*time.Minute, "5 hours ago"},
+		{23 * time.Hour, "23 hours ago"},
+		{day, "1 day ago"},
+		{3*day + 12*time.Hour, "3 days ago"},
+		{13 * day, "13 days ago"},
+		{14 * day, "2 weeks ago"},
+		{59 * day, "8 weeks ago"},
+		{60 * day, "2 months ago"},
+		{364 * day, "12 months ago"},
+		{365 * day, "1 year ago"},
+		{800 * day, "2 years ago"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.age.String(), func(t *testing.T) {
+			assert.Equal(t, tt.want, humanizeAge(tt.age))
+		})
+	}
+}
+
+func TestFormatLastUpdated(t *testing.T) {
+	updated := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := updated.Add(3 * 24 * time.Hour)
+
+	assert.Equal(t, "Mon, 01 Jan 2024 12:00:00 UTC", formatLastUpdated(config.LastUpdatedAbsolute, updated, now))
+	assert.Equal(t, "3 days ago", formatLastUpdated(config.LastUpdatedRelative, updated, now))
+	assert.Equal(t, "Mon, 01 Jan 2024 12:00:00 UTC (3 days ago)", formatLastUpdated(config.LastUpdatedBoth, updated, now))
+}
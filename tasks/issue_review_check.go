@@ -0,0 +1,226 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"sync"
+	"time"
+	"watchdog/internal/api"
+	"watchdog/internal/clock"
+	"watchdog/internal/config"
+	"watchdog/internal/metrics"
+	"watchdog/internal/notifier"
+	"watchdog/internal/state"
+
+	"github.com/rs/zerolog/log"
+)
+
+// IssueReviewCheckTask monitors GitHub repositories for stale issues.
+// An issue is considered "stale" if it hasn't been updated in X days (configured via stale_days or stale_duration).
+//
+// The task mirrors PRReviewCheckTask:
+//  1. Fetches open issues from configured repositories (optionally only those assigned to specific users)
+//  2. Skips pull requests, which GitHub's issues endpoint also returns
+//  3. Checks if issues are older than the stale threshold
+//  4. Sends notifications for stale issues (with cooldown to prevent spam)
+//
+// This implements the scheduler.Task interface via the Run() method.
+type IssueReviewCheckTask struct {
+	// config holds the GitHub monitoring configuration (repos, stale days, cooldown, etc.)
+	config config.GitHubConfig
+
+	// apiClient is used to fetch issue data from GitHub
+	apiClient api.GitHubClient
+
+	// notifier is used to send alerts (via Apprise/Telegram/Discord/etc.)
+	notifier notifier.Notifier
+
+	// format is the notification body format ("text", "markdown" or "html")
+	format string
+
+	// lastNotificationTime tracks when we last notified about each issue
+	// Key format: "owner/repo#123"
+	lastNotificationTime map[string]time.Time
+
+	// mu guards access to lastNotificationTime to prevent data races
+	mu sync.Mutex
+
+	// state persists lastNotificationTime across restarts (nil = in-memory only)
+	state state.StateStore
+
+	// orgRepos resolves organization-wide repository entries, caching each organization's repositories
+	orgRepos *orgRepoCache
+
+	// Clock tells the time for cooldowns and staleness checks (nil means the system clock)
+	Clock clock.Clock
+}
+
+// issueStateNamespace is the key issue cooldowns are stored under in the state file.
+const issueStateNamespace = "github_issues"
+
+// NewIssueReviewCheckTask creates a new issue monitoring task.
+// Parameters:
+//   - cfg: GitHub configuration (repos to monitor, stale threshold, etc.)
+//   - notifier: Where to send notifications (Apprise webhook, Telegram, etc.)
+//   - format: Notification body format ("text", "markdown" or "html"); empty means "text"
+func NewIssueReviewCheckTask(cfg config.GitHubConfig, notifier notifier.Notifier, format string) *IssueReviewCheckTask {
+	return &IssueReviewCheckTask{
+		config:               withNormalizedRepositories(cfg),
+		apiClient:            NewGitHubClient(cfg),
+		notifier:             notifier,
+		format:               format,
+		lastNotificationTime: make(map[string]time.Time),
+		orgRepos:             newOrgRepoCache(cfg.GetOrgReposCacheTTL(), cfg.IncludeArchived),
+		Clock:                clock.Real{},
+	}
+}
+
+// Name identifies the task in logs and status reports.
+func (t *IssueReviewCheckTask) Name() string {
+	return "github-issue-review"
+}
+
+// LoadState restores cooldowns saved by a previous process from store, and saves
+// them back to it after every run. A nil store keeps cooldowns in memory only.
+func (t *IssueReviewCheckTask) LoadState(store state.StateStore) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.state = store
+	if store == nil {
+		return
+	}
+	for issueID, lastTime := range store.Load(issueStateNamespace) {
+		t.lastNotificationTime[issueID] = lastTime
+	}
+}
+
+// Run executes the issue monitoring logic.
+//
+// Returns:
+//   - Always returns nil (errors are logged but don't stop the scheduler)
+//   - Individual repo/issue failures are logged and skipped
+func (t *IssueReviewCheckTask) Run(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+	ctx = notifier.WithTags(ctx, t.config.Tags...)
+
+	defer metrics.ObserveTaskRun(t.Name(), time.Now())
+
+	staleThreshold := t.config.GetStaleThreshold()
+
+	repos, err := t.orgRepos.expand(ctx, t.apiClient, t.config.Repositories, clock.Now(t.Clock))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to discover organization repositories")
+	}
+
+	for _, repoConfig := range repos {
+		issues, err := t.fetchIssues(ctx, repoConfig)
+		if err != nil {
+			log.Error().
+				Err(err).
+				Str("owner", repoConfig.Owner).
+				Str("repo", repoConfig.Repo).
+				Msg("Failed to fetch issues")
+			continue
+		}
+
+		for _, issue := range issues {
+			// The issues endpoint includes PRs; those are handled by PRReviewCheckTask
+			if issue.IsPullRequest() {
+				continue
+			}
+
+			if clock.Since(t.Clock, issue.UpdatedAt) < staleThreshold {
+				continue // Issue is still fresh, skip it
+			}
+
+			issueID := fmt.Sprintf("%s/%s#%d", repoConfig.Owner, repoConfig.Repo, issue.Number)
+
+			t.mu.Lock()
+			lastTime, ok := t.lastNotificationTime[issueID]
+			t.mu.Unlock()
+
+			if ok && clock.Since(t.Clock, lastTime) < t.config.GetNotificationCooldown() {
+				continue // We notified about this issue recently, skip it
+			}
+
+			subject := fmt.Sprintf("Stale issue: %s", issue.Title)
+			message := t.formatStaleMessage(repoConfig, issue)
+
+			log.Info().Str("issue", issueID).Msg("Sending notification for stale issue")
+			err = t.notifier.SendNotification(notifier.WithSeverity(ctx, notifier.SeverityWarning), subject, message)
+			if err != nil {
+				log.Error().Err(err).Str("issue", issueID).Msg("Failed to send notification")
+			} else {
+				t.mu.Lock()
+				t.lastNotificationTime[issueID] = clock.Now(t.Clock)
+				t.mu.Unlock()
+			}
+		}
+	}
+
+	t.mu.Lock()
+	cleanupNotificationTimes(t.lastNotificationTime, t.config.GetNotificationCooldown(), clock.Now(t.Clock))
+	saveNotificationTimes(t.state, issueStateNamespace, t.lastNotificationTime)
+	t.mu.Unlock()
+
+	return nil
+}
+
+// fetchIssues returns the open issues for a repository.
+// With no assignees configured, all open issues are returned. Otherwise issues are
+// fetched per assignee and de-duplicated, since an issue may have several of them.
+func (t *IssueReviewCheckTask) fetchIssues(ctx context.Context, repoConfig config.RepositoryConfig) ([]api.Issue, error) {
+	if len(repoConfig.Assignees) == 0 {
+		return t.apiClient.GetOpenIssues(ctx, repoConfig.Owner, repoConfig.Repo, "")
+	}
+
+	var issues []api.Issue
+	seen := make(map[int]bool)
+	for _, assignee := range repoConfig.Assignees {
+		assigned, err := t.apiClient.GetOpenIssues(ctx, repoConfig.Owner, repoConfig.Repo, assignee)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range assigned {
+			if seen[issue.Number] {
+				continue
+			}
+			seen[issue.Number] = true
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
+
+// formatStaleMessage builds the notification body for a stale issue in the configured format.
+func (t *IssueReviewCheckTask) formatStaleMessage(repoConfig config.RepositoryConfig, issue api.Issue) string {
+	updated := formatLastUpdated(t.config.GetLastUpdatedFormat(), issue.UpdatedAt, clock.Now(t.Clock))
+
+	assignees := make([]string, 0, len(issue.Assignees))
+	for _, assignee := range issue.Assignees {
+		assignees = append(assignees, assignee.Login)
+	}
+	assigned := "unassigned"
+	if len(assignees) > 0 {
+		assigned = "assigned to " + strings.Join(assignees, ", ")
+	}
+
+	switch t.format {
+	case notifier.FormatMarkdown:
+		return fmt.Sprintf("**Issue #%d** in %s/%s (%s) has had no activity.\n**Last updated:** %s\n**Link:** [%s](%s)",
+			issue.Number, repoConfig.Owner, repoConfig.Repo, assigned,
+			updated, issue.HTMLURL, issue.HTMLURL)
+	case notifier.FormatHTML:
+		return fmt.Sprintf("<b>Issue #%d</b> in %s/%s (%s) has had no activity.<br>\n<b>Last updated:</b> %s<br>\n<b>Link:</b> <a href=\"%s\">%s</a>",
+			issue.Number, html.EscapeString(repoConfig.Owner), html.EscapeString(repoConfig.Repo), html.EscapeString(assigned),
+			updated, html.EscapeString(issue.HTMLURL), html.EscapeString(issue.HTMLURL))
+	default:
+		return fmt.Sprintf("Issue #%d in %s/%s (%s) has had no activity.\nLast updated: %s\nLink: %s",
+			issue.Number, repoConfig.Owner, repoConfig.Repo, assigned,
+			updated, issue.HTMLURL)
+	}
+}
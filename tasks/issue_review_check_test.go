@@ -0,0 +1,179 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"watchdog/internal/api"
+	"watchdog/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewIssueReviewCheckTask(t *testing.T) {
+	task := NewIssueReviewCheckTask(config.GitHubConfig{}, &MockNotifier{}, "")
+
+	assert.NotNil(t, task)
+	assert.NotNil(t, task.apiClient)
+	assert.Empty(t, task.lastNotificationTime)
+}
+
+func TestIssueReviewCheckTask_Run_SkipsPullRequests(t *testing.T) {
+	cfg := config.GitHubConfig{
+		StaleDays: 4,
+		Repositories: []config.RepositoryConfig{
+			{Owner: "testowner", Repo: "testrepo"},
+		},
+	}
+
+	staleIssue := api.Issue{
+		Number:    10,
+		Title:     "Crash on startup",
+		UpdatedAt: time.Now().Add(-5 * 24 * time.Hour),
+		HTMLURL:   "https://github.com/testowner/testrepo/issues/10",
+	}
+	stalePR := api.Issue{
+		Number:      11,
+		Title:       "Fix crash",
+		UpdatedAt:   time.Now().Add(-5 * 24 * time.Hour),
+		PullRequest: &api.IssuePullRequest{URL: "https://api.github.com/repos/testowner/testrepo/pulls/11"},
+	}
+
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOpenIssues", mock.Anything, "testowner", "testrepo", "").Return([]api.Issue{staleIssue, stalePR}, nil)
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "Stale issue: Crash on startup", mock.MatchedBy(func(msg string) bool {
+		return assert.Contains(t, msg, "Issue #10 in testowner/testrepo (unassigned)") &&
+			assert.Contains(t, msg, "https://github.com/testowner/testrepo/issues/10")
+	})).Return(nil).Once()
+
+	task := NewIssueReviewCheckTask(cfg, mockNotifier, "")
+	task.apiClient = mockAPI
+
+	require.NoError(t, task.Run(context.Background()))
+	mockAPI.AssertExpectations(t)
+	mockNotifier.AssertExpectations(t)
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 1)
+}
+
+func TestIssueReviewCheckTask_Run_AssigneeFilter(t *testing.T) {
+	cfg := config.GitHubConfig{
+		StaleDays: 4,
+		Repositories: []config.RepositoryConfig{
+			{Owner: "testowner", Repo: "testrepo", Assignees: []string{"alice", "bob"}},
+		},
+	}
+
+	shared := api.Issue{
+		Number:    1,
+		Title:     "Shared issue",
+		Assignees: []api.User{{Login: "alice"}, {Login: "bob"}},
+		UpdatedAt: time.Now().Add(-5 * 24 * time.Hour),
+	}
+	bobsIssue := api.Issue{
+		Number:    2,
+		Title:     "Bob's issue",
+		Assignees: []api.User{{Login: "bob"}},
+		UpdatedAt: time.Now().Add(-5 * 24 * time.Hour),
+	}
+
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOpenIssues", mock.Anything, "testowner", "testrepo", "alice").Return([]api.Issue{shared}, nil)
+	mockAPI.On("GetOpenIssues", mock.Anything, "testowner", "testrepo", "bob").Return([]api.Issue{shared, bobsIssue}, nil)
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "Stale issue: Shared issue", mock.MatchedBy(func(msg string) bool {
+		return strings.Contains(msg, "assigned to alice, bob")
+	})).Return(nil).Once()
+	mockNotifier.On("SendNotification", mock.Anything, "Stale issue: Bob's issue", mock.Anything).Return(nil).Once()
+
+	task := NewIssueReviewCheckTask(cfg, mockNotifier, "")
+	task.apiClient = mockAPI
+
+	require.NoError(t, task.Run(context.Background()))
+	mockAPI.AssertExpectations(t)
+	mockNotifier.AssertExpectations(t)
+}
+
+func TestIssueReviewCheckTask_Run_Staleness(t *testing.T) {
+	cfg := config.GitHubConfig{
+		StaleDays: 4,
+		Repositories: []config.RepositoryConfig{
+			{Owner: "testowner", Repo: "testrepo"},
+		},
+	}
+
+	fresh := api.Issue{Number: 1, Title: "Fresh", UpdatedAt: time.Now().Add(-1 * 24 * time.Hour)}
+	stale := api.Issue{Number: 2, Title: "Stale", UpdatedAt: time.Now().Add(-10 * 24 * time.Hour)}
+
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOpenIssues", mock.Anything, "testowner", "testrepo", "").Return([]api.Issue{fresh, stale}, nil)
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "Stale issue: Stale", mock.Anything).Return(nil).Once()
+
+	task := NewIssueReviewCheckTask(cfg, mockNotifier, "")
+	task.apiClient = mockAPI
+
+	require.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertExpectations(t)
+	assert.Contains(t, task.lastNotificationTime, "testowner/testrepo#2")
+	assert.NotContains(t, task.lastNotificationTime, "testowner/testrepo#1")
+}
+
+func TestIssueReviewCheckTask_Run_RespectsCooldown(t *testing.T) {
+	cfg := config.GitHubConfig{
+		StaleDays:            4,
+		NotificationCooldown: "24h",
+		Repositories: []config.RepositoryConfig{
+			{Owner: "testowner", Repo: "testrepo"},
+		},
+	}
+
+	stale := api.Issue{Number: 2, Title: "Stale", UpdatedAt: time.Now().Add(-10 * 24 * time.Hour)}
+
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOpenIssues", mock.Anything, "testowner", "testrepo", "").Return([]api.Issue{stale}, nil)
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "Stale issue: Stale", mock.Anything).Return(nil).Once()
+
+	task := NewIssueReviewCheckTask(cfg, mockNotifier, "")
+	task.apiClient = mockAPI
+
+	require.NoError(t, task.Run(context.Background()))
+	require.NoError(t, task.Run(context.Background()))
+
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 1)
+}
+
+func TestIssueReviewCheckTask_Run_APIError_ContinuesWithOtherRepos(t *testing.T) {
+	cfg := config.GitHubConfig{
+		StaleDays: 4,
+		Repositories: []config.RepositoryConfig{
+			{Owner: "owner1", Repo: "repo1"},
+			{Owner: "owner2", Repo: "repo2"},
+		},
+	}
+
+	stale := api.Issue{Number: 3, Title: "Stale", UpdatedAt: time.Now().Add(-10 * 24 * time.Hour)}
+
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOpenIssues", mock.Anything, "owner1", "repo1", "").Return(nil, errors.New("api error"))
+	mockAPI.On("GetOpenIssues", mock.Anything, "owner2", "repo2", "").Return([]api.Issue{stale}, nil)
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "Stale issue: Stale", mock.Anything).Return(nil).Once()
+
+	task := NewIssueReviewCheckTask(cfg, mockNotifier, "")
+	task.apiClient = mockAPI
+
+	require.NoError(t, task.Run(context.Background()))
+	mockAPI.AssertExpectations(t)
+	mockNotifier.AssertExpectations(t)
+}
@@ -0,0 +1,117 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"watchdog/internal/api"
+	"watchdog/internal/config"
+)
+
+// orgRepoCache resolves organization-wide repository entries (repo "*") into the
+// organization's active repositories, reusing each organization's list for ttl.
+// Disabled repositories are always skipped; archived ones unless includeArchived is set.
+// A nil *orgRepoCache resolves without caching, skipping archived repositories.
+type orgRepoCache struct {
+	ttl             time.Duration
+	includeArchived bool
+
+	mu      sync.Mutex
+	entries map[string]orgRepoEntry
+}
+
+// orgRepoEntry is the repository list of one organization and when it was fetched.
+type orgRepoEntry struct {
+	names   []string
+	fetched time.Time
+}
+
+// newOrgRepoCache creates a cache that keeps organization repository lists for ttl.
+func newOrgRepoCache(ttl time.Duration, includeArchived bool) *orgRepoCache {
+	return &orgRepoCache{ttl: ttl, includeArchived: includeArchived, entries: make(map[string]orgRepoEntry)}
+}
+
+// ExpandRepositories replaces each organization-wide entry in repos with one entry per
+// active repository of that organization, without caching. See orgRepoCache.expand.
+func ExpandRepositories(ctx context.Context, client api.GitHubClient, repos []config.RepositoryConfig, includeArchived bool) ([]config.RepositoryConfig, error) {
+	c := newOrgRepoCache(0, includeArchived)
+	return c.expand(ctx, client, repos, time.Now())
+}
+
+// expand replaces each organization-wide entry in repos with one entry per active
+// repository of that organization, carrying over the entry's filters. Repositories that
+// are also listed explicitly keep their explicit entry, so they aren't checked twice.
+//
+// Organizations whose repositories can't be listed are skipped; the returned error
+// describes them, alongside the repositories that could be resolved.
+func (c *orgRepoCache) expand(ctx context.Context, client api.GitHubClient, repos []config.RepositoryConfig, now time.Time) ([]config.RepositoryConfig, error) {
+	explicit := make(map[string]bool)
+	for _, repo := range repos {
+		if !repo.IsOrgWide() {
+			explicit[strings.ToLower(repo.Owner+"/"+repo.Repo)] = true
+		}
+	}
+
+	var expanded []config.RepositoryConfig
+	var errs []error
+	for _, repo := range repos {
+		if !repo.IsOrgWide() {
+			expanded = append(expanded, repo)
+			continue
+		}
+
+		names, err := c.repositories(ctx, client, repo.Owner, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list repositories of %s: %v", repo.Owner, err))
+			continue
+		}
+		for _, name := range names {
+			if explicit[strings.ToLower(repo.Owner+"/"+name)] {
+				continue
+			}
+			discovered := repo
+			discovered.Repo = name
+			expanded = append(expanded, discovered)
+		}
+	}
+	return expanded, errors.Join(errs...)
+}
+
+// repositories returns the sorted names of the active repositories of org,
+// from the cache if they were fetched less than ttl ago.
+func (c *orgRepoCache) repositories(ctx context.Context, client api.GitHubClient, org string, now time.Time) ([]string, error) {
+	key := strings.ToLower(org)
+	if c != nil {
+		c.mu.Lock()
+		entry, ok := c.entries[key]
+		c.mu.Unlock()
+		if ok && now.Sub(entry.fetched) < c.ttl {
+			return entry.names, nil
+		}
+	}
+
+	repos, err := client.GetOrgRepositories(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(repos))
+	includeArchived := c != nil && c.includeArchived
+	for _, repo := range repos {
+		if repo.Disabled || (repo.Archived && !includeArchived) {
+			continue
+		}
+		names = append(names, repo.Name)
+	}
+	sort.Strings(names)
+
+	if c != nil {
+		c.mu.Lock()
+		c.entries[key] = orgRepoEntry{names: names, fetched: now}
+		c.mu.Unlock()
+	}
+	return names, nil
+}
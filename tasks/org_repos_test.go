@@ -0,0 +1,142 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"watchdog/internal/api"
+	"watchdog/internal/clock"
+	"watchdog/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPRReviewCheckTask_Run_OrgWideRepositories(t *testing.T) {
+	cfg := config.GitHubConfig{
+		StaleDays:        4,
+		OrgReposCacheTTL: "1h",
+		Repositories: []config.RepositoryConfig{
+			{Owner: "acme", Repo: "*", Authors: []string{"alice"}},
+			// Explicit entries take precedence over the org-wide one
+			{Owner: "acme", Repo: "api"},
+		},
+	}
+
+	stale := func(number int, author string) api.PullRequest {
+		return api.PullRequest{Number: number, Title: "PR", User: api.User{Login: author},
+			UpdatedAt: time.Now().Add(-5 * 24 * time.Hour), Head: api.PRHead{SHA: "sha"}}
+	}
+
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOrgRepositories", mock.Anything, "acme").Return([]api.Repository{
+		{Name: "web"}, {Name: "api"}, {Name: "legacy", Archived: true},
+	}, nil).Once()
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "acme", "api").Return([]api.PullRequest{stale(1, "bob")}, nil)
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "acme", "web").Return([]api.PullRequest{stale(2, "alice"), stale(3, "bob")}, nil)
+	mockAPI.On("GetCommitStatus", mock.Anything, "acme", mock.Anything, "sha").Return(&api.CommitStatus{State: "success"}, nil)
+	mockAPI.On("GetCheckSuites", mock.Anything, "acme", mock.Anything, "sha").Return(&api.CheckSuitesResponse{}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "acme", mock.Anything, mock.Anything).Return([]api.Review{}, nil)
+
+	var alerted []string
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "Stale PR: PR", mock.Anything).
+		Run(func(args mock.Arguments) { alerted = append(alerted, args.String(2)) }).
+		Return(nil)
+
+	fake := clock.NewFake(time.Now())
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+	task.apiClient = mockAPI
+	task.Clock = fake
+
+	require.NoError(t, task.Run(context.Background()))
+
+	// acme/api (explicit, no author filter) and alice's PR in the discovered acme/web
+	require.Len(t, alerted, 2)
+	assert.ElementsMatch(t, []string{"acme/api#1", "acme/web#2"}, notifiedIDs(task))
+	mockAPI.AssertNotCalled(t, "GetOpenPullRequests", mock.Anything, "acme", "legacy")
+
+	// The organization's repositories are cached for the TTL
+	fake.Advance(30 * time.Minute)
+	require.NoError(t, task.Run(context.Background()))
+	mockAPI.AssertNumberOfCalls(t, "GetOrgRepositories", 1)
+
+	mockAPI.On("GetOrgRepositories", mock.Anything, "acme").Return([]api.Repository{{Name: "web"}}, nil).Once()
+	fake.Advance(time.Hour)
+	require.NoError(t, task.Run(context.Background()))
+	mockAPI.AssertNumberOfCalls(t, "GetOrgRepositories", 2)
+}
+
+// notifiedIDs returns the IDs of the PRs the task is keeping a cooldown for.
+func notifiedIDs(task *PRReviewCheckTask) []string {
+	task.mu.Lock()
+	defer task.mu.Unlock()
+
+	var ids []string
+	for id := range task.lastNotificationTime {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestExpandRepositories_SkipsUnlistableOrganizations(t *testing.T) {
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOrgRepositories", mock.Anything, "ghost").Return(nil, errors.New("404 Not Found"))
+
+	repos, err := ExpandRepositories(context.Background(), mockAPI, []config.RepositoryConfig{
+		{Owner: "ghost"},
+		{Owner: "acme", Repo: "api"},
+	}, false)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to list repositories of ghost")
+	assert.Equal(t, []config.RepositoryConfig{{Owner: "acme", Repo: "api"}}, repos)
+}
+
+func TestPRReviewCheckTask_Run_UnlistableOrganizationFailsRun(t *testing.T) {
+	cfg := config.GitHubConfig{
+		StaleDays:    4,
+		Repositories: []config.RepositoryConfig{{Owner: "ghost"}},
+	}
+
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOrgRepositories", mock.Anything, "ghost").Return(nil, errors.New("connection refused"))
+
+	task := NewPRReviewCheckTask(cfg, &MockNotifier{}, "")
+	task.apiClient = mockAPI
+
+	// Nothing could be checked, so the run fails
+	assert.ErrorContains(t, task.Run(context.Background()), "failed to list repositories of ghost")
+}
+
+func TestExpandRepositories_ArchivedAndDisabled(t *testing.T) {
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOrgRepositories", mock.Anything, "acme").Return([]api.Repository{
+		{Name: "web"},
+		{Name: "legacy", Archived: true},
+		{Name: "api"},
+		{Name: "locked", Disabled: true},
+		{Name: "frozen", Archived: true, Disabled: true},
+	}, nil)
+	orgWide := []config.RepositoryConfig{{Owner: "acme", Repo: "*"}}
+
+	names := func(repos []config.RepositoryConfig) []string {
+		var out []string
+		for _, repo := range repos {
+			out = append(out, repo.Repo)
+		}
+		return out
+	}
+
+	// Archived repositories are excluded by default
+	repos, err := ExpandRepositories(context.Background(), mockAPI, orgWide, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api", "web"}, names(repos))
+
+	// include_archived brings them back, but disabled repositories stay excluded
+	repos, err = ExpandRepositories(context.Background(), mockAPI, orgWide, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api", "legacy", "web"}, names(repos))
+}
@@ -2,19 +2,25 @@ package tasks
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"html"
 	"strings"
 	"sync"
+	"text/template"
 	"time"
 	"watchdog/internal/api"
+	"watchdog/internal/clock"
 	"watchdog/internal/config"
+	"watchdog/internal/metrics"
 	"watchdog/internal/notifier"
+	"watchdog/internal/state"
 
 	"github.com/rs/zerolog/log"
 )
 
 // PRReviewCheckTask monitors GitHub repositories for stale pull requests.
-// A PR is considered "stale" if it hasn't been updated in X days (configured via stale_days).
+// A PR is considered "stale" if it hasn't been updated in X days (configured via stale_days or stale_duration).
 //
 // The task:
 //  1. Fetches all open PRs from configured repositories
@@ -33,195 +39,1167 @@ type PRReviewCheckTask struct {
 	// notifier is used to send alerts (via Apprise/Telegram/Discord/etc.)
 	notifier notifier.Notifier
 
+	// format is the notification body format ("text", "markdown" or "html")
+	// It must match the format the notifier sends to Apprise
+	format string
+
 	// lastNotificationTime tracks when we last notified about each PR
 	// Key format: "owner/repo#123" (e.g., "signoz/signoz-web#456")
 	// This prevents spamming notifications for the same PR
 	lastNotificationTime map[string]time.Time
 
-	// mu guards access to lastNotificationTime to prevent data races
+	// lastNotifiedUpdate is each PR's UpdatedAt when we last notified about it, for
+	// reset_cooldown_on_update. Same keys as lastNotificationTime.
+	lastNotifiedUpdate map[string]time.Time
+
+	// mu guards access to lastNotificationTime, lastNotifiedUpdate, sentThisRun and queued to prevent data races
 	mu sync.Mutex
+
+	// sentThisRun counts the stale PR notifications delivered during the current run,
+	// for max_notifications_per_run
+	sentThisRun int
+
+	// queued holds the notifications held back outside business hours per repository
+	// ("owner/repo") with business_hours mode "queue", until its business hours start
+	queued map[string][]queuedPRNotification
+
+	// state persists lastNotificationTime and lastNotifiedUpdate across restarts (nil = in-memory only)
+	state state.StateStore
+
+	// templates customize the notification subject and body (nil templates use the default format)
+	templates PRTemplates
+
+	// orgRepos resolves organization-wide repository entries, caching each organization's repositories
+	orgRepos *orgRepoCache
+
+	// requestedFor is the login only_requested_for resolved to ("@me" needs an API call,
+	// so it's resolved on the first run). Only accessed from Run, which never runs concurrently.
+	requestedFor string
+
+	// Clock tells the time for cooldowns and staleness checks (nil means the system clock)
+	Clock clock.Clock
 }
 
+// prStateNamespace is the key PR cooldowns are stored under in the state file.
+const prStateNamespace = "github_prs"
+
+// prUpdatedStateNamespace is the key the UpdatedAt of notified PRs is stored under in the state file.
+const prUpdatedStateNamespace = "github_prs_updated"
+
 // NewPRReviewCheckTask creates a new PR monitoring task.
 // Parameters:
 //   - cfg: GitHub configuration (repos to monitor, stale threshold, etc.)
 //   - notifier: Where to send notifications (Apprise webhook, Telegram, etc.)
+//   - format: Notification body format ("text", "markdown" or "html"); empty means "text"
 //
 // The task will use the GitHub token from cfg for API authentication (if provided).
-func NewPRReviewCheckTask(cfg config.GitHubConfig, notifier notifier.Notifier) *PRReviewCheckTask {
+func NewPRReviewCheckTask(cfg config.GitHubConfig, notifier notifier.Notifier, format string) *PRReviewCheckTask {
+	// validateConfig has already rejected invalid templates; fall back to the default format just in case
+	templates, err := ParsePRTemplates(cfg)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid notification template, using the default format")
+	}
+
 	return &PRReviewCheckTask{
-		config:               cfg,
-		apiClient:            api.NewGitHubAPI(cfg.Token),
+		templates:            templates,
+		config:               withNormalizedRepositories(cfg),
+		apiClient:            NewGitHubClient(cfg),
 		notifier:             notifier,
+		format:               format,
 		lastNotificationTime: make(map[string]time.Time),
+		lastNotifiedUpdate:   make(map[string]time.Time),
+		queued:               make(map[string][]queuedPRNotification),
+		orgRepos:             newOrgRepoCache(cfg.GetOrgReposCacheTTL(), cfg.IncludeArchived),
+		Clock:                clock.Real{},
+	}
+}
+
+// Name identifies the task in logs and status reports.
+func (t *PRReviewCheckTask) Name() string {
+	return "github-pr-review"
+}
+
+// NewGitHubClient creates the GitHub API client for the given config,
+// applying the configured retry count for transient failures and, when a GitHub
+// App is configured, authenticating with its installation tokens. With api "graphql"
+// it fetches PRs through the GraphQL API instead.
+func NewGitHubClient(cfg config.GitHubConfig) api.GitHubClient {
+	client := api.NewGitHubAPI(cfg.Token)
+	retry := api.DefaultRetryConfig
+	retry.MaxRetries = cfg.GetMaxRetries()
+	client.RetryConfig = &retry
+	client.Timeout = cfg.GetTimeout()
+
+	if app := cfg.App; app.IsConfigured() {
+		key, err := api.LoadPrivateKey(app.PrivateKeyPath)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to load GitHub App private key, falling back to token")
+		} else {
+			client.TokenSource = api.NewGitHubAppTokenSource(app.AppID, app.InstallationID, key)
+		}
+	}
+
+	if cfg.GetAPI() == config.GitHubAPIGraphQL {
+		return api.NewGitHubGraphQLAPI(client)
+	}
+	return client
+}
+
+// withNormalizedRepositories returns cfg with its repositories lowercased, de-duplicated
+// and sorted (see GitHubConfig.GetRepositories), so each repository is checked only once.
+func withNormalizedRepositories(cfg config.GitHubConfig) config.GitHubConfig {
+	repos, err := cfg.GetRepositories()
+	if err != nil {
+		// validateConfig has already rejected conflicting duplicates; keep the list as configured just in case
+		log.Error().Err(err).Msg("Invalid repository list, using it as configured")
+		return cfg
+	}
+	cfg.Repositories = repos
+	return cfg
+}
+
+// LoadState restores cooldowns saved by a previous process from store, and saves
+// them back to it after every run. A nil store keeps cooldowns in memory only.
+func (t *PRReviewCheckTask) LoadState(store state.StateStore) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.state = store
+	if store == nil {
+		return
+	}
+	for prID, lastTime := range store.Load(prStateNamespace) {
+		t.lastNotificationTime[prID] = lastTime
+	}
+	for prID, updatedAt := range store.Load(prUpdatedStateNamespace) {
+		t.lastNotifiedUpdate[prID] = updatedAt
 	}
 }
 
 // Run executes the PR monitoring logic.
 // This method is called periodically by the scheduler (e.g., every 5 minutes).
 //
+// Repositories are checked in parallel (up to the configured concurrency).
+// Organization-wide entries are expanded into the organization's non-archived repositories.
 // For each configured repository, it:
 //  1. Fetches all open PRs from GitHub
-//  2. Filters out draft PRs (not ready for review)
-//  3. Filters by author if configured (only watch specific team members)
-//  4. Checks if the PR is stale (not updated in X days)
-//  5. Sends a notification if stale (respecting cooldown period)
+//  2. Filters out draft PRs (not ready for review), unless monitor_drafts is set, and PRs
+//     opened by bots if ignore_bots is set
+//  3. Filters by author, assignee and labels if configured (only watch specific team members/labels)
+//  4. Checks if the PR is stale (not updated, or opened, in X days depending on stale_metric)
+//  5. Sends a notification if stale (respecting cooldown period), or with notify_mode "digest",
+//     one notification per repository listing all its stale PRs
+//  6. Forgets alerted PRs that are no longer open, notifying about them if notify_on_resolve is set
 //
 // Returns:
-//   - Always returns nil (errors are logged but don't stop the scheduler)
-//   - Individual repo/PR failures are logged and skipped
-func (t *PRReviewCheckTask) Run() error {
-	// Create a context with a reasonable timeout for the entire task
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+//   - An error if GitHub couldn't be queried at all: no repository's PRs could be fetched
+//     (other than for a used-up request budget), the organizations couldn't be listed, or
+//     only_requested_for couldn't be resolved. This lets the scheduler's failure alerts and
+//     backoff, and the readiness probe, notice that GitHub is unreachable.
+//   - Otherwise nil; individual repo/PR failures are logged and skipped
+func (t *PRReviewCheckTask) Run(ctx context.Context) error {
+	// Bound the entire run with a reasonable timeout
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
 	defer cancel()
+	ctx = notifier.WithTags(ctx, t.config.Tags...)
 
-	staleDays := t.config.GetStaleDays()
+	defer metrics.ObserveTaskRun(t.Name(), time.Now())
 
-	// Iterate through all configured repositories
-	for _, repoConfig := range t.config.Repositories {
-		// Fetch open PRs from GitHub (now with pagination for all PRs)
-		prs, err := t.apiClient.GetOpenPullRequests(ctx, repoConfig.Owner, repoConfig.Repo)
-		if err != nil {
-			// Log the error but continue with other repos
-			log.Error().
-				Err(err).
-				Str("owner", repoConfig.Owner).
-				Str("repo", repoConfig.Repo).
-				Msg("Failed to fetch PRs")
+	t.mu.Lock()
+	t.sentThisRun = 0
+	t.mu.Unlock()
+
+	opts := NewClassifyOptions(t.config)
+	if t.config.OnlyRequestedFor != "" {
+		if t.requestedFor == "" {
+			login, err := ResolveRequestedFor(ctx, t.apiClient, t.config.OnlyRequestedFor)
+			if err != nil {
+				// Without the filter every stale PR would be alerted on, so skip this run
+				return fmt.Errorf("skipping PR review check: %v", err)
+			}
+			t.requestedFor = login
+		}
+		opts.RequestedFor = t.requestedFor
+	}
+
+	repos, err := t.orgRepos.expand(ctx, t.apiClient, t.config.Repositories, clock.Now(t.Clock))
+	if err != nil {
+		if len(repos) == 0 {
+			return err
+		}
+		// Organizations that can't be listed are skipped for this run
+		log.Error().Err(err).Msg("Failed to discover organization repositories")
+	}
+
+	// Check repositories in parallel, bounded by the configured concurrency
+	// A failing repository is logged and doesn't affect the others
+	sem := make(chan struct{}, t.config.GetConcurrency())
+	var wg sync.WaitGroup
+	errs := make([]error, len(repos))
+	staleCounts := make([]int, len(repos))
+	for i, repoConfig := range repos {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, repoConfig config.RepositoryConfig) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			staleCounts[i], errs[i] = t.checkRepository(ctx, repoConfig, opts)
+		}(i, repoConfig)
+	}
+	wg.Wait()
+
+	// Export how many PRs are currently stale in each checked repo (including ones in cooldown).
+	// Repos that are no longer checked (removed, archived) or whose PRs couldn't be fetched
+	// are dropped rather than keep reporting their last count.
+	metrics.StalePRs.Reset()
+	for i, repoConfig := range repos {
+		if errs[i] == nil {
+			metrics.StalePRs.WithLabelValues(repoConfig.Owner + "/" + repoConfig.Repo).Set(float64(staleCounts[i]))
+		}
+	}
+
+	// Cleanup old entries from lastNotificationTime map to prevent memory leak
+	t.mu.Lock()
+	cleanupNotificationTimes(t.lastNotificationTime, t.config.GetNotificationCooldown(), clock.Now(t.Clock))
+	for prID := range t.lastNotifiedUpdate {
+		if _, ok := t.lastNotificationTime[prID]; !ok {
+			delete(t.lastNotifiedUpdate, prID)
+		}
+	}
+	saveNotificationTimes(t.state, prStateNamespace, t.withoutQueued(t.lastNotificationTime))
+	saveNotificationTimes(t.state, prUpdatedStateNamespace, t.withoutQueued(t.lastNotifiedUpdate))
+	t.mu.Unlock()
+
+	return fetchFailure(errs)
+}
+
+// fetchFailure returns an error if none of the repositories could be checked, given the
+// error checking each one returned, so a GitHub outage fails the run. Repositories skipped
+// for a used-up request budget don't count as failures, since the budget is deliberate.
+// It returns nil if at least one repository was checked.
+func fetchFailure(errs []error) error {
+	var first error
+	for _, err := range errs {
+		if err == nil {
+			return nil
+		}
+		var budget *api.BudgetExceededError
+		if first == nil && !errors.As(err, &budget) {
+			first = err
+		}
+	}
+	if first == nil {
+		return nil
+	}
+	return fmt.Errorf("failed to fetch PRs of all %d repositories: %v", len(errs), first)
+}
+
+// logFetchError logs why the PRs of a repository couldn't be fetched, calling out a
+// repository that is gone and a rate limit or used-up request budget, which need different
+// fixes than other failures.
+func logFetchError(err error, repoConfig config.RepositoryConfig) {
+	var rateLimit *api.RateLimitError
+	var budget *api.BudgetExceededError
+	switch {
+	case errors.As(err, &budget):
+		log.Warn().
+			Str("owner", repoConfig.Owner).
+			Str("repo", repoConfig.Repo).
+			Time("reset", budget.Reset).
+			Msg("API request budget used up, skipping repository until the budget resets")
+	case errors.As(err, &rateLimit):
+		event := log.Warn().
+			Str("owner", repoConfig.Owner).
+			Str("repo", repoConfig.Repo)
+		if !rateLimit.Reset.IsZero() {
+			event = event.Time("reset", rateLimit.Reset)
+		}
+		event.Msg("GitHub rate limit exceeded, skipping repository until the next run")
+	case errors.Is(err, api.ErrNotFound):
+		log.Error().
+			Str("owner", repoConfig.Owner).
+			Str("repo", repoConfig.Repo).
+			Msg("Repository not found; it may have been deleted or renamed, or the token can't access it")
+	default:
+		log.Error().
+			Err(err).
+			Str("owner", repoConfig.Owner).
+			Str("repo", repoConfig.Repo).
+			Msg("Failed to fetch PRs")
+	}
+}
+
+// checkRepository fetches the open PRs of one repository and notifies about stale ones,
+// returning how many PRs are stale. Errors are logged; the returned error is the one
+// fetching the PRs failed with, if any.
+// It is safe to call concurrently for different repositories.
+func (t *PRReviewCheckTask) checkRepository(ctx context.Context, repoConfig config.RepositoryConfig, opts ClassifyOptions) (int, error) {
+	// Fetch open PRs from GitHub (now with pagination for all PRs)
+	prs, err := t.apiClient.GetOpenPullRequests(ctx, repoConfig.Owner, repoConfig.Repo)
+	if err != nil {
+		// Log the error but continue with other repos
+		logFetchError(err, repoConfig)
+		return 0, err
+	}
+
+	t.resolveClosedPRs(ctx, repoConfig, prs)
+
+	repoID := fmt.Sprintf("%s/%s", repoConfig.Owner, repoConfig.Repo)
+
+	// Outside the repository's business hours, notifications are dropped or queued
+	open := t.inBusinessHours(repoConfig)
+	dropping := !open && repoConfig.BusinessHours.GetMode() == config.BusinessHoursDrop
+	if open {
+		t.flushQueued(ctx, repoID)
+	}
+
+	// In digest mode the cooldown applies to the repository as a whole
+	digest := t.config.GetNotifyMode() == config.NotifyModeDigest
+	digestDue := digest && !dropping && !t.inCooldown(repoID)
+	var digestPRs []digestPR
+
+	// Check each PR for staleness
+	staleCount := 0
+	for _, pr := range prs {
+		// Skip PRs the repository isn't watching (drafts, other authors, filtered labels,
+		// PRs not waiting on only_requested_for),
+		// PRs still within the grace period and PRs that are still fresh
+		if ClassifyPR(pr, repoConfig, opts, clock.Now(t.Clock)) != PRStale {
 			continue
 		}
+		staleCount++
 
-		// Check each PR for staleness
-		for _, pr := range prs {
-			// Skip draft PRs - they're not ready for review yet
-			if pr.Draft {
-				continue
-			}
+		prID := fmt.Sprintf("%s/%s#%d", repoConfig.Owner, repoConfig.Repo, pr.Number)
 
-			// Filter by author if configured
-			// If authors list is empty, we monitor all PRs
-			// If authors list is specified, only monitor PRs by those users
-			if len(repoConfig.Authors) > 0 {
-				isAuthorMatch := false
-				for _, author := range repoConfig.Authors {
-					// Case-insensitive comparison
-					if strings.EqualFold(pr.User.Login, author) {
-						isAuthorMatch = true
-						break
-					}
-				}
-				// Skip this PR if author doesn't match our filter
-				if !isAuthorMatch {
-					continue
-				}
+		if digest {
+			// Collect the PR for the repository's combined notification
+			if digestDue && !t.fetchDetails(ctx, repoConfig, &pr, prID) {
+				failing, ciURL := t.ciFailing(ctx, repoConfig, pr, prID)
+				digestPRs = append(digestPRs, digestPR{pr: pr, ciFailing: failing, ciURL: ciURL})
 			}
+			continue
+		}
 
-			// Check if PR is stale
-			// We use UpdatedAt (last activity time) rather than CreatedAt
-			// This way, PRs with recent comments/commits won't trigger alerts
-			if time.Since(pr.UpdatedAt) < time.Duration(staleDays)*24*time.Hour {
-				continue // PR is still fresh, skip it
-			}
+		// Check notification cooldown
+		// We don't want to spam notifications for the same PR every 5 minutes
+		// The cooldown (default 24h) ensures we only notify once per day per PR
+		if t.inCooldown(prID) && !t.updatedSinceNotified(prID, pr) {
+			continue // We notified about this PR recently, skip it
+		}
+
+		if dropping {
+			log.Debug().Str("pr", prID).Msg("Outside business hours, not notifying about stale PR")
+			continue
+		}
+
+		if t.fetchDetails(ctx, repoConfig, &pr, prID) {
+			continue
+		}
+
+		// PR is stale and we haven't notified recently - send notification
+		isFailure, ciURL := t.ciFailing(ctx, repoConfig, pr, prID)
+		var ciMsg string
+		if isFailure {
+			ciMsg = " (CI: Failing ❌)"
+		}
+
+		// Summarize where reviews stand (approved, changes requested, ...)
+		var reviewSummary string
+		reviews, errReviews := t.apiClient.GetPullRequestReviews(ctx, repoConfig.Owner, repoConfig.Repo, pr.Number)
+		if errReviews != nil {
+			log.Error().Err(errReviews).Str("pr", prID).Msg("Failed to fetch reviews")
+		} else {
+			reviewSummary = summarizeReviews(reviews)
+		}
 
-			// Check notification cooldown
-			// We don't want to spam notifications for the same PR every 5 minutes
-			// The cooldown (default 24h) ensures we only notify once per day per PR
-			prID := fmt.Sprintf("%s/%s#%d", repoConfig.Owner, repoConfig.Repo, pr.Number)
+		data := PRTemplateData{
+			Number:       pr.Number,
+			Title:        pr.Title,
+			Author:       pr.User.Login,
+			URL:          pr.HTMLURL,
+			Owner:        repoConfig.Owner,
+			Repo:         repoConfig.Repo,
+			UpdatedAt:    pr.UpdatedAt,
+			CreatedAt:    pr.CreatedAt,
+			Reviews:      reviewSummary,
+			WaitingOn:    t.waitingOn(pr),
+			Draft:        pr.Draft,
+			HasConflicts: pr.HasConflicts(),
+			Diffstat:     t.diffstat(pr),
+		}
+		if isFailure {
+			data.CIStatus = "failing"
+			data.CIURL = ciURL
+		}
+		subject := t.renderOr(t.templates.Subject, data, func() string {
+			return fmt.Sprintf("Stale PR: %s%s", pr.Title, stateLabels(pr))
+		})
+		message := t.renderOr(t.templates.Body, data, func() string {
+			return t.formatStaleMessage(repoConfig, pr, ciMsg, ciURL, reviewSummary)
+		})
 
+		log.Info().Str("pr", prID).Msg("Sending notification for stale PR")
+		severity := notifier.SeverityWarning
+		if isFailure {
+			severity = notifier.SeverityFailure
+		}
+		if t.deliver(ctx, repoID, open, prID, severity, subject, message) {
 			t.mu.Lock()
-			lastTime, ok := t.lastNotificationTime[prID]
+			t.lastNotifiedUpdate[prID] = pr.UpdatedAt
 			t.mu.Unlock()
+		}
+	}
 
-			if ok {
-				if time.Since(lastTime) < t.config.GetNotificationCooldown() {
-					continue // We notified about this PR recently, skip it
-				}
+	if len(digestPRs) > 0 {
+		log.Info().Str("repo", repoID).Int("stale_prs", len(digestPRs)).Msg("Sending digest notification for stale PRs")
+		severity := notifier.SeverityWarning
+		for _, d := range digestPRs {
+			if d.ciFailing {
+				severity = notifier.SeverityFailure
 			}
+		}
+		subject := fmt.Sprintf("%d stale PRs in %s", len(digestPRs), repoID)
+		if len(digestPRs) == 1 {
+			subject = fmt.Sprintf("1 stale PR in %s", repoID)
+		}
+		t.deliver(ctx, repoID, open, repoID, severity, subject, t.formatDigestMessage(repoID, digestPRs))
+	}
+
+	return staleCount, nil
+}
+
+// inCooldown reports whether we notified about id (a PR, or a repository in digest mode)
+// within the notification cooldown.
+func (t *PRReviewCheckTask) inCooldown(id string) bool {
+	t.mu.Lock()
+	lastTime, ok := t.lastNotificationTime[id]
+	t.mu.Unlock()
+
+	return ok && clock.Since(t.Clock, lastTime) < t.config.GetNotificationCooldown()
+}
+
+// fetchDetails looks up the fields of a stale PR the PR list doesn't include, when they are
+// needed: whether it has merge conflicts (merge_conflicts "label" or "skip") and its size
+// (include_diffstat). It fills them in and reports whether to skip the PR for its conflicts.
+// This costs one request per stale PR. If the lookup fails, the PR is notified about as usual.
+func (t *PRReviewCheckTask) fetchDetails(ctx context.Context, repoConfig config.RepositoryConfig, pr *api.PullRequest, prID string) bool {
+	mode := t.config.GetMergeConflicts()
+	if mode == config.MergeConflictsNotify && !t.config.IncludeDiffstat {
+		return false
+	}
+
+	details, err := t.apiClient.GetPullRequest(ctx, repoConfig.Owner, repoConfig.Repo, pr.Number)
+	if err != nil {
+		log.Error().Err(err).Str("pr", prID).Msg("Failed to fetch PR details")
+		return false
+	}
+	pr.Additions, pr.Deletions, pr.ChangedFiles = details.Additions, details.Deletions, details.ChangedFiles
+	if mode == config.MergeConflictsNotify {
+		return false
+	}
+	pr.Mergeable, pr.MergeableState = details.Mergeable, details.MergeableState
+
+	if mode == config.MergeConflictsSkip && pr.HasConflicts() {
+		log.Debug().Str("pr", prID).Msg("Skipping stale PR with merge conflicts")
+		return true
+	}
+	return false
+}
 
-			// PR is stale and we haven't notified recently - send notification
-			subject := fmt.Sprintf("Stale PR: %s", pr.Title)
+// updatedSinceNotified reports whether reset_cooldown_on_update is on and the PR was
+// updated since we last notified about it, which ends its cooldown early.
+func (t *PRReviewCheckTask) updatedSinceNotified(prID string, pr api.PullRequest) bool {
+	if !t.config.ResetCooldownOnUpdate {
+		return false
+	}
 
-			// Check CI status (Commit Status + Check Suites)
-			var ciMsg string
+	t.mu.Lock()
+	notifiedUpdate, ok := t.lastNotifiedUpdate[prID]
+	t.mu.Unlock()
 
-			// 1. Get Commit Status (Legacy / CircleCI / Jenkins)
-			commitStatus, errStatus := t.apiClient.GetCommitStatus(ctx, repoConfig.Owner, repoConfig.Repo, pr.Head.SHA)
-			if errStatus != nil {
-				log.Error().Err(errStatus).Str("pr", prID).Msg("Failed to check commit status")
+	if !ok || !pr.UpdatedAt.After(notifiedUpdate) {
+		return false
+	}
+	log.Debug().Str("pr", prID).Time("updated_at", pr.UpdatedAt).Msg("PR updated since the last notification, resetting its cooldown")
+	return true
+}
+
+// notify sends a notification and, if it was delivered, starts the cooldown for id.
+// It reports whether the notification was delivered. Errors are logged so the remaining
+// PRs and repositories are still checked.
+func (t *PRReviewCheckTask) notify(ctx context.Context, id string, severity notifier.Severity, subject, message string) bool {
+	// Respect max_notifications_per_run; the PR is picked up again next run
+	if !t.reserveNotification() {
+		log.Info().Str("pr", id).Msg("Notification limit for this run reached, deferring to the next run")
+		return false
+	}
+
+	if err := t.notifier.SendNotification(notifier.WithSeverity(ctx, severity), subject, message); err != nil {
+		log.Error().Err(err).Str("pr", id).Msg("Failed to send notification")
+		// Only delivered notifications count towards the limit
+		t.mu.Lock()
+		t.sentThisRun--
+		t.mu.Unlock()
+		return false
+	}
+
+	t.mu.Lock()
+	t.lastNotificationTime[id] = clock.Now(t.Clock)
+	t.mu.Unlock()
+	return true
+}
+
+// queuedPRNotification is a notification held back until business hours start.
+type queuedPRNotification struct {
+	id       string
+	severity notifier.Severity
+	subject  string
+	message  string
+}
+
+// inBusinessHours reports whether it is within the business hours of the repository,
+// which is always the case if it has none.
+func (t *PRReviewCheckTask) inBusinessHours(repoConfig config.RepositoryConfig) bool {
+	if !repoConfig.BusinessHours.IsEnabled() {
+		return true
+	}
+	hours, err := repoConfig.GetBusinessHours()
+	if err != nil {
+		// validateConfig has already rejected invalid business hours; notify just in case
+		log.Error().Err(err).Str("owner", repoConfig.Owner).Str("repo", repoConfig.Repo).Msg("Invalid business hours, notifying at any time")
+		return true
+	}
+	return hours.Contains(clock.Now(t.Clock))
+}
+
+// deliver notifies about id like notify within business hours (open). Outside them, it
+// queues the notification for repoID until business hours start, and starts the cooldown
+// for id so it isn't queued again. It reports whether the notification was delivered or queued.
+func (t *PRReviewCheckTask) deliver(ctx context.Context, repoID string, open bool, id string, severity notifier.Severity, subject, message string) bool {
+	if open {
+		return t.notify(ctx, id, severity, subject, message)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.queued[repoID] = append(t.queued[repoID], queuedPRNotification{id: id, severity: severity, subject: subject, message: message})
+	t.lastNotificationTime[id] = clock.Now(t.Clock)
+	log.Info().Str("pr", id).Int("queued", len(t.queued[repoID])).Msg("Outside business hours, queueing notification")
+	return true
+}
+
+// withoutQueued returns entries without the PRs (or digests) whose notification is only
+// queued. The queue is kept in memory, so their cooldowns aren't persisted: after a
+// restart the notification is sent again instead of being lost until the cooldown expires.
+// Must be called with mu held.
+func (t *PRReviewCheckTask) withoutQueued(entries map[string]time.Time) map[string]time.Time {
+	if len(t.queued) == 0 {
+		return entries
+	}
+	persisted := make(map[string]time.Time, len(entries))
+	for id, at := range entries {
+		persisted[id] = at
+	}
+	for _, queued := range t.queued {
+		for _, n := range queued {
+			delete(persisted, n.id)
+		}
+	}
+	return persisted
+}
+
+// flushQueued sends the notifications queued for repoID outside its business hours, in the
+// order they were queued. Notifications about PRs closed in the meantime (whose cooldown
+// resolveClosedPRs dropped) are discarded. A notification that isn't delivered (an error, or
+// max_notifications_per_run) has its cooldown cleared, so the next run checks the PR again.
+func (t *PRReviewCheckTask) flushQueued(ctx context.Context, repoID string) {
+	t.mu.Lock()
+	queued := t.queued[repoID]
+	delete(t.queued, repoID)
+	t.mu.Unlock()
+
+	if len(queued) > 0 {
+		log.Info().Str("repo", repoID).Int("queued", len(queued)).Msg("Business hours started, sending queued notifications")
+	}
+	for _, n := range queued {
+		t.mu.Lock()
+		_, pending := t.lastNotificationTime[n.id]
+		t.mu.Unlock()
+		if !pending {
+			log.Debug().Str("pr", n.id).Msg("PR closed since its notification was queued, discarding it")
+			continue
+		}
+
+		if !t.notify(ctx, n.id, n.severity, n.subject, n.message) {
+			t.mu.Lock()
+			delete(t.lastNotificationTime, n.id)
+			t.mu.Unlock()
+		}
+	}
+}
+
+// reserveNotification counts a notification towards max_notifications_per_run, returning
+// false if the limit for this run has already been reached.
+func (t *PRReviewCheckTask) reserveNotification() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if limit := t.config.MaxNotificationsPerRun; limit > 0 && t.sentThisRun >= limit {
+		return false
+	}
+	t.sentThisRun++
+	return true
+}
+
+// ciFailing reports whether the CI of the PR's head commit is failing, combining the
+// commit status (legacy / CircleCI / Jenkins) with check suites (GitHub Actions).
+// Only failures count: pending or unknown CI (including API errors, which are logged) is not failing.
+// If it is failing, it also returns a link to the failing run (see ciFailureURL).
+func (t *PRReviewCheckTask) ciFailing(ctx context.Context, repoConfig config.RepositoryConfig, pr api.PullRequest, prID string) (bool, string) {
+	commitStatus, errStatus := t.apiClient.GetCommitStatus(ctx, repoConfig.Owner, repoConfig.Repo, pr.Head.SHA)
+	if errStatus != nil {
+		log.Error().Err(errStatus).Str("pr", prID).Msg("Failed to check commit status")
+	}
+
+	checkSuites, errChecks := t.apiClient.GetCheckSuites(ctx, repoConfig.Owner, repoConfig.Repo, pr.Head.SHA)
+	if errChecks != nil {
+		log.Error().Err(errChecks).Str("pr", prID).Msg("Failed to check suites")
+	}
+
+	if commitStatus != nil {
+		switch commitStatus.State {
+		case "failure", "error":
+			return true, ciFailureURL(pr, commitStatus)
+		}
+	}
+
+	if checkSuites != nil {
+		for _, suite := range checkSuites.CheckSuites {
+			if suite.Conclusion == "failure" || suite.Conclusion == "timed_out" || suite.Conclusion == "cancelled" {
+				return true, ciFailureURL(pr, nil)
 			}
+		}
+	}
+	return false, ""
+}
 
-			// 2. Get Check Suites (GitHub Actions)
-			checkSuites, errChecks := t.apiClient.GetCheckSuites(ctx, repoConfig.Owner, repoConfig.Repo, pr.Head.SHA)
-			if errChecks != nil {
-				log.Error().Err(errChecks).Str("pr", prID).Msg("Failed to check suites")
+// ciFailureURL links to the failing CI run of pr: the details page of the first failed
+// commit status in status (which may be nil) that has one, otherwise the PR's checks page.
+// Check suites don't link to their runs, so failing GitHub Actions get the checks page too.
+func ciFailureURL(pr api.PullRequest, status *api.CommitStatus) string {
+	if status != nil {
+		for _, s := range status.Statuses {
+			if (s.State == "failure" || s.State == "error") && s.TargetURL != "" {
+				return s.TargetURL
 			}
+		}
+	}
+	if pr.HTMLURL == "" {
+		return ""
+	}
+	return strings.TrimSuffix(pr.HTMLURL, "/") + "/checks"
+}
 
-			// 3. Combine Logic
-			// Priority: Failure only. We assume success/pending unless we find a failure.
-			isFailure := false
+// resolveClosedPRs drops cooldown entries for previously alerted PRs of this repository
+// that are no longer open, sending a "Resolved" notification first if notify_on_resolve
+// is set. If that notification fails, the entry is kept so it is retried on the next run.
+func (t *PRReviewCheckTask) resolveClosedPRs(ctx context.Context, repoConfig config.RepositoryConfig, openPRs []api.PullRequest) {
+	prefix := fmt.Sprintf("%s/%s#", repoConfig.Owner, repoConfig.Repo)
+	open := make(map[string]bool, len(openPRs))
+	for _, pr := range openPRs {
+		open[fmt.Sprintf("%s%d", prefix, pr.Number)] = true
+	}
 
-			// Check Commit Status
-			if commitStatus != nil {
-				switch commitStatus.State {
-				case "failure", "error":
-					isFailure = true
-				}
+	var resolved []string
+	t.mu.Lock()
+	for prID := range t.lastNotificationTime {
+		if strings.HasPrefix(prID, prefix) && !open[prID] {
+			resolved = append(resolved, prID)
+		}
+	}
+	t.mu.Unlock()
+
+	for _, prID := range resolved {
+		if t.config.NotifyOnResolve {
+			number := strings.TrimPrefix(prID, prefix)
+			subject := fmt.Sprintf("Resolved: PR #%s was closed/merged", number)
+			message := fmt.Sprintf("PR #%s in %s/%s, previously reported as stale, is no longer open.", number, repoConfig.Owner, repoConfig.Repo)
+			log.Info().Str("pr", prID).Msg("Sending notification for resolved PR")
+			if err := t.notifier.SendNotification(notifier.WithSeverity(ctx, notifier.SeveritySuccess), subject, message); err != nil {
+				log.Error().Err(err).Str("pr", prID).Msg("Failed to send notification")
+				continue
 			}
+		}
+
+		t.mu.Lock()
+		delete(t.lastNotificationTime, prID)
+		delete(t.lastNotifiedUpdate, prID)
+		t.mu.Unlock()
+	}
+}
+
+// formatStaleMessage builds the notification body for a stale PR in the configured format.
+// Markdown and HTML bodies use bold labels and a clickable link; plain text is the default.
+// The last update is shown as configured by last_updated_format.
+// A "Reviews:" line is added when reviewSummary is non-empty, a "Waiting on:" line
+// listing requested reviewers when include_reviewers is on and there are any, and a
+// "CI failing:" line linking to ciURL when include_ci_links is on and CI is failing.
+func (t *PRReviewCheckTask) formatStaleMessage(repoConfig config.RepositoryConfig, pr api.PullRequest, ciMsg, ciURL, reviewSummary string) string {
+	updated := formatLastUpdated(t.config.GetLastUpdatedFormat(), pr.UpdatedAt, clock.Now(t.Clock))
+	waitingOn := t.waitingOn(pr)
+	if !t.config.IncludeCILinks {
+		ciURL = ""
+	}
 
-			// Check Suites
-			if checkSuites != nil {
-				for _, suite := range checkSuites.CheckSuites {
-					if suite.Conclusion == "failure" || suite.Conclusion == "timed_out" || suite.Conclusion == "cancelled" {
-						isFailure = true
-						break
-					}
+	switch t.format {
+	case notifier.FormatMarkdown:
+		reviewsLine := ""
+		if reviewSummary != "" {
+			reviewsLine = fmt.Sprintf("\n**Reviews:** %s", reviewSummary)
+		}
+		if waitingOn != "" {
+			reviewsLine += fmt.Sprintf("\n**Waiting on:** %s", waitingOn)
+		}
+		if ciURL != "" {
+			reviewsLine += fmt.Sprintf("\n**CI failing:** [%s](%s)", ciURL, ciURL)
+		}
+		return fmt.Sprintf("**PR #%d**%s in %s/%s by %s is pending review.%s%s\n**Last updated:** %s\n**Link:** [%s](%s)",
+			pr.Number, t.labels(pr), repoConfig.Owner, repoConfig.Repo, pr.User.Login,
+			ciMsg, reviewsLine,
+			updated, pr.HTMLURL, pr.HTMLURL)
+	case notifier.FormatHTML:
+		reviewsLine := ""
+		if reviewSummary != "" {
+			reviewsLine = fmt.Sprintf("<br>\n<b>Reviews:</b> %s", html.EscapeString(reviewSummary))
+		}
+		if waitingOn != "" {
+			reviewsLine += fmt.Sprintf("<br>\n<b>Waiting on:</b> %s", html.EscapeString(waitingOn))
+		}
+		if ciURL != "" {
+			reviewsLine += fmt.Sprintf("<br>\n<b>CI failing:</b> <a href=\"%s\">%s</a>", html.EscapeString(ciURL), html.EscapeString(ciURL))
+		}
+		return fmt.Sprintf("<b>PR #%d</b>%s in %s/%s by %s is pending review.%s%s<br>\n<b>Last updated:</b> %s<br>\n<b>Link:</b> <a href=\"%s\">%s</a>",
+			pr.Number, t.labels(pr), html.EscapeString(repoConfig.Owner), html.EscapeString(repoConfig.Repo), html.EscapeString(pr.User.Login),
+			ciMsg, reviewsLine,
+			updated, html.EscapeString(pr.HTMLURL), html.EscapeString(pr.HTMLURL))
+	default:
+		reviewsLine := ""
+		if reviewSummary != "" {
+			reviewsLine = fmt.Sprintf("\nReviews: %s", reviewSummary)
+		}
+		if waitingOn != "" {
+			reviewsLine += fmt.Sprintf("\nWaiting on: %s", waitingOn)
+		}
+		if ciURL != "" {
+			reviewsLine += fmt.Sprintf("\nCI failing: %s", ciURL)
+		}
+		return fmt.Sprintf("PR #%d%s in %s/%s by %s is pending review.%s%s\nLast updated: %s\nLink: %s",
+			pr.Number, t.labels(pr), repoConfig.Owner, repoConfig.Repo, pr.User.Login,
+			ciMsg, reviewsLine,
+			updated, pr.HTMLURL)
+	}
+}
+
+// digestPR is a stale PR listed in a repository's digest notification.
+type digestPR struct {
+	pr        api.PullRequest
+	ciFailing bool
+
+	// ciURL links to the failing CI run (see ciFailureURL)
+	ciURL string
+}
+
+// formatDigestMessage builds the body of a digest notification, listing one stale PR per line
+// in the configured format. With include_ci_links, a PR's failing CI note links to the run.
+func (t *PRReviewCheckTask) formatDigestMessage(repoID string, prs []digestPR) string {
+	lastUpdatedFormat, now := t.config.GetLastUpdatedFormat(), clock.Now(t.Clock)
+	var b strings.Builder
+	switch t.format {
+	case notifier.FormatMarkdown:
+		fmt.Fprintf(&b, "**Pending review in %s:**", repoID)
+		for _, d := range prs {
+			ci := ""
+			if d.ciFailing {
+				ci = " (CI: Failing ❌)"
+				if t.config.IncludeCILinks && d.ciURL != "" {
+					ci = fmt.Sprintf(" ([CI: Failing ❌](%s))", d.ciURL)
 				}
 			}
-
-			if isFailure {
-				ciMsg = " (CI: Failing ❌)"
+			fmt.Fprintf(&b, "\n- [#%d %s](%s)%s by %s, last updated %s%s",
+				d.pr.Number, d.pr.Title, d.pr.HTMLURL, t.labels(d.pr), d.pr.User.Login, formatLastUpdated(lastUpdatedFormat, d.pr.UpdatedAt, now), ci)
+		}
+	case notifier.FormatHTML:
+		fmt.Fprintf(&b, "<b>Pending review in %s:</b>", html.EscapeString(repoID))
+		for _, d := range prs {
+			ci := ""
+			if d.ciFailing {
+				ci = " (CI: Failing ❌)"
+				if t.config.IncludeCILinks && d.ciURL != "" {
+					ci = fmt.Sprintf(" (<a href=\"%s\">CI: Failing ❌</a>)", html.EscapeString(d.ciURL))
+				}
+			}
+			fmt.Fprintf(&b, "<br>\n• <a href=\"%s\">#%d %s</a>%s by %s, last updated %s%s",
+				html.EscapeString(d.pr.HTMLURL), d.pr.Number, html.EscapeString(d.pr.Title), t.labels(d.pr),
+				html.EscapeString(d.pr.User.Login), formatLastUpdated(lastUpdatedFormat, d.pr.UpdatedAt, now), ci)
+		}
+	default:
+		fmt.Fprintf(&b, "Pending review in %s:", repoID)
+		for _, d := range prs {
+			ci := ""
+			if d.ciFailing {
+				ci = " (CI: Failing ❌)"
+				if t.config.IncludeCILinks && d.ciURL != "" {
+					ci = fmt.Sprintf(" (CI: Failing ❌ %s)", d.ciURL)
+				}
 			}
+			fmt.Fprintf(&b, "\n- #%d %s%s by %s, last updated %s%s\n  %s",
+				d.pr.Number, d.pr.Title, t.labels(d.pr), d.pr.User.Login, formatLastUpdated(lastUpdatedFormat, d.pr.UpdatedAt, now), ci, d.pr.HTMLURL)
+		}
+	}
+	return b.String()
+}
 
-			message := fmt.Sprintf("PR #%d in %s/%s by %s is pending review.%s\nLast updated: %s\nLink: %s",
-				pr.Number, repoConfig.Owner, repoConfig.Repo, pr.User.Login,
-				ciMsg,
-				pr.UpdatedAt.Format(time.RFC1123), pr.HTMLURL)
+// diffstat summarizes the size of pr's diff, e.g. "+120/-30, 4 files", or returns "" if
+// include_diffstat is off or the size wasn't fetched.
+func (t *PRReviewCheckTask) diffstat(pr api.PullRequest) string {
+	if !t.config.IncludeDiffstat || (pr.Additions == 0 && pr.Deletions == 0 && pr.ChangedFiles == 0) {
+		return ""
+	}
+	return formatDiffstat(pr.Additions, pr.Deletions, pr.ChangedFiles)
+}
 
-			log.Info().Str("pr", prID).Msg("Sending notification for stale PR")
-			err = t.notifier.SendNotification(ctx, subject, message)
-			if err != nil {
-				// Log the error but continue with other PRs
-				log.Error().Err(err).Str("pr", prID).Msg("Failed to send notification")
-			} else {
-				// Record that we sent a notification for this PR
-				// This starts the cooldown period
-				t.mu.Lock()
-				t.lastNotificationTime[prID] = time.Now()
-				t.mu.Unlock()
+// formatDiffstat formats the size of a diff, e.g. "+120/-30, 4 files".
+func formatDiffstat(additions, deletions, changedFiles int) string {
+	return fmt.Sprintf("+%d/-%d, %s", additions, deletions, plural(changedFiles, "file"))
+}
+
+// labels returns the parenthesized notes shown after a PR's number in notification
+// bodies: its state (see stateLabels) and, with include_diffstat, its size.
+func (t *PRReviewCheckTask) labels(pr api.PullRequest) string {
+	labels := stateLabels(pr)
+	if diffstat := t.diffstat(pr); diffstat != "" {
+		labels += " (" + diffstat + ")"
+	}
+	return labels
+}
+
+// stateLabels returns " (draft)" for draft PRs, which are only alerted on with
+// monitor_drafts, and " (has conflicts)" for PRs with merge conflicts, which are only
+// detected with merge_conflicts "label". It returns an empty string for other PRs.
+func stateLabels(pr api.PullRequest) string {
+	var labels string
+	if pr.Draft {
+		labels += " (draft)"
+	}
+	if pr.HasConflicts() {
+		labels += " (has conflicts)"
+	}
+	return labels
+}
+
+// waitingOn lists the PR's requested reviewers (e.g. "alice, bob"), or returns an empty
+// string if there are none or include_reviewers is disabled.
+func (t *PRReviewCheckTask) waitingOn(pr api.PullRequest) string {
+	if !t.config.GetIncludeReviewers() || len(pr.RequestedReviewers) == 0 {
+		return ""
+	}
+	logins := make([]string, 0, len(pr.RequestedReviewers))
+	for _, reviewer := range pr.RequestedReviewers {
+		logins = append(logins, reviewer.Login)
+	}
+	return strings.Join(logins, ", ")
+}
+
+// renderOr renders tmpl with data, or returns fallback() if tmpl is nil or fails to render.
+func (t *PRReviewCheckTask) renderOr(tmpl *template.Template, data PRTemplateData, fallback func() string) string {
+	if tmpl == nil {
+		return fallback()
+	}
+	out, err := renderPRTemplate(tmpl, data)
+	if err != nil {
+		log.Error().Err(err).Str("template", tmpl.Name()).Msg("Failed to render notification template, using the default format")
+		return fallback()
+	}
+	return out
+}
+
+// summarizeReviews reduces a PR's reviews to the latest state per reviewer,
+// e.g. "alice ✅, bob 🔄", in the order reviewers first appeared.
+// Comments don't override an earlier approval or change request, matching how GitHub
+// tracks review decisions; a dismissed review clears the reviewer's state.
+// Returns an empty string if there is nothing to report.
+func summarizeReviews(reviews []api.Review) string {
+	var order []string
+	latest := make(map[string]string)
+
+	for _, review := range reviews {
+		login := review.User.Login
+		if login == "" || review.State == api.ReviewStatePending {
+			continue
+		}
+		if _, seen := latest[login]; !seen {
+			order = append(order, login)
+		}
+
+		switch review.State {
+		case api.ReviewStateCommented:
+			if latest[login] == "" {
+				latest[login] = review.State
 			}
+		case api.ReviewStateDismissed:
+			latest[login] = ""
+		default:
+			latest[login] = review.State
 		}
 	}
 
-	// Cleanup old entries from lastNotificationTime map to prevent memory leak
-	// Remove entries older than 7 days (or configured cooldown if longer)
-	// This ensures we respect the cooldown while eventually cleaning up closed/merged PRs
-	minCleanupAge := 7 * 24 * time.Hour
-	cooldown := t.config.GetNotificationCooldown()
-
-	// Use the larger of the two to avoid cleaning up before cooldown expires
-	cleanupThreshold := minCleanupAge
-	if cooldown > minCleanupAge {
+	var parts []string
+	for _, login := range order {
+		var icon string
+		switch latest[login] {
+		case api.ReviewStateApproved:
+			icon = "✅"
+		case api.ReviewStateChangesRequested:
+			icon = "🔄"
+		case api.ReviewStateCommented:
+			icon = "💬"
+		default:
+			continue
+		}
+		parts = append(parts, login+" "+icon)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// cleanupNotificationTimes removes cooldown entries older than 7 days (or the cooldown,
+// if longer) so closed/merged items don't accumulate forever.
+// Using the larger of the two ensures we never clean up before the cooldown expires.
+// The caller must hold the lock guarding lastNotificationTime.
+func cleanupNotificationTimes(lastNotificationTime map[string]time.Time, cooldown time.Duration, now time.Time) {
+	cleanupThreshold := 7 * 24 * time.Hour
+	if cooldown > cleanupThreshold {
 		cleanupThreshold = cooldown
 	}
 
-	t.mu.Lock()
-	for prID, lastTime := range t.lastNotificationTime {
-		if time.Since(lastTime) > cleanupThreshold {
-			delete(t.lastNotificationTime, prID)
+	for id, lastTime := range lastNotificationTime {
+		if now.Sub(lastTime) > cleanupThreshold {
+			delete(lastNotificationTime, id)
 		}
 	}
-	t.mu.Unlock()
+}
+
+// saveNotificationTimes persists a copy of lastNotificationTime to store under namespace.
+// Failures are logged rather than returned: losing state only means repeat alerts after a restart.
+// The caller must hold the lock guarding lastNotificationTime.
+func saveNotificationTimes(store state.StateStore, namespace string, lastNotificationTime map[string]time.Time) {
+	if store == nil {
+		return
+	}
+
+	entries := make(map[string]time.Time, len(lastNotificationTime))
+	for id, lastTime := range lastNotificationTime {
+		entries[id] = lastTime
+	}
+	if err := store.Save(namespace, entries); err != nil {
+		log.Error().Err(err).Str("namespace", namespace).Msg("Failed to save notification state")
+	}
+}
+
+// PRStatus is how the PR review check treats an open pull request.
+type PRStatus string
+
+const (
+	// PRIgnored PRs are never alerted on: drafts (unless monitor_drafts is set), PRs by
+	// bots (with ignore_bots), and PRs filtered out by author, assignee, label or requested reviewer
+	PRIgnored PRStatus = "ignored"
+	// PRFresh PRs are watched but not stale yet: idle for less than the stale threshold,
+	// or opened within the grace period
+	PRFresh PRStatus = "fresh"
+	// PRStale PRs are alerted on (subject to the notification cooldown)
+	PRStale PRStatus = "stale"
+)
+
+// ClassifyOptions are the task-wide settings ClassifyPR applies on top of each
+// repository's own filters.
+type ClassifyOptions struct {
+	// StaleThreshold is how long a PR can be idle before it's stale (see GitHubConfig.GetStaleThreshold)
+	StaleThreshold time.Duration
+	// GracePeriod is how long after being opened a PR is never stale
+	GracePeriod time.Duration
+	// MonitorDrafts includes draft PRs; otherwise they're ignored
+	MonitorDrafts bool
+	// IgnoreBots ignores PRs opened by bots (see isBot)
+	IgnoreBots bool
+	// BotAuthors are more logins IgnoreBots treats as bots
+	BotAuthors []string
+	// RequestedFor, if set, ignores PRs where this login isn't a requested reviewer.
+	// It must already be resolved (see ResolveRequestedFor), not "@me".
+	RequestedFor string
+}
+
+// NewClassifyOptions returns the ClassifyOptions configured in cfg. RequestedFor is
+// left empty; callers set it to the login resolved from cfg.OnlyRequestedFor.
+func NewClassifyOptions(cfg config.GitHubConfig) ClassifyOptions {
+	return ClassifyOptions{
+		StaleThreshold: cfg.GetStaleThreshold(),
+		GracePeriod:    cfg.GetGracePeriod(),
+		MonitorDrafts:  cfg.MonitorDrafts,
+		IgnoreBots:     cfg.IgnoreBots,
+		BotAuthors:     cfg.BotAuthors,
+	}
+}
+
+// ResolveRequestedFor returns the login only_requested_for refers to: "@me" is resolved
+// to the user the client's token belongs to, any other value is returned as is.
+func ResolveRequestedFor(ctx context.Context, client api.GitHubClient, onlyRequestedFor string) (string, error) {
+	login := strings.TrimSpace(onlyRequestedFor)
+	if !strings.EqualFold(login, config.RequestedForMe) {
+		return login, nil
+	}
+	user, err := client.GetAuthenticatedUser(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve only_requested_for %q: %v", config.RequestedForMe, err)
+	}
+	return user.Login, nil
+}
 
-	// Always return nil - we don't want task errors to stop the scheduler
-	return nil
+// ClassifyPR reports whether pr is ignored, fresh or stale at now, applying the
+// repository's author, assignee and label filters and its stale_metric, and opts.
+// A PR is stale once it has been idle for opts.StaleThreshold. A PR opened less than
+// opts.GracePeriod ago is never stale, whatever its last update time.
+func ClassifyPR(pr api.PullRequest, repoConfig config.RepositoryConfig, opts ClassifyOptions, now time.Time) PRStatus {
+	// Skip draft PRs - they're not ready for review yet - unless drafts are monitored
+	if pr.Draft && !opts.MonitorDrafts {
+		return PRIgnored
+	}
+
+	// Skip bot PRs (dependency updates and the like) if configured
+	if opts.IgnoreBots && isBot(pr.User, opts.BotAuthors) {
+		return PRIgnored
+	}
+
+	// Filter by author and assignee if configured
+	if !matchesPeopleFilters(pr, repoConfig) {
+		return PRIgnored
+	}
+
+	// Only watch PRs waiting on a specific reviewer if configured
+	if opts.RequestedFor != "" && !isRequestedReviewer(pr, opts.RequestedFor) {
+		return PRIgnored
+	}
+
+	// Filter by labels if configured
+	// Excluded labels always win over included ones
+	if !matchesLabelFilters(pr, repoConfig) {
+		return PRIgnored
+	}
+
+	// Give newly opened PRs time to settle; rebasing can make UpdatedAt look old
+	if now.Sub(pr.CreatedAt) < opts.GracePeriod {
+		return PRFresh
+	}
+
+	// By default we use UpdatedAt (last activity time) rather than CreatedAt
+	// This way, PRs with recent comments/commits won't trigger alerts
+	// Repositories can opt into CreatedAt to alert on total time open instead
+	if now.Sub(PRStaleSince(pr, repoConfig)) < opts.StaleThreshold {
+		return PRFresh
+	}
+	return PRStale
+}
+
+// PRStaleSince returns the time staleness is measured from: the PR's last update, or
+// its creation if the repository's stale_metric is "created".
+func PRStaleSince(pr api.PullRequest, repoConfig config.RepositoryConfig) time.Time {
+	if repoConfig.GetStaleMetric() == config.StaleMetricCreated {
+		return pr.CreatedAt
+	}
+	return pr.UpdatedAt
+}
+
+// isBot reports whether user is a bot: GitHub reports the account as one, its login ends
+// in "[bot]" (as GitHub App logins do, e.g. "dependabot[bot]"), or it is one of botAuthors.
+// Login comparison is case-insensitive.
+func isBot(user api.User, botAuthors []string) bool {
+	if strings.EqualFold(user.Type, "Bot") || strings.HasSuffix(strings.ToLower(user.Login), "[bot]") {
+		return true
+	}
+	for _, login := range botAuthors {
+		if strings.EqualFold(user.Login, login) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRequestedReviewer reports whether login has a pending review request on pr.
+// Login comparison is case-insensitive.
+func isRequestedReviewer(pr api.PullRequest, login string) bool {
+	for _, reviewer := range pr.RequestedReviewers {
+		if strings.EqualFold(reviewer.Login, login) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesPeopleFilters reports whether a PR passes the repository's author and assignee filters.
+// An empty list doesn't filter; if both lists are set, filter_mode decides whether the PR has
+// to match both ("and") or either one ("or"). Login comparison is case-insensitive.
+func matchesPeopleFilters(pr api.PullRequest, repoConfig config.RepositoryConfig) bool {
+	hasLogin := func(users []api.User, logins []string) bool {
+		for _, user := range users {
+			for _, login := range logins {
+				if strings.EqualFold(user.Login, login) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	filterAuthors := len(repoConfig.Authors) > 0
+	filterAssignees := len(repoConfig.Assignees) > 0
+	authorMatch := hasLogin([]api.User{pr.User}, repoConfig.Authors)
+	assigneeMatch := hasLogin(pr.Assignees, repoConfig.Assignees)
+
+	switch {
+	case filterAuthors && filterAssignees:
+		if repoConfig.GetFilterMode() == config.FilterModeOr {
+			return authorMatch || assigneeMatch
+		}
+		return authorMatch && assigneeMatch
+	case filterAuthors:
+		return authorMatch
+	case filterAssignees:
+		return assigneeMatch
+	default:
+		return true
+	}
+}
+
+// matchesLabelFilters reports whether a PR passes the repository's label filters.
+// A PR carrying any excluded label is rejected, even if it also has an included label.
+// An empty include list matches every PR; otherwise at least one included label is required.
+// Label comparison is case-insensitive.
+func matchesLabelFilters(pr api.PullRequest, repoConfig config.RepositoryConfig) bool {
+	hasLabel := func(names []string) bool {
+		for _, label := range pr.Labels {
+			for _, name := range names {
+				if strings.EqualFold(label.Name, name) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	if hasLabel(repoConfig.ExcludeLabels) {
+		return false
+	}
+	if len(repoConfig.IncludeLabels) > 0 && !hasLabel(repoConfig.IncludeLabels) {
+		return false
+	}
+	return true
 }
@@ -1,14 +1,30 @@
 package tasks
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 	"watchdog/internal/api"
+	"watchdog/internal/clock"
 	"watchdog/internal/config"
+	"watchdog/internal/metrics"
+	"watchdog/internal/notifier"
+	"watchdog/internal/scheduler"
+	"watchdog/internal/state"
 
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -27,6 +43,14 @@ func (m *MockGitHubClient) GetOpenPullRequests(ctx context.Context, owner, repo
 	return args.Get(0).([]api.PullRequest), args.Error(1)
 }
 
+func (m *MockGitHubClient) GetPullRequest(ctx context.Context, owner, repo string, number int) (*api.PullRequest, error) {
+	args := m.Called(ctx, owner, repo, number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*api.PullRequest), args.Error(1)
+}
+
 func (m *MockGitHubClient) GetCommitStatus(ctx context.Context, owner, repo, ref string) (*api.CommitStatus, error) {
 	args := m.Called(ctx, owner, repo, ref)
 	if args.Get(0) == nil {
@@ -43,6 +67,46 @@ func (m *MockGitHubClient) GetCheckSuites(ctx context.Context, owner, repo, ref
 	return args.Get(0).(*api.CheckSuitesResponse), args.Error(1)
 }
 
+func (m *MockGitHubClient) GetOpenIssues(ctx context.Context, owner, repo, assignee string) ([]api.Issue, error) {
+	args := m.Called(ctx, owner, repo, assignee)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]api.Issue), args.Error(1)
+}
+
+func (m *MockGitHubClient) GetOrgRepositories(ctx context.Context, org string) ([]api.Repository, error) {
+	args := m.Called(ctx, org)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]api.Repository), args.Error(1)
+}
+
+func (m *MockGitHubClient) GetAuthenticatedUser(ctx context.Context) (*api.User, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*api.User), args.Error(1)
+}
+
+func (m *MockGitHubClient) GetFailedWorkflowRuns(ctx context.Context, owner, repo, branch string) ([]api.WorkflowRun, error) {
+	args := m.Called(ctx, owner, repo, branch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]api.WorkflowRun), args.Error(1)
+}
+
+func (m *MockGitHubClient) GetPullRequestReviews(ctx context.Context, owner, repo string, number int) ([]api.Review, error) {
+	args := m.Called(ctx, owner, repo, number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]api.Review), args.Error(1)
+}
+
 func TestNewPRReviewCheckTask(t *testing.T) {
 	cfg := config.GitHubConfig{
 		Token:     "ghp_test",
@@ -53,7 +117,7 @@ func TestNewPRReviewCheckTask(t *testing.T) {
 	}
 	notifier := &MockNotifier{}
 
-	task := NewPRReviewCheckTask(cfg, notifier)
+	task := NewPRReviewCheckTask(cfg, notifier, "")
 
 	assert.NotNil(t, task)
 	assert.Equal(t, cfg, task.config)
@@ -63,15 +127,20 @@ func TestNewPRReviewCheckTask(t *testing.T) {
 	assert.Empty(t, task.lastNotificationTime)
 }
 
+func TestNewGitHubClient_API(t *testing.T) {
+	assert.IsType(t, &api.GitHubAPI{}, NewGitHubClient(config.GitHubConfig{Token: "ghp_test"}))
+	assert.IsType(t, &api.GitHubGraphQLAPI{}, NewGitHubClient(config.GitHubConfig{Token: "ghp_test", API: "graphql"}))
+}
+
 func TestPRReviewCheckTask_Run_NoRepositories(t *testing.T) {
 	cfg := config.GitHubConfig{
 		StaleDays:    4,
 		Repositories: []config.RepositoryConfig{},
 	}
 
-	task := NewPRReviewCheckTask(cfg, &MockNotifier{})
+	task := NewPRReviewCheckTask(cfg, &MockNotifier{}, "")
 
-	err := task.Run()
+	err := task.Run(context.Background())
 
 	assert.NoError(t, err)
 }
@@ -87,10 +156,10 @@ func TestPRReviewCheckTask_Run_NoPullRequests(t *testing.T) {
 	mockAPI := &MockGitHubClient{}
 	mockAPI.On("GetOpenPullRequests", mock.Anything, "owner1", "repo1").Return([]api.PullRequest{}, nil)
 
-	task := NewPRReviewCheckTask(cfg, &MockNotifier{})
+	task := NewPRReviewCheckTask(cfg, &MockNotifier{}, "")
 	task.apiClient = mockAPI
 
-	err := task.Run()
+	err := task.Run(context.Background())
 
 	assert.NoError(t, err)
 	mockAPI.AssertExpectations(t)
@@ -119,10 +188,11 @@ func TestPRReviewCheckTask_Run_StalePR_SendsNotification(t *testing.T) {
 	mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{stalePR}, nil)
 	mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CommitStatus{State: "success"}, nil)
 	mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CheckSuitesResponse{TotalCount: 0}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", mock.Anything).Return([]api.Review{}, nil)
 
 	mockNotifier := &MockNotifier{}
 	mockNotifier.On("SendNotification", mock.Anything, "Stale PR: Stale PR", mock.MatchedBy(func(msg string) bool {
-		// Should NOT contain "Waiting on" or CI status (since passing)
+		// Should NOT contain "Waiting on" (no reviewers requested) or CI status (since passing)
 		return assert.Contains(t, msg, "#123") &&
 			assert.Contains(t, msg, "testowner/testrepo") &&
 			assert.Contains(t, msg, "testuser") &&
@@ -130,16 +200,1121 @@ func TestPRReviewCheckTask_Run_StalePR_SendsNotification(t *testing.T) {
 			!strings.Contains(msg, "CI:")
 	})).Return(nil)
 
-	task := NewPRReviewCheckTask(cfg, mockNotifier)
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
 	task.apiClient = mockAPI
 
-	err := task.Run()
+	err := task.Run(context.Background())
 
 	assert.NoError(t, err)
 	mockAPI.AssertExpectations(t)
 	mockNotifier.AssertExpectations(t)
 }
 
+func TestPRReviewCheckTask_Run_FakeClock_StalenessAndCooldown(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC))
+	cfg := config.GitHubConfig{
+		StaleDays:            4,
+		NotificationCooldown: "24h",
+		Repositories: []config.RepositoryConfig{
+			{Owner: "testowner", Repo: "testrepo"},
+		},
+	}
+
+	pr := api.PullRequest{
+		Number:    123,
+		Title:     "Aging PR",
+		User:      api.User{Login: "testuser"},
+		UpdatedAt: fake.Now().Add(-4*24*time.Hour + time.Minute), // one minute short of stale
+		Head:      api.PRHead{SHA: "sha123"},
+	}
+
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{pr}, nil)
+	mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CommitStatus{State: "success"}, nil)
+	mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CheckSuitesResponse{TotalCount: 0}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", mock.Anything).Return([]api.Review{}, nil)
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "Stale PR: Aging PR", mock.Anything).Return(nil)
+
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+	task.apiClient = mockAPI
+	task.Clock = fake
+
+	require.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 0)
+
+	// The PR turns stale
+	fake.Advance(time.Minute)
+	require.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 1)
+
+	// Still within the 24h cooldown
+	fake.Advance(24*time.Hour - time.Second)
+	require.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 1)
+
+	// Cooldown over
+	fake.Advance(time.Second)
+	require.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 2)
+}
+
+// businessHoursTask returns a task watching a repository in Tokyo and one in New York,
+// each with 09:00-17:00 business hours in mode, and one stale PR in each.
+func businessHoursTask(t *testing.T, fake *clock.Fake, mode string) (*PRReviewCheckTask, *MockNotifier) {
+	t.Helper()
+	hours := config.BusinessHoursConfig{Start: "09:00", End: "17:00", Mode: mode}
+	cfg := config.GitHubConfig{
+		StaleDays:            4,
+		NotificationCooldown: "24h",
+		Repositories: []config.RepositoryConfig{
+			{Owner: "acme", Repo: "tokyo", Timezone: "Asia/Tokyo", BusinessHours: hours},
+			{Owner: "acme", Repo: "nyc", Timezone: "America/New_York", BusinessHours: hours},
+		},
+	}
+
+	mockAPI := &MockGitHubClient{}
+	for _, repo := range []string{"tokyo", "nyc"} {
+		mockAPI.On("GetOpenPullRequests", mock.Anything, "acme", repo).Return([]api.PullRequest{{
+			Number:    1,
+			Title:     "Stale in " + repo,
+			User:      api.User{Login: "dev"},
+			UpdatedAt: fake.Now().Add(-10 * 24 * time.Hour),
+			Head:      api.PRHead{SHA: "sha-" + repo},
+		}}, nil)
+		mockAPI.On("GetCommitStatus", mock.Anything, "acme", repo, "sha-"+repo).Return(&api.CommitStatus{State: "success"}, nil)
+		mockAPI.On("GetCheckSuites", mock.Anything, "acme", repo, "sha-"+repo).Return(&api.CheckSuitesResponse{}, nil)
+		mockAPI.On("GetPullRequestReviews", mock.Anything, "acme", repo, 1).Return([]api.Review{}, nil)
+	}
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+	task.apiClient = mockAPI
+	task.Clock = fake
+	return task, mockNotifier
+}
+
+// sentSubjects returns the subjects of the notifications sent through m, in order.
+func sentSubjects(m *MockNotifier) []string {
+	var subjects []string
+	for _, call := range m.Calls {
+		subjects = append(subjects, call.Arguments.String(1))
+	}
+	return subjects
+}
+
+func TestPRReviewCheckTask_Run_BusinessHours_Drop(t *testing.T) {
+	// Wednesday 01:00 UTC: 10:00 on Wednesday in Tokyo, 20:00 on Tuesday in New York
+	fake := clock.NewFake(time.Date(2024, 1, 10, 1, 0, 0, 0, time.UTC))
+	task, mockNotifier := businessHoursTask(t, fake, "")
+
+	require.NoError(t, task.Run(context.Background()))
+	assert.Equal(t, []string{"Stale PR: Stale in tokyo"}, sentSubjects(mockNotifier))
+
+	// 15:00 UTC: midnight in Tokyo, 10:00 in New York. The dropped PR didn't start a
+	// cooldown, so it is notified about now
+	fake.Set(time.Date(2024, 1, 10, 15, 0, 0, 0, time.UTC))
+	require.NoError(t, task.Run(context.Background()))
+	assert.Equal(t, []string{"Stale PR: Stale in tokyo", "Stale PR: Stale in nyc"}, sentSubjects(mockNotifier))
+}
+
+func TestPRReviewCheckTask_Run_BusinessHours_Weekend(t *testing.T) {
+	// Saturday 10:00 in Tokyo and Friday 20:00 in New York: neither is a business hour
+	fake := clock.NewFake(time.Date(2024, 1, 13, 1, 0, 0, 0, time.UTC))
+	task, mockNotifier := businessHoursTask(t, fake, "drop")
+
+	require.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertNotCalled(t, "SendNotification", mock.Anything, mock.Anything, mock.Anything)
+
+	// Monday 10:00 in Tokyo
+	fake.Set(time.Date(2024, 1, 15, 1, 0, 0, 0, time.UTC))
+	require.NoError(t, task.Run(context.Background()))
+	assert.Equal(t, []string{"Stale PR: Stale in tokyo"}, sentSubjects(mockNotifier))
+}
+
+func TestPRReviewCheckTask_Run_BusinessHours_Queue(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 10, 1, 0, 0, 0, time.UTC))
+	task, mockNotifier := businessHoursTask(t, fake, "queue")
+
+	// 20:00 in New York: the notification is queued
+	require.NoError(t, task.Run(context.Background()))
+	assert.Equal(t, []string{"Stale PR: Stale in tokyo"}, sentSubjects(mockNotifier))
+	assert.Len(t, task.queued["acme/nyc"], 1)
+
+	// Still outside business hours: it isn't queued again
+	fake.Advance(time.Hour)
+	require.NoError(t, task.Run(context.Background()))
+	assert.Len(t, task.queued["acme/nyc"], 1)
+
+	// 09:30 in New York: the queued notification is sent, once
+	fake.Set(time.Date(2024, 1, 10, 14, 30, 0, 0, time.UTC))
+	require.NoError(t, task.Run(context.Background()))
+	assert.Equal(t, []string{"Stale PR: Stale in tokyo", "Stale PR: Stale in nyc"}, sentSubjects(mockNotifier))
+	assert.Empty(t, task.queued)
+
+	fake.Advance(time.Hour)
+	require.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 2)
+}
+
+func TestPRReviewCheckTask_Run_BusinessHours_QueueSurvivesRestart(t *testing.T) {
+	store := state.NewFileStore(filepath.Join(t.TempDir(), "state.json"))
+	fake := clock.NewFake(time.Date(2024, 1, 10, 1, 0, 0, 0, time.UTC))
+
+	// 20:00 in New York: the notification is queued, then the process stops
+	task, mockNotifier := businessHoursTask(t, fake, "queue")
+	task.LoadState(store)
+	require.NoError(t, task.Run(context.Background()))
+	assert.Equal(t, []string{"Stale PR: Stale in tokyo"}, sentSubjects(mockNotifier))
+	assert.Len(t, task.queued["acme/nyc"], 1)
+
+	// 09:30 in New York, after a restart: the lost queued notification is sent, while the
+	// delivered one keeps its cooldown
+	fake.Set(time.Date(2024, 1, 10, 14, 30, 0, 0, time.UTC))
+	restarted, restartedNotifier := businessHoursTask(t, fake, "queue")
+	restarted.LoadState(store)
+	require.NoError(t, restarted.Run(context.Background()))
+	assert.Equal(t, []string{"Stale PR: Stale in nyc"}, sentSubjects(restartedNotifier))
+}
+
+func TestPRReviewCheckTask_Run_ResetCooldownOnUpdate(t *testing.T) {
+	for _, reset := range []bool{true, false} {
+		t.Run(fmt.Sprintf("reset=%v", reset), func(t *testing.T) {
+			fake := clock.NewFake(time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC))
+			cfg := config.GitHubConfig{
+				StaleDays:             4,
+				NotificationCooldown:  "24h",
+				ResetCooldownOnUpdate: reset,
+				Repositories: []config.RepositoryConfig{
+					// Staleness is measured from creation, so an updated PR stays stale
+					{Owner: "testowner", Repo: "testrepo", StaleMetric: config.StaleMetricCreated},
+				},
+			}
+
+			// The mock returns this slice on every call, so updating the PR in it is seen by later runs
+			prs := []api.PullRequest{{
+				Number:    123,
+				Title:     "Old PR",
+				User:      api.User{Login: "testuser"},
+				CreatedAt: fake.Now().Add(-10 * 24 * time.Hour),
+				UpdatedAt: fake.Now().Add(-2 * time.Hour),
+				Head:      api.PRHead{SHA: "sha123"},
+			}}
+
+			mockAPI := &MockGitHubClient{}
+			mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return(prs, nil)
+			mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CommitStatus{State: "success"}, nil)
+			mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CheckSuitesResponse{TotalCount: 0}, nil)
+			mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", mock.Anything).Return([]api.Review{}, nil)
+
+			mockNotifier := &MockNotifier{}
+			mockNotifier.On("SendNotification", mock.Anything, "Stale PR: Old PR", mock.Anything).Return(nil)
+
+			task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+			task.apiClient = mockAPI
+			task.Clock = fake
+
+			require.NoError(t, task.Run(context.Background()))
+			mockNotifier.AssertNumberOfCalls(t, "SendNotification", 1)
+
+			// No activity on the PR: the cooldown holds
+			fake.Advance(time.Hour)
+			require.NoError(t, task.Run(context.Background()))
+			mockNotifier.AssertNumberOfCalls(t, "SendNotification", 1)
+
+			// A new commit updates the PR within the cooldown
+			prs[0].UpdatedAt = fake.Now()
+			fake.Advance(time.Hour)
+			require.NoError(t, task.Run(context.Background()))
+			if !reset {
+				mockNotifier.AssertNumberOfCalls(t, "SendNotification", 1)
+				return
+			}
+			mockNotifier.AssertNumberOfCalls(t, "SendNotification", 2)
+
+			// The re-alert starts a new cooldown for the updated PR
+			fake.Advance(time.Hour)
+			require.NoError(t, task.Run(context.Background()))
+			mockNotifier.AssertNumberOfCalls(t, "SendNotification", 2)
+		})
+	}
+}
+
+func TestPRReviewCheckTask_LoadState_NotifiedUpdate(t *testing.T) {
+	store := state.NewFileStore(filepath.Join(t.TempDir(), "state.json"))
+	updatedAt := time.Date(2024, 1, 9, 10, 0, 0, 0, time.UTC)
+
+	task := NewPRReviewCheckTask(config.GitHubConfig{}, &MockNotifier{}, "")
+	task.LoadState(store)
+	task.lastNotificationTime["owner/repo#1"] = time.Now()
+	task.lastNotifiedUpdate["owner/repo#1"] = updatedAt
+	task.lastNotifiedUpdate["owner/repo#2"] = updatedAt // no cooldown left, dropped on save
+	require.NoError(t, task.Run(context.Background()))
+
+	restarted := NewPRReviewCheckTask(config.GitHubConfig{}, &MockNotifier{}, "")
+	restarted.LoadState(state.NewFileStore(store.Path))
+	assert.Equal(t, map[string]time.Time{"owner/repo#1": updatedAt}, restarted.lastNotifiedUpdate)
+}
+
+// memoryStateStore is a state.StateStore kept in memory, standing in for a non-file backend.
+type memoryStateStore struct {
+	mu   sync.Mutex
+	data map[string]map[string]time.Time
+}
+
+func (s *memoryStateStore) Load(namespace string) map[string]time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := make(map[string]time.Time, len(s.data[namespace]))
+	for key, value := range s.data[namespace] {
+		entries[key] = value
+	}
+	return entries
+}
+
+func (s *memoryStateStore) Save(namespace string, entries map[string]time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.data == nil {
+		s.data = make(map[string]map[string]time.Time)
+	}
+	s.data[namespace] = entries
+	return nil
+}
+
+func TestPRReviewCheckTask_LoadState_MemoryStore(t *testing.T) {
+	store := &memoryStateStore{}
+	notifiedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	task := NewPRReviewCheckTask(config.GitHubConfig{}, &MockNotifier{}, "")
+	task.LoadState(store)
+	task.lastNotificationTime["owner/repo#1"] = notifiedAt
+	require.NoError(t, task.Run(context.Background()))
+	assert.Equal(t, map[string]time.Time{"owner/repo#1": notifiedAt}, store.Load(prStateNamespace))
+
+	restarted := NewPRReviewCheckTask(config.GitHubConfig{}, &MockNotifier{}, "")
+	restarted.LoadState(store)
+	assert.Equal(t, map[string]time.Time{"owner/repo#1": notifiedAt}, restarted.lastNotificationTime)
+}
+
+func TestPRReviewCheckTask_LoadState_NilStore(t *testing.T) {
+	task := NewPRReviewCheckTask(config.GitHubConfig{}, &MockNotifier{}, "")
+	task.LoadState(nil)
+	task.lastNotificationTime["owner/repo#1"] = time.Now()
+
+	// Without a store cooldowns are kept in memory only
+	require.NoError(t, task.Run(context.Background()))
+	assert.Len(t, task.lastNotificationTime, 1)
+}
+
+func TestCleanupNotificationTimes_UsesGivenTime(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	entries := map[string]time.Time{
+		"old":    now.Add(-7*24*time.Hour - time.Second),
+		"recent": now.Add(-7 * 24 * time.Hour),
+	}
+
+	cleanupNotificationTimes(entries, time.Hour, now)
+
+	assert.NotContains(t, entries, "old")
+	assert.Contains(t, entries, "recent")
+}
+
+func TestPRReviewCheckTask_Run_StalePR_NotificationTags(t *testing.T) {
+	cfg := config.GitHubConfig{
+		StaleDays: 4,
+		Tags:      []string{"dev"},
+		Repositories: []config.RepositoryConfig{
+			{Owner: "testowner", Repo: "testrepo"},
+		},
+	}
+
+	stalePR := api.PullRequest{
+		Number:    123,
+		Title:     "Stale PR",
+		User:      api.User{Login: "testuser"},
+		UpdatedAt: time.Now().Add(-5 * 24 * time.Hour),
+		Head:      api.PRHead{SHA: "sha123"},
+	}
+
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{stalePR}, nil)
+	mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CommitStatus{State: "success"}, nil)
+	mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CheckSuitesResponse{}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", 123).Return([]api.Review{}, nil)
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.MatchedBy(func(ctx context.Context) bool {
+		tags := notifier.TagsFromContext(ctx)
+		return len(tags) == 1 && tags[0] == "dev"
+	}), "Stale PR: Stale PR", mock.Anything).Return(nil)
+
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+	task.apiClient = mockAPI
+
+	require.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertExpectations(t)
+}
+
+func TestPRReviewCheckTask_Run_StalePR_MessagePrefixAndSuffix(t *testing.T) {
+	cfg := config.GitHubConfig{
+		StaleDays: 4,
+		Repositories: []config.RepositoryConfig{
+			{Owner: "testowner", Repo: "testrepo"},
+		},
+	}
+
+	stalePR := api.PullRequest{
+		Number:    123,
+		Title:     "Stale PR",
+		User:      api.User{Login: "testuser"},
+		UpdatedAt: time.Now().Add(-5 * 24 * time.Hour),
+		Head:      api.PRHead{SHA: "sha123"},
+	}
+
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{stalePR}, nil)
+	mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CommitStatus{State: "success"}, nil)
+	mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CheckSuitesResponse{}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", 123).Return([]api.Review{}, nil)
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "Stale PR: Stale PR", mock.MatchedBy(func(msg string) bool {
+		return strings.HasPrefix(msg, "[staging]\n") && strings.HasSuffix(msg, "\n-- watchdog") && strings.Contains(msg, "#123")
+	})).Return(nil)
+
+	task := NewPRReviewCheckTask(cfg, notifier.NewPrefixNotifier(mockNotifier, "[staging]", "-- watchdog"), "")
+	task.apiClient = mockAPI
+
+	require.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertExpectations(t)
+}
+
+func TestPRReviewCheckTask_Run_StalePR_MessageFormat(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:        "text",
+			format:      "text",
+			contains:    []string{"PR #123 in testowner/testrepo", "Link: https://github.com/testowner/testrepo/pull/123"},
+			notContains: []string{"**", "<b>"},
+		},
+		{
+			name:     "markdown",
+			format:   "markdown",
+			contains: []string{"**PR #123**", "**Last updated:**", "(https://github.com/testowner/testrepo/pull/123)"},
+		},
+		{
+			name:     "html",
+			format:   "html",
+			contains: []string{"<b>PR #123</b>", `<a href="https://github.com/testowner/testrepo/pull/123">`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.GitHubConfig{
+				StaleDays: 4,
+				Repositories: []config.RepositoryConfig{
+					{Owner: "testowner", Repo: "testrepo"},
+				},
+			}
+
+			stalePR := api.PullRequest{
+				Number:    123,
+				Title:     "Stale PR",
+				User:      api.User{Login: "testuser"},
+				UpdatedAt: time.Now().Add(-5 * 24 * time.Hour),
+				HTMLURL:   "https://github.com/testowner/testrepo/pull/123",
+				Head:      api.PRHead{SHA: "sha123"},
+			}
+
+			mockAPI := &MockGitHubClient{}
+			mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{stalePR}, nil)
+			mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CommitStatus{State: "success"}, nil)
+			mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CheckSuitesResponse{TotalCount: 0}, nil)
+			mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", mock.Anything).Return([]api.Review{}, nil)
+
+			var sent string
+			mockNotifier := &MockNotifier{}
+			mockNotifier.On("SendNotification", mock.Anything, "Stale PR: Stale PR", mock.Anything).
+				Run(func(args mock.Arguments) { sent = args.String(2) }).
+				Return(nil)
+
+			task := NewPRReviewCheckTask(cfg, mockNotifier, tt.format)
+			task.apiClient = mockAPI
+
+			err := task.Run(context.Background())
+
+			require.NoError(t, err)
+			for _, s := range tt.contains {
+				assert.Contains(t, sent, s)
+			}
+			for _, s := range tt.notContains {
+				assert.NotContains(t, sent, s)
+			}
+		})
+	}
+}
+
+func TestPRReviewCheckTask_Run_StalePR_LastUpdatedFormat(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	stalePR := api.PullRequest{
+		Number:    123,
+		Title:     "Stale PR",
+		User:      api.User{Login: "testuser"},
+		UpdatedAt: now.Add(-5 * 24 * time.Hour),
+		HTMLURL:   "https://github.com/testowner/testrepo/pull/123",
+		Head:      api.PRHead{SHA: "sha123"},
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: "", want: "Last updated: Tue, 05 Mar 2024 12:00:00 UTC\n"},
+		{format: "relative", want: "Last updated: 5 days ago\n"},
+		{format: "both", want: "Last updated: Tue, 05 Mar 2024 12:00:00 UTC (5 days ago)\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			cfg := config.GitHubConfig{
+				StaleDays:         4,
+				LastUpdatedFormat: tt.format,
+				Repositories:      []config.RepositoryConfig{{Owner: "testowner", Repo: "testrepo"}},
+			}
+
+			mockAPI := &MockGitHubClient{}
+			mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{stalePR}, nil)
+			mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CommitStatus{State: "success"}, nil)
+			mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CheckSuitesResponse{}, nil)
+			mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", mock.Anything).Return([]api.Review{}, nil)
+
+			var sent string
+			mockNotifier := &MockNotifier{}
+			mockNotifier.On("SendNotification", mock.Anything, "Stale PR: Stale PR", mock.Anything).
+				Run(func(args mock.Arguments) { sent = args.String(2) }).
+				Return(nil)
+
+			task := NewPRReviewCheckTask(cfg, mockNotifier, "text")
+			task.apiClient = mockAPI
+			task.Clock = clock.NewFake(now)
+
+			require.NoError(t, task.Run(context.Background()))
+			assert.Contains(t, sent, tt.want)
+		})
+	}
+}
+
+func TestPRReviewCheckTask_Run_MergeConflicts(t *testing.T) {
+	tests := []struct {
+		name           string
+		mode           string
+		mergeableState string
+		wantSubject    string // empty: no notification
+	}{
+		{name: "skip dirty", mode: "skip", mergeableState: "dirty"},
+		{name: "skip clean", mode: "skip", mergeableState: "clean", wantSubject: "Stale PR: Stale PR"},
+		{name: "label dirty", mode: "label", mergeableState: "dirty", wantSubject: "Stale PR: Stale PR (has conflicts)"},
+		{name: "label clean", mode: "label", mergeableState: "clean", wantSubject: "Stale PR: Stale PR"},
+		{name: "notify", mode: "", wantSubject: "Stale PR: Stale PR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.GitHubConfig{
+				StaleDays:      4,
+				MergeConflicts: tt.mode,
+				Repositories:   []config.RepositoryConfig{{Owner: "testowner", Repo: "testrepo"}},
+			}
+			stalePR := api.PullRequest{
+				Number:    123,
+				Title:     "Stale PR",
+				User:      api.User{Login: "testuser"},
+				UpdatedAt: time.Now().Add(-5 * 24 * time.Hour),
+				HTMLURL:   "https://github.com/testowner/testrepo/pull/123",
+				Head:      api.PRHead{SHA: "sha123"},
+			}
+			details := stalePR
+			details.MergeableState = tt.mergeableState
+
+			mockAPI := &MockGitHubClient{}
+			mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{stalePR}, nil)
+			if tt.mode != "" {
+				mockAPI.On("GetPullRequest", mock.Anything, "testowner", "testrepo", 123).Return(&details, nil).Once()
+			}
+			mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CommitStatus{State: "success"}, nil)
+			mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CheckSuitesResponse{}, nil)
+			mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", 123).Return([]api.Review{}, nil)
+
+			var subjects, bodies []string
+			mockNotifier := &MockNotifier{}
+			mockNotifier.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					subjects = append(subjects, args.String(1))
+					bodies = append(bodies, args.String(2))
+				}).
+				Return(nil)
+
+			task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+			task.apiClient = mockAPI
+
+			require.NoError(t, task.Run(context.Background()))
+			if tt.mode == "" {
+				mockAPI.AssertNotCalled(t, "GetPullRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			if tt.wantSubject == "" {
+				assert.Empty(t, subjects)
+				return
+			}
+			assert.Equal(t, []string{tt.wantSubject}, subjects)
+			if tt.mergeableState == "dirty" {
+				assert.Contains(t, bodies[0], "PR #123 (has conflicts) in testowner/testrepo")
+			} else {
+				assert.NotContains(t, bodies[0], "has conflicts")
+			}
+		})
+	}
+}
+
+func TestPRReviewCheckTask_Run_MergeConflicts_LookupError(t *testing.T) {
+	cfg := config.GitHubConfig{
+		StaleDays:      4,
+		MergeConflicts: "skip",
+		Repositories:   []config.RepositoryConfig{{Owner: "testowner", Repo: "testrepo"}},
+	}
+	stalePR := api.PullRequest{
+		Number:    123,
+		Title:     "Stale PR",
+		UpdatedAt: time.Now().Add(-5 * 24 * time.Hour),
+		Head:      api.PRHead{SHA: "sha123"},
+	}
+
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{stalePR}, nil)
+	mockAPI.On("GetPullRequest", mock.Anything, "testowner", "testrepo", 123).Return(nil, errors.New("API error"))
+	mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CommitStatus{State: "success"}, nil)
+	mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CheckSuitesResponse{}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", 123).Return([]api.Review{}, nil)
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "Stale PR: Stale PR", mock.Anything).Return(nil).Once()
+
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+	task.apiClient = mockAPI
+
+	// Without knowing about conflicts the PR is notified about as usual
+	require.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertExpectations(t)
+}
+
+func TestFormatDiffstat(t *testing.T) {
+	tests := []struct {
+		additions, deletions, changedFiles int
+		want                               string
+	}{
+		{additions: 120, deletions: 30, changedFiles: 4, want: "+120/-30, 4 files"},
+		{additions: 1, deletions: 0, changedFiles: 1, want: "+1/-0, 1 file"},
+		{additions: 0, deletions: 250, changedFiles: 12, want: "+0/-250, 12 files"},
+		{additions: 15000, deletions: 9876, changedFiles: 300, want: "+15000/-9876, 300 files"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatDiffstat(tt.additions, tt.deletions, tt.changedFiles))
+		})
+	}
+}
+
+func TestPRReviewCheckTask_Run_IncludeDiffstat(t *testing.T) {
+	tests := []struct {
+		name            string
+		includeDiffstat bool
+		format          string
+		digest          bool
+		want            string // empty: no diffstat expected
+	}{
+		{name: "off", includeDiffstat: false},
+		{name: "text", includeDiffstat: true, want: "PR #123 (+120/-30, 4 files) in testowner/testrepo"},
+		{name: "markdown", includeDiffstat: true, format: "markdown", want: "**PR #123** (+120/-30, 4 files) in testowner/testrepo"},
+		{name: "digest", includeDiffstat: true, digest: true, want: "#123 Stale PR (+120/-30, 4 files) by testuser"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.GitHubConfig{
+				StaleDays:       4,
+				IncludeDiffstat: tt.includeDiffstat,
+				Repositories:    []config.RepositoryConfig{{Owner: "testowner", Repo: "testrepo"}},
+			}
+			if tt.digest {
+				cfg.NotifyMode = config.NotifyModeDigest
+			}
+			stalePR := api.PullRequest{
+				Number:    123,
+				Title:     "Stale PR",
+				User:      api.User{Login: "testuser"},
+				UpdatedAt: time.Now().Add(-5 * 24 * time.Hour),
+				HTMLURL:   "https://github.com/testowner/testrepo/pull/123",
+				Head:      api.PRHead{SHA: "sha123"},
+			}
+			details := stalePR
+			details.Additions, details.Deletions, details.ChangedFiles = 120, 30, 4
+			details.MergeableState = "dirty"
+
+			mockAPI := &MockGitHubClient{}
+			mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{stalePR}, nil)
+			mockAPI.On("GetPullRequest", mock.Anything, "testowner", "testrepo", 123).Return(&details, nil).Once()
+			mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CommitStatus{State: "success"}, nil)
+			mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CheckSuitesResponse{}, nil)
+			mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", 123).Return([]api.Review{}, nil)
+
+			var bodies []string
+			mockNotifier := &MockNotifier{}
+			mockNotifier.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) { bodies = append(bodies, args.String(2)) }).
+				Return(nil)
+
+			task := NewPRReviewCheckTask(cfg, mockNotifier, tt.format)
+			task.apiClient = mockAPI
+
+			require.NoError(t, task.Run(context.Background()))
+			require.Len(t, bodies, 1)
+			if tt.want == "" {
+				mockAPI.AssertNotCalled(t, "GetPullRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				assert.NotContains(t, bodies[0], "files")
+				return
+			}
+			assert.Contains(t, bodies[0], tt.want)
+			// Only the diffstat is taken from the lookup; merge_conflicts is still "notify"
+			assert.NotContains(t, bodies[0], "has conflicts")
+		})
+	}
+}
+
+func TestPRReviewCheckTask_Run_StalePR_ReviewState(t *testing.T) {
+	tests := []struct {
+		name        string
+		reviews     []api.Review
+		reviewsErr  error
+		contains    string
+		notContains string
+	}{
+		{
+			name:     "approved",
+			reviews:  []api.Review{{User: api.User{Login: "alice"}, State: api.ReviewStateApproved}},
+			contains: "Reviews: alice ✅",
+		},
+		{
+			name: "changes requested",
+			reviews: []api.Review{
+				{User: api.User{Login: "alice"}, State: api.ReviewStateApproved},
+				{User: api.User{Login: "bob"}, State: api.ReviewStateChangesRequested},
+			},
+			contains: "Reviews: alice ✅, bob 🔄",
+		},
+		{
+			name:        "no reviews",
+			reviews:     []api.Review{},
+			notContains: "Reviews:",
+		},
+		{
+			name:        "reviews unavailable",
+			reviewsErr:  errors.New("api error"),
+			notContains: "Reviews:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.GitHubConfig{
+				StaleDays: 4,
+				Repositories: []config.RepositoryConfig{
+					{Owner: "testowner", Repo: "testrepo"},
+				},
+			}
+
+			stalePR := api.PullRequest{
+				Number:    123,
+				Title:     "Stale PR",
+				User:      api.User{Login: "testuser"},
+				UpdatedAt: time.Now().Add(-5 * 24 * time.Hour),
+				HTMLURL:   "https://github.com/testowner/testrepo/pull/123",
+				Head:      api.PRHead{SHA: "sha123"},
+			}
+
+			mockAPI := &MockGitHubClient{}
+			mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{stalePR}, nil)
+			mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CommitStatus{State: "success"}, nil)
+			mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CheckSuitesResponse{}, nil)
+			if tt.reviewsErr != nil {
+				mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", 123).Return(nil, tt.reviewsErr)
+			} else {
+				mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", 123).Return(tt.reviews, nil)
+			}
+
+			var sent string
+			mockNotifier := &MockNotifier{}
+			mockNotifier.On("SendNotification", mock.Anything, "Stale PR: Stale PR", mock.Anything).
+				Run(func(args mock.Arguments) { sent = args.String(2) }).
+				Return(nil)
+
+			task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+			task.apiClient = mockAPI
+
+			require.NoError(t, task.Run(context.Background()))
+			mockAPI.AssertExpectations(t)
+			if tt.contains != "" {
+				assert.Contains(t, sent, tt.contains)
+			}
+			if tt.notContains != "" {
+				assert.NotContains(t, sent, tt.notContains)
+			}
+		})
+	}
+}
+
+func TestSummarizeReviews(t *testing.T) {
+	reviews := []api.Review{
+		{User: api.User{Login: "alice"}, State: api.ReviewStateChangesRequested},
+		{User: api.User{Login: "bob"}, State: api.ReviewStateCommented},
+		{User: api.User{Login: "alice"}, State: api.ReviewStateApproved},
+		{User: api.User{Login: "alice"}, State: api.ReviewStateCommented}, // doesn't override the approval
+		{User: api.User{Login: "carol"}, State: api.ReviewStateApproved},
+		{User: api.User{Login: "carol"}, State: api.ReviewStateDismissed},
+		{User: api.User{Login: "dave"}, State: api.ReviewStatePending},
+	}
+
+	assert.Equal(t, "alice ✅, bob 💬", summarizeReviews(reviews))
+	assert.Equal(t, "", summarizeReviews(nil))
+}
+
+func TestPRReviewCheckTask_Run_StaleMetric(t *testing.T) {
+	// Opened 10 days ago but active yesterday
+	pr := api.PullRequest{
+		Number:    42,
+		Title:     "Long running PR",
+		User:      api.User{Login: "testuser"},
+		CreatedAt: time.Now().Add(-10 * 24 * time.Hour),
+		UpdatedAt: time.Now().Add(-1 * 24 * time.Hour),
+		HTMLURL:   "https://github.com/testowner/testrepo/pull/42",
+		Head:      api.PRHead{SHA: "sha42"},
+	}
+
+	tests := []struct {
+		name         string
+		metric       string
+		expectNotify bool
+	}{
+		{name: "default uses updated", metric: "", expectNotify: false},
+		{name: "updated", metric: "updated", expectNotify: false},
+		{name: "created", metric: "created", expectNotify: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.GitHubConfig{
+				StaleDays: 4,
+				Repositories: []config.RepositoryConfig{
+					{Owner: "testowner", Repo: "testrepo", StaleMetric: tt.metric},
+				},
+			}
+
+			mockAPI := &MockGitHubClient{}
+			mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{pr}, nil)
+			mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha42").Return(&api.CommitStatus{State: "success"}, nil).Maybe()
+			mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha42").Return(&api.CheckSuitesResponse{}, nil).Maybe()
+			mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", mock.Anything).Return([]api.Review{}, nil).Maybe()
+
+			mockNotifier := &MockNotifier{}
+			if tt.expectNotify {
+				mockNotifier.On("SendNotification", mock.Anything, "Stale PR: Long running PR", mock.Anything).Return(nil)
+			}
+
+			task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+			task.apiClient = mockAPI
+
+			err := task.Run(context.Background())
+
+			assert.NoError(t, err)
+			if tt.expectNotify {
+				mockNotifier.AssertExpectations(t)
+			} else {
+				mockNotifier.AssertNotCalled(t, "SendNotification", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestPRReviewCheckTask_Run_GracePeriod(t *testing.T) {
+	// Opened an hour ago, but its (rebased) commits make UpdatedAt look old
+	brandNew := api.PullRequest{
+		Number:    7,
+		Title:     "Rebased PR",
+		User:      api.User{Login: "testuser"},
+		CreatedAt: time.Now().Add(-1 * time.Hour),
+		UpdatedAt: time.Now().Add(-10 * 24 * time.Hour),
+		Head:      api.PRHead{SHA: "sha7"},
+	}
+	old := api.PullRequest{
+		Number:    8,
+		Title:     "Old PR",
+		User:      api.User{Login: "testuser"},
+		CreatedAt: time.Now().Add(-10 * 24 * time.Hour),
+		UpdatedAt: time.Now().Add(-10 * 24 * time.Hour),
+		Head:      api.PRHead{SHA: "sha8"},
+	}
+
+	cfg := config.GitHubConfig{
+		StaleDays:    4,
+		GracePeriod:  "12h",
+		Repositories: []config.RepositoryConfig{{Owner: "testowner", Repo: "testrepo"}},
+	}
+
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{brandNew, old}, nil)
+	mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha8").Return(&api.CommitStatus{State: "success"}, nil)
+	mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha8").Return(&api.CheckSuitesResponse{}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", 8).Return([]api.Review{}, nil)
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "Stale PR: Old PR", mock.Anything).Return(nil).Once()
+
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+	task.apiClient = mockAPI
+
+	require.NoError(t, task.Run(context.Background()))
+
+	mockNotifier.AssertExpectations(t)
+	mockNotifier.AssertNotCalled(t, "SendNotification", mock.Anything, "Stale PR: Rebased PR", mock.Anything)
+	mockAPI.AssertNotCalled(t, "GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha7")
+}
+
+func TestPRReviewCheckTask_Run_StaleDuration(t *testing.T) {
+	pr := func(number int, idle time.Duration) api.PullRequest {
+		return api.PullRequest{
+			Number:    number,
+			Title:     fmt.Sprintf("PR %d", number),
+			User:      api.User{Login: "testuser"},
+			CreatedAt: time.Now().Add(-idle),
+			UpdatedAt: time.Now().Add(-idle),
+			Head:      api.PRHead{SHA: fmt.Sprintf("sha%d", number)},
+		}
+	}
+
+	// stale_duration overrides stale_days: a 12h threshold alerts well before 7 days
+	cfg := config.GitHubConfig{
+		StaleDays:     7,
+		StaleDuration: "12h",
+		Repositories:  []config.RepositoryConfig{{Owner: "testowner", Repo: "testrepo"}},
+	}
+
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{pr(1, 6*time.Hour), pr(2, 13*time.Hour)}, nil)
+	mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha2").Return(&api.CommitStatus{State: "success"}, nil)
+	mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha2").Return(&api.CheckSuitesResponse{}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", 2).Return([]api.Review{}, nil)
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "Stale PR: PR 2", mock.Anything).Return(nil).Once()
+
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+	task.apiClient = mockAPI
+
+	require.NoError(t, task.Run(context.Background()))
+
+	mockNotifier.AssertExpectations(t)
+	mockNotifier.AssertNotCalled(t, "SendNotification", mock.Anything, "Stale PR: PR 1", mock.Anything)
+}
+
+func TestPRReviewCheckTask_Run_LabelFilters(t *testing.T) {
+	labeled := func(number int, labels ...string) api.PullRequest {
+		pr := api.PullRequest{
+			Number:    number,
+			Title:     fmt.Sprintf("PR %d", number),
+			User:      api.User{Login: "testuser"},
+			UpdatedAt: time.Now().Add(-5 * 24 * time.Hour),
+			Head:      api.PRHead{SHA: fmt.Sprintf("sha%d", number)},
+		}
+		for _, l := range labels {
+			pr.Labels = append(pr.Labels, api.Label{Name: l})
+		}
+		return pr
+	}
+
+	prs := []api.PullRequest{
+		labeled(1, "needs-review"),
+		labeled(2, "wip"),
+		labeled(3, "Needs-Review", "on-hold"),
+		labeled(4),
+	}
+
+	tests := []struct {
+		name     string
+		include  []string
+		exclude  []string
+		expected []string
+	}{
+		{
+			name:     "no filters",
+			expected: []string{"PR 1", "PR 2", "PR 3", "PR 4"},
+		},
+		{
+			name:     "include only",
+			include:  []string{"needs-review"},
+			expected: []string{"PR 1", "PR 3"},
+		},
+		{
+			name:     "exclude only",
+			exclude:  []string{"wip", "on-hold"},
+			expected: []string{"PR 1", "PR 4"},
+		},
+		{
+			name:     "exclude takes precedence over include",
+			include:  []string{"needs-review"},
+			exclude:  []string{"on-hold"},
+			expected: []string{"PR 1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.GitHubConfig{
+				StaleDays: 4,
+				Repositories: []config.RepositoryConfig{
+					{Owner: "testowner", Repo: "testrepo", IncludeLabels: tt.include, ExcludeLabels: tt.exclude},
+				},
+			}
+
+			mockAPI := &MockGitHubClient{}
+			mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return(prs, nil)
+			mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", mock.Anything).Return(&api.CommitStatus{State: "success"}, nil)
+			mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", mock.Anything).Return(&api.CheckSuitesResponse{}, nil)
+			mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", mock.Anything).Return([]api.Review{}, nil)
+
+			var notified []string
+			mockNotifier := &MockNotifier{}
+			mockNotifier.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					notified = append(notified, strings.TrimPrefix(args.String(1), "Stale PR: "))
+				}).
+				Return(nil)
+
+			task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+			task.apiClient = mockAPI
+
+			err := task.Run(context.Background())
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, notified)
+		})
+	}
+}
+
+func TestPRReviewCheckTask_Run_ExportsStalePRMetric(t *testing.T) {
+	cfg := config.GitHubConfig{
+		StaleDays: 4,
+		Repositories: []config.RepositoryConfig{
+			{Owner: "metricsowner", Repo: "metricsrepo"},
+		},
+	}
+
+	prs := []api.PullRequest{
+		{Number: 1, Title: "Stale 1", UpdatedAt: time.Now().Add(-5 * 24 * time.Hour), Head: api.PRHead{SHA: "a"}},
+		{Number: 2, Title: "Stale 2", UpdatedAt: time.Now().Add(-6 * 24 * time.Hour), Head: api.PRHead{SHA: "b"}},
+		{Number: 3, Title: "Fresh", UpdatedAt: time.Now(), Head: api.PRHead{SHA: "c"}},
+	}
+
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "metricsowner", "metricsrepo").Return(prs, nil)
+	mockAPI.On("GetCommitStatus", mock.Anything, "metricsowner", "metricsrepo", mock.Anything).Return(&api.CommitStatus{State: "success"}, nil)
+	mockAPI.On("GetCheckSuites", mock.Anything, "metricsowner", "metricsrepo", mock.Anything).Return(&api.CheckSuitesResponse{}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "metricsowner", "metricsrepo", mock.Anything).Return([]api.Review{}, nil)
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+	task.apiClient = mockAPI
+
+	require.NoError(t, task.Run(context.Background()))
+
+	server := httptest.NewServer(metrics.Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(body), `watchdog_stale_prs{repository="metricsowner/metricsrepo"} 2`)
+	assert.Contains(t, string(body), `watchdog_task_run_duration_seconds_count{task="github-pr-review"}`)
+}
+
+func TestPRReviewCheckTask_Run_StalePRMetricDropsUncheckedRepos(t *testing.T) {
+	cfg := config.GitHubConfig{
+		StaleDays:  4,
+		NotifyMode: config.NotifyModeDigest,
+		Repositories: []config.RepositoryConfig{
+			{Owner: "gaugeowner", Repo: "kept"},
+			{Owner: "gaugeowner", Repo: "failing"},
+			{Owner: "gaugeowner", Repo: "removed"},
+		},
+	}
+	stale := []api.PullRequest{{Number: 1, Title: "Stale", UpdatedAt: time.Now().Add(-5 * 24 * time.Hour)}}
+
+	fetchErr := errors.New("connection reset")
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "gaugeowner", "kept").Return(stale, nil)
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "gaugeowner", "failing").Return(stale, nil).Once()
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "gaugeowner", "failing").Return(nil, fetchErr)
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "gaugeowner", "removed").Return(stale, nil)
+	mockAPI.On("GetCommitStatus", mock.Anything, "gaugeowner", mock.Anything, mock.Anything).Return(&api.CommitStatus{State: "success"}, nil)
+	mockAPI.On("GetCheckSuites", mock.Anything, "gaugeowner", mock.Anything, mock.Anything).Return(&api.CheckSuitesResponse{}, nil)
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+	task.apiClient = mockAPI
+
+	require.NoError(t, task.Run(context.Background()))
+	body := scrapeMetrics(t)
+	assert.Contains(t, body, `watchdog_stale_prs{repository="gaugeowner/failing"} 1`)
+	assert.Contains(t, body, `watchdog_stale_prs{repository="gaugeowner/removed"} 1`)
+
+	// The next run no longer checks "removed" and can't fetch "failing"
+	task.config.Repositories = task.config.Repositories[:2]
+	require.NoError(t, task.Run(context.Background()))
+
+	body = scrapeMetrics(t)
+	assert.Contains(t, body, `watchdog_stale_prs{repository="gaugeowner/kept"} 1`)
+	assert.NotContains(t, body, "gaugeowner/failing")
+	assert.NotContains(t, body, "gaugeowner/removed")
+}
+
+// scrapeMetrics returns what the metrics endpoint currently serves.
+func scrapeMetrics(t *testing.T) string {
+	server := httptest.NewServer(metrics.Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
 func TestPRReviewCheckTask_Run_StalePR_WithRequestedReviewers(t *testing.T) {
 	cfg := config.GitHubConfig{
 		StaleDays: 4,
@@ -166,17 +1341,60 @@ func TestPRReviewCheckTask_Run_StalePR_WithRequestedReviewers(t *testing.T) {
 	mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{stalePR}, nil)
 	mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CommitStatus{State: "success"}, nil)
 	mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CheckSuitesResponse{TotalCount: 0}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", mock.Anything).Return([]api.Review{}, nil)
 
 	mockNotifier := &MockNotifier{}
 	mockNotifier.On("SendNotification", mock.Anything, "Stale PR: Stale PR", mock.MatchedBy(func(msg string) bool {
-		// Reviewer "Waiting on" list should be REMOVED
-		return !strings.Contains(msg, "Waiting on: alice, bob")
+		// Requested reviewers are listed by default (include_reviewers defaults to true)
+		return strings.Contains(msg, "Waiting on: alice, bob")
 	})).Return(nil)
 
-	task := NewPRReviewCheckTask(cfg, mockNotifier)
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
 	task.apiClient = mockAPI
 
-	err := task.Run()
+	err := task.Run(context.Background())
+	assert.NoError(t, err)
+	mockNotifier.AssertExpectations(t)
+}
+
+func TestPRReviewCheckTask_Run_StalePR_IncludeReviewersDisabled(t *testing.T) {
+	includeReviewers := false
+	cfg := config.GitHubConfig{
+		StaleDays:        4,
+		IncludeReviewers: &includeReviewers,
+		Repositories: []config.RepositoryConfig{
+			{Owner: "testowner", Repo: "testrepo"},
+		},
+	}
+
+	stalePR := api.PullRequest{
+		Number:    123,
+		Title:     "Stale PR",
+		User:      api.User{Login: "testuser"},
+		UpdatedAt: time.Now().Add(-5 * 24 * time.Hour),
+		RequestedReviewers: []api.User{
+			{Login: "alice"},
+			{Login: "bob"},
+		},
+		HTMLURL: "http://github.com/pr/123",
+		Head:    api.PRHead{SHA: "sha123"},
+	}
+
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{stalePR}, nil)
+	mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CommitStatus{State: "success"}, nil)
+	mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CheckSuitesResponse{TotalCount: 0}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", mock.Anything).Return([]api.Review{}, nil)
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "Stale PR: Stale PR", mock.MatchedBy(func(msg string) bool {
+		return !strings.Contains(msg, "Waiting on:")
+	})).Return(nil)
+
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+	task.apiClient = mockAPI
+
+	err := task.Run(context.Background())
 	assert.NoError(t, err)
 	mockNotifier.AssertExpectations(t)
 }
@@ -204,17 +1422,19 @@ func TestPRReviewCheckTask_Run_StalePR_NoRequestedReviewers(t *testing.T) {
 	mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{stalePR}, nil)
 	mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CommitStatus{State: "success"}, nil)
 	mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CheckSuitesResponse{TotalCount: 0}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", mock.Anything).Return([]api.Review{}, nil)
 
 	mockNotifier := &MockNotifier{}
 	mockNotifier.On("SendNotification", mock.Anything, "Stale PR: Stale PR", mock.MatchedBy(func(msg string) bool {
-		// "No specific reviewers" message should be REMOVED
-		return !strings.Contains(msg, "No specific reviewers requested")
+		// With no requested reviewers the line is omitted entirely
+		return !strings.Contains(msg, "No specific reviewers requested") &&
+			!strings.Contains(msg, "Waiting on:")
 	})).Return(nil)
 
-	task := NewPRReviewCheckTask(cfg, mockNotifier)
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
 	task.apiClient = mockAPI
 
-	err := task.Run()
+	err := task.Run(context.Background())
 	assert.NoError(t, err)
 	mockNotifier.AssertExpectations(t)
 }
@@ -241,10 +1461,10 @@ func TestPRReviewCheckTask_Run_FreshPR_NoNotification(t *testing.T) {
 
 	mockNotifier := &MockNotifier{}
 
-	task := NewPRReviewCheckTask(cfg, mockNotifier)
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
 	task.apiClient = mockAPI
 
-	err := task.Run()
+	err := task.Run(context.Background())
 
 	assert.NoError(t, err)
 	mockAPI.AssertExpectations(t)
@@ -274,13 +1494,166 @@ func TestPRReviewCheckTask_Run_DraftPR_Skipped(t *testing.T) {
 
 	mockNotifier := &MockNotifier{}
 
-	task := NewPRReviewCheckTask(cfg, mockNotifier)
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+	task.apiClient = mockAPI
+
+	err := task.Run(context.Background())
+
+	assert.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+	mockNotifier.AssertNotCalled(t, "SendNotification", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPRReviewCheckTask_Run_DraftPR_MonitorDrafts(t *testing.T) {
+	cfg := config.GitHubConfig{
+		StaleDays:     4,
+		MonitorDrafts: true,
+		Repositories: []config.RepositoryConfig{
+			{Owner: "testowner", Repo: "testrepo"},
+		},
+	}
+
+	draftPR := api.PullRequest{
+		Number:    123,
+		Title:     "Draft PR",
+		User:      api.User{Login: "testuser"},
+		HTMLURL:   "https://github.com/testowner/testrepo/pull/123",
+		UpdatedAt: time.Now().Add(-10 * 24 * time.Hour),
+		Draft:     true,
+		Head:      api.PRHead{SHA: "sha123"},
+	}
+
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{draftPR}, nil)
+	mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CommitStatus{State: "success"}, nil)
+	mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CheckSuitesResponse{}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", 123).Return([]api.Review{}, nil)
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "Stale PR: Draft PR (draft)", mock.MatchedBy(func(msg string) bool {
+		return strings.HasPrefix(msg, "PR #123 (draft) in testowner/testrepo by testuser is pending review.")
+	})).Return(nil).Once()
+
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+	task.apiClient = mockAPI
+
+	require.NoError(t, task.Run(context.Background()))
+
+	mockNotifier.AssertExpectations(t)
+}
+
+func TestPRReviewCheckTask_Run_DigestMode_LabelsDrafts(t *testing.T) {
+	cfg := config.GitHubConfig{
+		StaleDays:     4,
+		MonitorDrafts: true,
+		NotifyMode:    config.NotifyModeDigest,
+		Repositories:  []config.RepositoryConfig{{Owner: "testowner", Repo: "testrepo"}},
+	}
+
+	prs := []api.PullRequest{
+		{Number: 1, Title: "Ready", User: api.User{Login: "alice"}, UpdatedAt: time.Now().Add(-10 * 24 * time.Hour), Head: api.PRHead{SHA: "sha1"}},
+		{Number: 2, Title: "WIP", User: api.User{Login: "bob"}, UpdatedAt: time.Now().Add(-10 * 24 * time.Hour), Draft: true, Head: api.PRHead{SHA: "sha2"}},
+	}
+
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return(prs, nil)
+	mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", mock.Anything).Return(&api.CommitStatus{State: "success"}, nil)
+	mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", mock.Anything).Return(&api.CheckSuitesResponse{}, nil)
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "2 stale PRs in testowner/testrepo", mock.MatchedBy(func(msg string) bool {
+		return strings.Contains(msg, "- #1 Ready by alice") && strings.Contains(msg, "- #2 WIP (draft) by bob")
+	})).Return(nil).Once()
+
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+	task.apiClient = mockAPI
+
+	require.NoError(t, task.Run(context.Background()))
+
+	mockNotifier.AssertExpectations(t)
+}
+
+func TestPRReviewCheckTask_Run_OnlyRequestedFor(t *testing.T) {
+	stale := func(number int, reviewers ...string) api.PullRequest {
+		pr := api.PullRequest{
+			Number:    number,
+			Title:     fmt.Sprintf("PR %d", number),
+			User:      api.User{Login: "author"},
+			UpdatedAt: time.Now().Add(-10 * 24 * time.Hour),
+			Head:      api.PRHead{SHA: fmt.Sprintf("sha%d", number)},
+		}
+		for _, r := range reviewers {
+			pr.RequestedReviewers = append(pr.RequestedReviewers, api.User{Login: r})
+		}
+		return pr
+	}
+
+	tests := []struct {
+		name             string
+		onlyRequestedFor string
+		me               string
+	}{
+		{name: "username", onlyRequestedFor: "alice"},
+		{name: "case-insensitive username", onlyRequestedFor: "Alice"},
+		{name: "authenticated user", onlyRequestedFor: "@me", me: "alice"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.GitHubConfig{
+				StaleDays:        4,
+				OnlyRequestedFor: tt.onlyRequestedFor,
+				Repositories:     []config.RepositoryConfig{{Owner: "testowner", Repo: "testrepo"}},
+			}
+
+			mockAPI := &MockGitHubClient{}
+			if tt.me != "" {
+				mockAPI.On("GetAuthenticatedUser", mock.Anything).Return(&api.User{Login: tt.me}, nil).Once()
+			}
+			mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{
+				stale(1, "bob", "alice"), // alice is a requested reviewer
+				stale(2, "bob"),          // alice isn't
+				stale(3),                 // nobody is
+			}, nil)
+			mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha1").Return(&api.CommitStatus{State: "success"}, nil)
+			mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha1").Return(&api.CheckSuitesResponse{}, nil)
+			mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", 1).Return([]api.Review{}, nil)
+
+			mockNotifier := &MockNotifier{}
+			mockNotifier.On("SendNotification", mock.Anything, "Stale PR: PR 1", mock.Anything).Return(nil).Once()
+
+			task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+			task.apiClient = mockAPI
+
+			require.NoError(t, task.Run(context.Background()))
+			// "@me" is resolved once, not on every run
+			task.lastNotificationTime = map[string]time.Time{"testowner/testrepo#1": time.Now()}
+			require.NoError(t, task.Run(context.Background()))
+
+			mockNotifier.AssertExpectations(t)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPRReviewCheckTask_Run_OnlyRequestedFor_UnresolvedSkipsRun(t *testing.T) {
+	cfg := config.GitHubConfig{
+		StaleDays:        4,
+		OnlyRequestedFor: "@me",
+		Repositories:     []config.RepositoryConfig{{Owner: "testowner", Repo: "testrepo"}},
+	}
+
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetAuthenticatedUser", mock.Anything).Return(nil, errors.New("401 Unauthorized"))
+
+	mockNotifier := &MockNotifier{}
+
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
 	task.apiClient = mockAPI
 
-	err := task.Run()
+	require.ErrorContains(t, task.Run(context.Background()), "401 Unauthorized")
 
-	assert.NoError(t, err)
-	mockAPI.AssertExpectations(t)
+	mockAPI.AssertNotCalled(t, "GetOpenPullRequests", mock.Anything, mock.Anything, mock.Anything)
 	mockNotifier.AssertNotCalled(t, "SendNotification", mock.Anything, mock.Anything, mock.Anything)
 }
 
@@ -309,14 +1682,15 @@ func TestPRReviewCheckTask_Run_AuthorFilter_Matches(t *testing.T) {
 	mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{stalePR}, nil)
 	mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CommitStatus{State: "success"}, nil)
 	mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CheckSuitesResponse{TotalCount: 0}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", mock.Anything).Return([]api.Review{}, nil)
 
 	mockNotifier := &MockNotifier{}
 	mockNotifier.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-	task := NewPRReviewCheckTask(cfg, mockNotifier)
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
 	task.apiClient = mockAPI
 
-	err := task.Run()
+	err := task.Run(context.Background())
 
 	assert.NoError(t, err)
 	mockNotifier.AssertExpectations(t)
@@ -347,10 +1721,10 @@ func TestPRReviewCheckTask_Run_AuthorFilter_NoMatch(t *testing.T) {
 
 	mockNotifier := &MockNotifier{}
 
-	task := NewPRReviewCheckTask(cfg, mockNotifier)
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
 	task.apiClient = mockAPI
 
-	err := task.Run()
+	err := task.Run(context.Background())
 
 	assert.NoError(t, err)
 	mockNotifier.AssertNotCalled(t, "SendNotification", mock.Anything, mock.Anything, mock.Anything)
@@ -381,19 +1755,447 @@ func TestPRReviewCheckTask_Run_AuthorFilter_CaseInsensitive(t *testing.T) {
 	mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{stalePR}, nil)
 	mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CommitStatus{State: "success"}, nil)
 	mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CheckSuitesResponse{TotalCount: 0}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", mock.Anything).Return([]api.Review{}, nil)
 
 	mockNotifier := &MockNotifier{}
 	mockNotifier.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-	task := NewPRReviewCheckTask(cfg, mockNotifier)
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
 	task.apiClient = mockAPI
 
-	err := task.Run()
+	err := task.Run(context.Background())
 
 	assert.NoError(t, err)
 	mockNotifier.AssertExpectations(t)
 }
 
+func TestPRReviewCheckTask_Run_AssigneeFilter(t *testing.T) {
+	cfg := config.GitHubConfig{
+		StaleDays: 4,
+		Repositories: []config.RepositoryConfig{
+			{Owner: "testowner", Repo: "testrepo", Assignees: []string{"Reviewer1"}},
+		},
+	}
+
+	assigned := api.PullRequest{
+		Number:    1,
+		Title:     "Assigned PR",
+		User:      api.User{Login: "someone"},
+		Assignees: []api.User{{Login: "other"}, {Login: "reviewer1"}},
+		UpdatedAt: time.Now().Add(-5 * 24 * time.Hour),
+		Head:      api.PRHead{SHA: "sha1"},
+	}
+	unassigned := api.PullRequest{
+		Number:    2,
+		Title:     "Unassigned PR",
+		User:      api.User{Login: "someone"},
+		UpdatedAt: time.Now().Add(-5 * 24 * time.Hour),
+		Head:      api.PRHead{SHA: "sha2"},
+	}
+
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{assigned, unassigned}, nil)
+	mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha1").Return(&api.CommitStatus{State: "success"}, nil)
+	mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha1").Return(&api.CheckSuitesResponse{}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", 1).Return([]api.Review{}, nil)
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "Stale PR: Assigned PR", mock.Anything).Return(nil).Once()
+
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+	task.apiClient = mockAPI
+
+	require.NoError(t, task.Run(context.Background()))
+
+	mockNotifier.AssertExpectations(t)
+	mockNotifier.AssertNotCalled(t, "SendNotification", mock.Anything, "Stale PR: Unassigned PR", mock.Anything)
+}
+
+// digestTestPRs returns two stale PRs with passing CI, registered on mockAPI.
+func digestTestPRs(mockAPI *MockGitHubClient) []api.PullRequest {
+	prs := []api.PullRequest{
+		{Number: 1, Title: "First", User: api.User{Login: "alice"}, UpdatedAt: time.Now().Add(-5 * 24 * time.Hour),
+			HTMLURL: "https://github.com/testowner/testrepo/pull/1", Head: api.PRHead{SHA: "sha1"}},
+		{Number: 2, Title: "Second", User: api.User{Login: "bob"}, UpdatedAt: time.Now().Add(-6 * 24 * time.Hour),
+			HTMLURL: "https://github.com/testowner/testrepo/pull/2", Head: api.PRHead{SHA: "sha2"}},
+	}
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return(prs, nil)
+	mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", mock.Anything).Return(&api.CommitStatus{State: "success"}, nil)
+	mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", mock.Anything).Return(&api.CheckSuitesResponse{}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", mock.Anything).Return([]api.Review{}, nil)
+	return prs
+}
+
+func TestPRReviewCheckTask_Run_IndividualMode_SendsOneNotificationPerPR(t *testing.T) {
+	cfg := config.GitHubConfig{
+		StaleDays:    4,
+		NotifyMode:   config.NotifyModeIndividual,
+		Repositories: []config.RepositoryConfig{{Owner: "testowner", Repo: "testrepo"}},
+	}
+
+	mockAPI := &MockGitHubClient{}
+	digestTestPRs(mockAPI)
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "Stale PR: First", mock.Anything).Return(nil).Once()
+	mockNotifier.On("SendNotification", mock.Anything, "Stale PR: Second", mock.Anything).Return(nil).Once()
+
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+	task.apiClient = mockAPI
+
+	require.NoError(t, task.Run(context.Background()))
+
+	mockNotifier.AssertExpectations(t)
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 2)
+}
+
+func TestPRReviewCheckTask_Run_DigestMode_CombinesStalePRs(t *testing.T) {
+	cfg := config.GitHubConfig{
+		StaleDays:    4,
+		NotifyMode:   "digest",
+		Repositories: []config.RepositoryConfig{{Owner: "testowner", Repo: "testrepo"}},
+	}
+
+	mockAPI := &MockGitHubClient{}
+	digestTestPRs(mockAPI)
+
+	var message string
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "2 stale PRs in testowner/testrepo", mock.Anything).
+		Run(func(args mock.Arguments) { message = args.String(2) }).
+		Return(nil).Once()
+
+	now := time.Now()
+	fake := clock.NewFake(now)
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+	task.apiClient = mockAPI
+	task.Clock = fake
+
+	require.NoError(t, task.Run(context.Background()))
+
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 1)
+	assert.True(t, strings.HasPrefix(message, "Pending review in testowner/testrepo:"), message)
+	assert.Contains(t, message, "- #1 First by alice")
+	assert.Contains(t, message, "https://github.com/testowner/testrepo/pull/1")
+	assert.Contains(t, message, "- #2 Second by bob")
+	mockAPI.AssertNotCalled(t, "GetPullRequestReviews", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// The cooldown applies to the repository: no digest until it expires
+	fake.Advance(time.Hour)
+	require.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 1)
+
+	mockNotifier.On("SendNotification", mock.Anything, "2 stale PRs in testowner/testrepo", mock.Anything).Return(nil).Once()
+	fake.Advance(24 * time.Hour)
+	require.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 2)
+}
+
+func TestPRReviewCheckTask_Run_DigestMode_FailingCIRaisesSeverity(t *testing.T) {
+	cfg := config.GitHubConfig{
+		StaleDays:    4,
+		NotifyMode:   config.NotifyModeDigest,
+		Repositories: []config.RepositoryConfig{{Owner: "testowner", Repo: "testrepo"}},
+	}
+
+	pr := api.PullRequest{Number: 3, Title: "Broken", User: api.User{Login: "carol"},
+		UpdatedAt: time.Now().Add(-5 * 24 * time.Hour), HTMLURL: "https://github.com/testowner/testrepo/pull/3", Head: api.PRHead{SHA: "sha3"}}
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{pr}, nil)
+	mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha3").Return(&api.CommitStatus{State: "failure"}, nil)
+	mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha3").Return(&api.CheckSuitesResponse{}, nil)
+
+	var severity notifier.Severity
+	var message string
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "1 stale PR in testowner/testrepo", mock.Anything).
+		Run(func(args mock.Arguments) {
+			severity = notifier.SeverityFromContext(args.Get(0).(context.Context))
+			message = args.String(2)
+		}).
+		Return(nil).Once()
+
+	task := NewPRReviewCheckTask(cfg, mockNotifier, notifier.FormatMarkdown)
+	task.apiClient = mockAPI
+
+	require.NoError(t, task.Run(context.Background()))
+
+	mockNotifier.AssertExpectations(t)
+	assert.Equal(t, notifier.SeverityFailure, severity)
+	assert.Contains(t, message, "- [#3 Broken](https://github.com/testowner/testrepo/pull/3) by carol")
+	assert.Contains(t, message, "(CI: Failing ❌)")
+}
+
+func TestPRReviewCheckTask_Run_IncludeCILinks(t *testing.T) {
+	failingStatus := &api.CommitStatus{State: "failure", Statuses: []api.Status{
+		{State: "success", Context: "ci/lint", TargetURL: "https://ci.example.com/lint/7"},
+		{State: "failure", Context: "ci/build", TargetURL: "https://ci.example.com/build/42"},
+	}}
+	failingSuites := &api.CheckSuitesResponse{CheckSuites: []api.CheckSuite{{Status: "completed", Conclusion: "failure"}}}
+
+	tests := []struct {
+		name     string
+		links    bool
+		status   *api.CommitStatus
+		suites   *api.CheckSuitesResponse
+		wantLine string
+	}{
+		{
+			name:     "failing commit status links to its run",
+			links:    true,
+			status:   failingStatus,
+			suites:   &api.CheckSuitesResponse{},
+			wantLine: "\nCI failing: https://ci.example.com/build/42\n",
+		},
+		{
+			name:     "failing status without a target URL links to the checks page",
+			links:    true,
+			status:   &api.CommitStatus{State: "error", Statuses: []api.Status{{State: "error", Context: "ci/build"}}},
+			suites:   &api.CheckSuitesResponse{},
+			wantLine: "\nCI failing: https://github.com/testowner/testrepo/pull/3/checks\n",
+		},
+		{
+			name:     "failing check suite links to the checks page",
+			links:    true,
+			status:   &api.CommitStatus{State: "success"},
+			suites:   failingSuites,
+			wantLine: "\nCI failing: https://github.com/testowner/testrepo/pull/3/checks\n",
+		},
+		{
+			name:   "disabled",
+			status: failingStatus,
+			suites: &api.CheckSuitesResponse{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.GitHubConfig{
+				StaleDays:      4,
+				IncludeCILinks: tt.links,
+				Repositories:   []config.RepositoryConfig{{Owner: "testowner", Repo: "testrepo"}},
+			}
+			pr := api.PullRequest{Number: 3, Title: "Broken", User: api.User{Login: "carol"},
+				UpdatedAt: time.Now().Add(-5 * 24 * time.Hour), HTMLURL: "https://github.com/testowner/testrepo/pull/3", Head: api.PRHead{SHA: "sha3"}}
+			mockAPI := &MockGitHubClient{}
+			mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{pr}, nil)
+			mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha3").Return(tt.status, nil)
+			mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha3").Return(tt.suites, nil)
+			mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", 3).Return([]api.Review{}, nil)
+
+			var message string
+			mockNotifier := &MockNotifier{}
+			mockNotifier.On("SendNotification", mock.Anything, "Stale PR: Broken", mock.Anything).
+				Run(func(args mock.Arguments) { message = args.String(2) }).
+				Return(nil).Once()
+
+			task := NewPRReviewCheckTask(cfg, mockNotifier, notifier.FormatText)
+			task.apiClient = mockAPI
+
+			require.NoError(t, task.Run(context.Background()))
+
+			mockNotifier.AssertExpectations(t)
+			assert.Contains(t, message, "(CI: Failing ❌)")
+			if tt.wantLine == "" {
+				assert.NotContains(t, message, "CI failing:")
+			} else {
+				assert.Contains(t, message, tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestPRReviewCheckTask_Run_IncludeCILinks_Formats(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		digest bool
+		want   string
+	}{
+		{name: "markdown", format: notifier.FormatMarkdown, want: "**CI failing:** [https://ci.example.com/build/42](https://ci.example.com/build/42)"},
+		{name: "html", format: notifier.FormatHTML, want: `<b>CI failing:</b> <a href="https://ci.example.com/build/42">https://ci.example.com/build/42</a>`},
+		{name: "text digest", format: notifier.FormatText, digest: true, want: "(CI: Failing ❌ https://ci.example.com/build/42)"},
+		{name: "markdown digest", format: notifier.FormatMarkdown, digest: true, want: "([CI: Failing ❌](https://ci.example.com/build/42))"},
+		{name: "html digest", format: notifier.FormatHTML, digest: true, want: `(<a href="https://ci.example.com/build/42">CI: Failing ❌</a>)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.GitHubConfig{
+				StaleDays:      4,
+				IncludeCILinks: true,
+				Repositories:   []config.RepositoryConfig{{Owner: "testowner", Repo: "testrepo"}},
+			}
+			if tt.digest {
+				cfg.NotifyMode = config.NotifyModeDigest
+			}
+			pr := api.PullRequest{Number: 3, Title: "Broken", User: api.User{Login: "carol"},
+				UpdatedAt: time.Now().Add(-5 * 24 * time.Hour), HTMLURL: "https://github.com/testowner/testrepo/pull/3", Head: api.PRHead{SHA: "sha3"}}
+			mockAPI := &MockGitHubClient{}
+			mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{pr}, nil)
+			mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha3").Return(&api.CommitStatus{State: "failure", Statuses: []api.Status{
+				{State: "failure", Context: "ci/build", TargetURL: "https://ci.example.com/build/42"},
+			}}, nil)
+			mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha3").Return(&api.CheckSuitesResponse{}, nil)
+			mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", 3).Return([]api.Review{}, nil).Maybe()
+
+			var message string
+			mockNotifier := &MockNotifier{}
+			mockNotifier.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) { message = args.String(2) }).
+				Return(nil).Once()
+
+			task := NewPRReviewCheckTask(cfg, mockNotifier, tt.format)
+			task.apiClient = mockAPI
+
+			require.NoError(t, task.Run(context.Background()))
+
+			mockNotifier.AssertExpectations(t)
+			assert.Contains(t, message, tt.want)
+		})
+	}
+}
+
+func TestPRReviewCheckTask_Run_MaxNotificationsPerRun(t *testing.T) {
+	cfg := config.GitHubConfig{
+		StaleDays:              4,
+		MaxNotificationsPerRun: 2,
+		Repositories:           []config.RepositoryConfig{{Owner: "testowner", Repo: "testrepo"}},
+	}
+
+	var prs []api.PullRequest
+	for i := 1; i <= 5; i++ {
+		prs = append(prs, api.PullRequest{Number: i, Title: fmt.Sprintf("PR %d", i), User: api.User{Login: "testuser"},
+			UpdatedAt: time.Now().Add(-5 * 24 * time.Hour), Head: api.PRHead{SHA: "sha"}})
+	}
+
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return(prs, nil)
+	mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha").Return(&api.CommitStatus{State: "success"}, nil)
+	mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha").Return(&api.CheckSuitesResponse{}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", mock.Anything).Return([]api.Review{}, nil)
+
+	// The first notification fails: it doesn't count towards the limit
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "Stale PR: PR 1", mock.Anything).Return(errors.New("unavailable")).Once()
+	mockNotifier.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+	task.apiClient = mockAPI
+
+	require.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 3)
+	assert.Len(t, notifiedIDs(task), 2)
+
+	// PRs in cooldown don't count either: the next run notifies the next two
+	require.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 5)
+	assert.Len(t, notifiedIDs(task), 4)
+}
+
+func TestIsBot(t *testing.T) {
+	tests := []struct {
+		name string
+		user api.User
+		want bool
+	}{
+		{name: "human", user: api.User{Login: "alice", Type: "User"}, want: false},
+		{name: "app login suffix", user: api.User{Login: "dependabot[bot]"}, want: true},
+		{name: "suffix is case-insensitive", user: api.User{Login: "Renovate[Bot]"}, want: true},
+		{name: "bot account type", user: api.User{Login: "dependabot", Type: "Bot"}, want: true},
+		{name: "listed login", user: api.User{Login: "Renovate-Runner"}, want: true},
+		{name: "bot in name only", user: api.User{Login: "robotics-fan"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isBot(tt.user, []string{"renovate-runner"}))
+		})
+	}
+}
+
+func TestPRReviewCheckTask_Run_IgnoreBots(t *testing.T) {
+	old := time.Now().Add(-5 * 24 * time.Hour)
+	prs := []api.PullRequest{
+		{Number: 1, Title: "Human PR", User: api.User{Login: "alice", Type: "User"}, UpdatedAt: old, Head: api.PRHead{SHA: "sha1"}},
+		{Number: 2, Title: "Bump lodash", User: api.User{Login: "dependabot[bot]", Type: "Bot"}, UpdatedAt: old, Head: api.PRHead{SHA: "sha2"}},
+		{Number: 3, Title: "Update deps", User: api.User{Login: "renovate-runner"}, UpdatedAt: old, Head: api.PRHead{SHA: "sha3"}},
+	}
+
+	run := func(t *testing.T, ignoreBots bool) []int {
+		cfg := config.GitHubConfig{
+			StaleDays:    4,
+			IgnoreBots:   ignoreBots,
+			BotAuthors:   []string{"renovate-runner"},
+			Repositories: []config.RepositoryConfig{{Owner: "testowner", Repo: "testrepo"}},
+		}
+
+		mockAPI := &MockGitHubClient{}
+		mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return(prs, nil)
+		mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", mock.Anything).Return(&api.CommitStatus{State: "success"}, nil)
+		mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", mock.Anything).Return(&api.CheckSuitesResponse{}, nil)
+		mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", mock.Anything).Return([]api.Review{}, nil)
+
+		var mu sync.Mutex
+		var notified []int
+		mockNotifier := &MockNotifier{}
+		mockNotifier.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			mu.Lock()
+			defer mu.Unlock()
+			for _, pr := range prs {
+				if strings.Contains(args.String(2), fmt.Sprintf("#%d", pr.Number)) {
+					notified = append(notified, pr.Number)
+				}
+			}
+		}).Return(nil)
+
+		task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+		task.apiClient = mockAPI
+		require.NoError(t, task.Run(context.Background()))
+		sort.Ints(notified)
+		return notified
+	}
+
+	t.Run("on", func(t *testing.T) {
+		assert.Equal(t, []int{1}, run(t, true), "only the human's PR is alerted on")
+	})
+	t.Run("off", func(t *testing.T) {
+		assert.Equal(t, []int{1, 2, 3}, run(t, false))
+	})
+}
+
+func TestMatchesPeopleFilters(t *testing.T) {
+	pr := api.PullRequest{
+		User:      api.User{Login: "alice"},
+		Assignees: []api.User{{Login: "bob"}},
+	}
+
+	tests := []struct {
+		name      string
+		authors   []string
+		assignees []string
+		mode      string
+		want      bool
+	}{
+		{name: "no filters", want: true},
+		{name: "assignee match", assignees: []string{"BOB"}, want: true},
+		{name: "assignee no match", assignees: []string{"carol"}, want: false},
+		{name: "and both match", authors: []string{"alice"}, assignees: []string{"bob"}, want: true},
+		{name: "and only author matches", authors: []string{"alice"}, assignees: []string{"carol"}, want: false},
+		{name: "and only assignee matches", authors: []string{"carol"}, assignees: []string{"bob"}, mode: "and", want: false},
+		{name: "or only author matches", authors: []string{"alice"}, assignees: []string{"carol"}, mode: "or", want: true},
+		{name: "or only assignee matches", authors: []string{"carol"}, assignees: []string{"bob"}, mode: "OR", want: true},
+		{name: "or neither matches", authors: []string{"carol"}, assignees: []string{"dave"}, mode: "or", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoConfig := config.RepositoryConfig{Authors: tt.authors, Assignees: tt.assignees, FilterMode: tt.mode}
+			assert.Equal(t, tt.want, matchesPeopleFilters(pr, repoConfig))
+		})
+	}
+}
+
 func TestPRReviewCheckTask_Run_StalePR_CIFailure(t *testing.T) {
 	cfg := config.GitHubConfig{
 		StaleDays: 4,
@@ -414,16 +2216,17 @@ func TestPRReviewCheckTask_Run_StalePR_CIFailure(t *testing.T) {
 	mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{stalePR}, nil)
 	mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "badsha").Return(&api.CommitStatus{State: "failure"}, nil)
 	mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "badsha").Return(&api.CheckSuitesResponse{TotalCount: 0}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", mock.Anything).Return([]api.Review{}, nil)
 
 	mockNotifier := &MockNotifier{}
 	mockNotifier.On("SendNotification", mock.Anything, mock.Anything, mock.MatchedBy(func(msg string) bool {
 		return assert.Contains(t, msg, "CI: Failing ❌")
 	})).Return(nil)
 
-	task := NewPRReviewCheckTask(cfg, mockNotifier)
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
 	task.apiClient = mockAPI
 
-	err := task.Run()
+	err := task.Run(context.Background())
 	assert.NoError(t, err)
 	mockNotifier.AssertExpectations(t)
 }
@@ -448,6 +2251,7 @@ func TestPRReviewCheckTask_Run_StalePR_CIPending(t *testing.T) {
 	mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{stalePR}, nil)
 	mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "pendingsha").Return(&api.CommitStatus{State: "pending"}, nil)
 	mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "pendingsha").Return(&api.CheckSuitesResponse{TotalCount: 0}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", mock.Anything).Return([]api.Review{}, nil)
 
 	mockNotifier := &MockNotifier{}
 	mockNotifier.On("SendNotification", mock.Anything, mock.Anything, mock.MatchedBy(func(msg string) bool {
@@ -455,10 +2259,10 @@ func TestPRReviewCheckTask_Run_StalePR_CIPending(t *testing.T) {
 		return !strings.Contains(msg, "CI: Pending")
 	})).Return(nil)
 
-	task := NewPRReviewCheckTask(cfg, mockNotifier)
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
 	task.apiClient = mockAPI
 
-	err := task.Run()
+	err := task.Run(context.Background())
 	assert.NoError(t, err)
 	mockNotifier.AssertExpectations(t)
 }
@@ -488,16 +2292,17 @@ func TestPRReviewCheckTask_Run_StalePR_CheckSuiteFailure(t *testing.T) {
 			{Conclusion: "failure", Status: "completed"},
 		},
 	}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", mock.Anything).Return([]api.Review{}, nil)
 
 	mockNotifier := &MockNotifier{}
 	mockNotifier.On("SendNotification", mock.Anything, mock.Anything, mock.MatchedBy(func(msg string) bool {
 		return assert.Contains(t, msg, "CI: Failing ❌")
 	})).Return(nil)
 
-	task := NewPRReviewCheckTask(cfg, mockNotifier)
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
 	task.apiClient = mockAPI
 
-	err := task.Run()
+	err := task.Run(context.Background())
 	assert.NoError(t, err)
 	mockNotifier.AssertExpectations(t)
 }
@@ -527,14 +2332,15 @@ func TestPRReviewCheckTask_Run_NoAuthorFilter_AllPRsMonitored(t *testing.T) {
 	mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{stalePR}, nil)
 	mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CommitStatus{State: "success"}, nil)
 	mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CheckSuitesResponse{TotalCount: 0}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", mock.Anything).Return([]api.Review{}, nil)
 
 	mockNotifier := &MockNotifier{}
 	mockNotifier.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-	task := NewPRReviewCheckTask(cfg, mockNotifier)
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
 	task.apiClient = mockAPI
 
-	err := task.Run()
+	err := task.Run(context.Background())
 
 	assert.NoError(t, err)
 	mockNotifier.AssertExpectations(t)
@@ -562,24 +2368,143 @@ func TestPRReviewCheckTask_Run_RespectsCooldown(t *testing.T) {
 	mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{stalePR}, nil)
 	mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CommitStatus{State: "success"}, nil).Once()
 	mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CheckSuitesResponse{TotalCount: 0}, nil).Once()
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", mock.Anything).Return([]api.Review{}, nil).Once()
 
 	mockNotifier := &MockNotifier{}
 	mockNotifier.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
-	task := NewPRReviewCheckTask(cfg, mockNotifier)
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
 	task.apiClient = mockAPI
 
 	// First run - should notify
-	err := task.Run()
+	err := task.Run(context.Background())
 	require.NoError(t, err)
 
 	// Immediate second run - should not notify due to cooldown
-	err = task.Run()
+	err = task.Run(context.Background())
 	require.NoError(t, err)
 
 	mockNotifier.AssertExpectations(t)
 }
 
+func TestPRReviewCheckTask_Run_CooldownPersistsAcrossRestart(t *testing.T) {
+	cfg := config.GitHubConfig{
+		StaleDays:            4,
+		NotificationCooldown: "1h",
+		Repositories: []config.RepositoryConfig{
+			{Owner: "testowner", Repo: "testrepo"},
+		},
+	}
+
+	stalePR := api.PullRequest{
+		Number:    123,
+		Title:     "Stale PR",
+		User:      api.User{Login: "testuser"},
+		UpdatedAt: time.Now().Add(-5 * 24 * time.Hour),
+		Head:      api.PRHead{SHA: "sha123"},
+	}
+
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{stalePR}, nil)
+	mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CommitStatus{State: "success"}, nil)
+	mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CheckSuitesResponse{}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", mock.Anything).Return([]api.Review{}, nil)
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	store := state.NewFileStore(filepath.Join(t.TempDir(), "state.json"))
+
+	// First process notifies and persists the cooldown
+	first := NewPRReviewCheckTask(cfg, mockNotifier, "")
+	first.apiClient = mockAPI
+	first.LoadState(store)
+	require.NoError(t, first.Run(context.Background()))
+
+	// After a "restart" the new task picks up the cooldown and stays quiet
+	restarted := NewPRReviewCheckTask(cfg, mockNotifier, "")
+	restarted.apiClient = mockAPI
+	restarted.LoadState(state.NewFileStore(store.Path))
+	assert.Contains(t, restarted.lastNotificationTime, "testowner/testrepo#123")
+	require.NoError(t, restarted.Run(context.Background()))
+
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 1)
+}
+
+func TestPRReviewCheckTask_Run_NotifyOnResolve(t *testing.T) {
+	cfg := config.GitHubConfig{
+		StaleDays:       4,
+		NotifyOnResolve: true,
+		Repositories: []config.RepositoryConfig{
+			{Owner: "testowner", Repo: "testrepo"},
+		},
+	}
+
+	stalePR := api.PullRequest{
+		Number:    123,
+		Title:     "Stale PR",
+		User:      api.User{Login: "testuser"},
+		UpdatedAt: time.Now().Add(-5 * 24 * time.Hour),
+		Head:      api.PRHead{SHA: "sha123"},
+	}
+
+	// The PR is open on the first run and gone (closed or merged) on the next two
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{stalePR}, nil).Once()
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{}, nil).Twice()
+	mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CommitStatus{State: "success"}, nil)
+	mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CheckSuitesResponse{}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", 123).Return([]api.Review{}, nil)
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "Stale PR: Stale PR", mock.Anything).Return(nil).Once()
+	mockNotifier.On("SendNotification", mock.MatchedBy(func(ctx context.Context) bool {
+		return notifier.SeverityFromContext(ctx) == notifier.SeveritySuccess
+	}), "Resolved: PR #123 was closed/merged", mock.MatchedBy(func(msg string) bool {
+		return strings.Contains(msg, "testowner/testrepo")
+	})).Return(nil).Once()
+
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+	task.apiClient = mockAPI
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, task.Run(context.Background()))
+	}
+
+	mockAPI.AssertExpectations(t)
+	mockNotifier.AssertExpectations(t)
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 2)
+	assert.NotContains(t, task.lastNotificationTime, "testowner/testrepo#123")
+}
+
+func TestPRReviewCheckTask_Run_ResolvedPRWithoutNotifyOnResolve(t *testing.T) {
+	cfg := config.GitHubConfig{
+		StaleDays: 4,
+		Repositories: []config.RepositoryConfig{
+			{Owner: "testowner", Repo: "testrepo"},
+			{Owner: "otherowner", Repo: "otherrepo"},
+		},
+	}
+
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{}, nil)
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "otherowner", "otherrepo").Return(nil, errors.New("API error"))
+
+	mockNotifier := &MockNotifier{}
+
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+	task.apiClient = mockAPI
+	task.lastNotificationTime["testowner/testrepo#123"] = time.Now().Add(-time.Hour)
+	task.lastNotificationTime["otherowner/otherrepo#7"] = time.Now().Add(-time.Hour)
+
+	require.NoError(t, task.Run(context.Background()))
+
+	// Closed PR is forgotten silently; a repo that failed to load keeps its entries
+	mockNotifier.AssertNotCalled(t, "SendNotification", mock.Anything, mock.Anything, mock.Anything)
+	assert.NotContains(t, task.lastNotificationTime, "testowner/testrepo#123")
+	assert.Contains(t, task.lastNotificationTime, "otherowner/otherrepo#7")
+}
+
 func TestPRReviewCheckTask_Run_APIError_ContinuesWithOtherRepos(t *testing.T) {
 	cfg := config.GitHubConfig{
 		StaleDays: 4,
@@ -603,14 +2528,15 @@ func TestPRReviewCheckTask_Run_APIError_ContinuesWithOtherRepos(t *testing.T) {
 	mockAPI.On("GetOpenPullRequests", mock.Anything, "owner2", "repo2").Return([]api.PullRequest{stalePR}, nil)
 	mockAPI.On("GetCommitStatus", mock.Anything, "owner2", "repo2", "sha456").Return(&api.CommitStatus{State: "success"}, nil)
 	mockAPI.On("GetCheckSuites", mock.Anything, "owner2", "repo2", "sha456").Return(&api.CheckSuitesResponse{TotalCount: 0}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "owner2", "repo2", mock.Anything).Return([]api.Review{}, nil)
 
 	mockNotifier := &MockNotifier{}
 	mockNotifier.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-	task := NewPRReviewCheckTask(cfg, mockNotifier)
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
 	task.apiClient = mockAPI
 
-	err := task.Run()
+	err := task.Run(context.Background())
 
 	// Should not return error, just log and continue
 	assert.NoError(t, err)
@@ -618,6 +2544,59 @@ func TestPRReviewCheckTask_Run_APIError_ContinuesWithOtherRepos(t *testing.T) {
 	mockNotifier.AssertExpectations(t)
 }
 
+func TestPRReviewCheckTask_Run_LogsFetchErrorsByType(t *testing.T) {
+	var buf bytes.Buffer
+	original := log.Logger
+	// Repositories are checked concurrently, so writes to buf must be serialized
+	log.Logger = zerolog.New(zerolog.SyncWriter(&buf))
+	t.Cleanup(func() { log.Logger = original })
+
+	cfg := config.GitHubConfig{
+		StaleDays: 4,
+		Repositories: []config.RepositoryConfig{
+			{Owner: "acme", Repo: "gone"},
+			{Owner: "acme", Repo: "busy"},
+			{Owner: "acme", Repo: "flaky"},
+			{Owner: "acme", Repo: "budgeted"},
+		},
+	}
+	reset := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "acme", "gone").
+		Return(nil, &api.APIError{Service: "github", StatusCode: http.StatusNotFound})
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "acme", "busy").
+		Return(nil, &api.RateLimitError{APIError: api.APIError{Service: "github", StatusCode: http.StatusForbidden}, Reset: reset})
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "acme", "flaky").
+		Return(nil, errors.New("connection reset"))
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "acme", "budgeted").
+		Return(nil, fmt.Errorf("failed to fetch PRs: %w", &api.BudgetExceededError{Limit: 100, Reset: reset}))
+
+	task := NewPRReviewCheckTask(cfg, &MockNotifier{}, "")
+	task.apiClient = mockAPI
+
+	require.Error(t, task.Run(context.Background()), "no repository could be checked")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	logged := func(repo string) string {
+		for _, line := range lines {
+			if strings.Contains(line, `"repo":"`+repo+`"`) {
+				return line
+			}
+		}
+		return ""
+	}
+	assert.Contains(t, logged("gone"), "Repository not found")
+	assert.Contains(t, logged("busy"), "GitHub rate limit exceeded")
+	assert.Contains(t, logged("busy"), `"level":"warn"`)
+	assert.Contains(t, logged("busy"), `"reset":"2024-01-01T12:00:00Z"`)
+	assert.Contains(t, logged("flaky"), "Failed to fetch PRs")
+	assert.Contains(t, logged("flaky"), `"error":"connection reset"`)
+	assert.Contains(t, logged("budgeted"), "API request budget used up")
+	assert.Contains(t, logged("budgeted"), `"level":"warn"`)
+	assert.Contains(t, logged("budgeted"), `"reset":"2024-01-01T12:00:00Z"`)
+}
+
 func TestPRReviewCheckTask_Run_NotificationError_ContinuesWithOtherPRs(t *testing.T) {
 	cfg := config.GitHubConfig{
 		StaleDays: 4,
@@ -648,17 +2627,19 @@ func TestPRReviewCheckTask_Run_NotificationError_ContinuesWithOtherPRs(t *testin
 	mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{stalePR1, stalePR2}, nil)
 	mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CommitStatus{State: "success"}, nil)
 	mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CheckSuitesResponse{TotalCount: 0}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", mock.Anything).Return([]api.Review{}, nil)
 	mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha456").Return(&api.CommitStatus{State: "success"}, nil)
 	mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha456").Return(&api.CheckSuitesResponse{TotalCount: 0}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", mock.Anything).Return([]api.Review{}, nil)
 
 	mockNotifier := &MockNotifier{}
 	mockNotifier.On("SendNotification", mock.Anything, "Stale PR: PR 1", mock.Anything).Return(errors.New("notification failed"))
 	mockNotifier.On("SendNotification", mock.Anything, "Stale PR: PR 2", mock.Anything).Return(nil)
 
-	task := NewPRReviewCheckTask(cfg, mockNotifier)
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
 	task.apiClient = mockAPI
 
-	err := task.Run()
+	err := task.Run(context.Background())
 
 	assert.NoError(t, err)
 	mockAPI.AssertExpectations(t)
@@ -697,22 +2678,223 @@ func TestPRReviewCheckTask_Run_MultipleRepositories(t *testing.T) {
 	mockAPI.On("GetOpenPullRequests", mock.Anything, "owner2", "repo2").Return([]api.PullRequest{stalePR2}, nil)
 	mockAPI.On("GetCommitStatus", mock.Anything, "owner1", "repo1", "sha123").Return(&api.CommitStatus{State: "success"}, nil)
 	mockAPI.On("GetCheckSuites", mock.Anything, "owner1", "repo1", "sha123").Return(&api.CheckSuitesResponse{TotalCount: 0}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "owner1", "repo1", mock.Anything).Return([]api.Review{}, nil)
 	mockAPI.On("GetCommitStatus", mock.Anything, "owner2", "repo2", "sha456").Return(&api.CommitStatus{State: "success"}, nil)
 	mockAPI.On("GetCheckSuites", mock.Anything, "owner2", "repo2", "sha456").Return(&api.CheckSuitesResponse{TotalCount: 0}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "owner2", "repo2", mock.Anything).Return([]api.Review{}, nil)
 
 	mockNotifier := &MockNotifier{}
 	mockNotifier.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).Return(nil).Times(2)
 
-	task := NewPRReviewCheckTask(cfg, mockNotifier)
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
 	task.apiClient = mockAPI
 
-	err := task.Run()
+	err := task.Run(context.Background())
 
 	assert.NoError(t, err)
 	mockAPI.AssertExpectations(t)
 	mockNotifier.AssertExpectations(t)
 }
 
+func TestPRReviewCheckTask_Run_DuplicateRepositoriesCheckedOnce(t *testing.T) {
+	cfg := config.GitHubConfig{
+		StaleDays: 4,
+		Repositories: []config.RepositoryConfig{
+			{Owner: "testowner", Repo: "testrepo"},
+			{Owner: "TestOwner", Repo: "TestRepo"},
+		},
+	}
+
+	pr := api.PullRequest{Number: 9, Title: "Listed twice", User: api.User{Login: "testuser"},
+		UpdatedAt: time.Now().Add(-5 * 24 * time.Hour), Head: api.PRHead{SHA: "sha9"}}
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{pr}, nil).Once()
+	mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha9").Return(&api.CommitStatus{State: "success"}, nil)
+	mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha9").Return(&api.CheckSuitesResponse{}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", 9).Return([]api.Review{}, nil)
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "Stale PR: Listed twice", mock.Anything).Return(nil).Once()
+
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+	task.apiClient = mockAPI
+
+	require.NoError(t, task.Run(context.Background()))
+
+	assert.Len(t, task.config.Repositories, 1)
+	mockAPI.AssertNumberOfCalls(t, "GetOpenPullRequests", 1)
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 1)
+}
+
+func TestPRReviewCheckTask_Run_ChecksRepositoriesConcurrently(t *testing.T) {
+	const repoCount = 4
+	const delay = 200 * time.Millisecond
+
+	cfg := config.GitHubConfig{
+		StaleDays:   4,
+		Concurrency: repoCount,
+	}
+
+	mockAPI := &MockGitHubClient{}
+	for i := 0; i < repoCount; i++ {
+		repo := fmt.Sprintf("repo%d", i)
+		cfg.Repositories = append(cfg.Repositories, config.RepositoryConfig{Owner: "owner", Repo: repo})
+
+		stalePR := api.PullRequest{
+			Number:    i + 1,
+			Title:     "Stale PR " + repo,
+			UpdatedAt: time.Now().Add(-5 * 24 * time.Hour),
+			Head:      api.PRHead{SHA: "sha-" + repo},
+		}
+		mockAPI.On("GetOpenPullRequests", mock.Anything, "owner", repo).
+			After(delay).
+			Return([]api.PullRequest{stalePR}, nil)
+		mockAPI.On("GetCommitStatus", mock.Anything, "owner", repo, "sha-"+repo).Return(&api.CommitStatus{State: "success"}, nil)
+		mockAPI.On("GetCheckSuites", mock.Anything, "owner", repo, "sha-"+repo).Return(&api.CheckSuitesResponse{}, nil)
+		mockAPI.On("GetPullRequestReviews", mock.Anything, "owner", repo, i+1).Return([]api.Review{}, nil)
+	}
+	// One failing repository doesn't affect the others
+	cfg.Repositories = append(cfg.Repositories, config.RepositoryConfig{Owner: "owner", Repo: "broken"})
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "owner", "broken").Return(nil, errors.New("api error"))
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+	task.apiClient = mockAPI
+
+	start := time.Now()
+	require.NoError(t, task.Run(context.Background()))
+	elapsed := time.Since(start)
+
+	assert.Less(t, elapsed, repoCount*delay, "repositories should be checked in parallel")
+	mockAPI.AssertExpectations(t)
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", repoCount)
+	assert.Len(t, task.lastNotificationTime, repoCount)
+}
+
+func TestPRReviewCheckTask_Run_RetriesTransientGitHubErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxRetries int
+		notified   bool
+	}{
+		{name: "recovers within retry budget", maxRetries: 2, notified: true},
+		{name: "gives up when retries are exhausted", maxRetries: 1, notified: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pullAttempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case strings.HasSuffix(r.URL.Path, "/pulls"):
+					// Fail twice with 502 before succeeding
+					if atomic.AddInt32(&pullAttempts, 1) <= 2 {
+						w.WriteHeader(http.StatusBadGateway)
+						return
+					}
+					_, _ = w.Write([]byte(fmt.Sprintf(`[{"number":1,"title":"Stale PR","updated_at":%q,"head":{"sha":"sha1"}}]`,
+						time.Now().Add(-5*24*time.Hour).Format(time.RFC3339))))
+				case strings.HasSuffix(r.URL.Path, "/status"):
+					_, _ = w.Write([]byte(`{"state":"success"}`))
+				case strings.HasSuffix(r.URL.Path, "/check-suites"):
+					_, _ = w.Write([]byte(`{"total_count":0,"check_suites":[]}`))
+				default:
+					_, _ = w.Write([]byte(`[]`))
+				}
+			}))
+			defer server.Close()
+
+			maxRetries := tt.maxRetries
+			cfg := config.GitHubConfig{
+				StaleDays:    4,
+				MaxRetries:   &maxRetries,
+				Repositories: []config.RepositoryConfig{{Owner: "testowner", Repo: "testrepo"}},
+			}
+
+			mockNotifier := &MockNotifier{}
+			mockNotifier.On("SendNotification", mock.Anything, "Stale PR: Stale PR", mock.Anything).Return(nil).Maybe()
+
+			task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+			client := task.apiClient.(*api.GitHubAPI)
+			require.NotNil(t, client.RetryConfig)
+			assert.Equal(t, tt.maxRetries, client.RetryConfig.MaxRetries)
+			client.BaseURL = server.URL
+			client.RetryConfig.InitialBackoff = time.Millisecond
+
+			err := task.Run(context.Background())
+
+			if tt.notified {
+				require.NoError(t, err)
+				mockNotifier.AssertNumberOfCalls(t, "SendNotification", 1)
+			} else {
+				require.Error(t, err, "the only repository couldn't be fetched")
+				mockNotifier.AssertNotCalled(t, "SendNotification", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestPRReviewCheckTask_Run_AllFetchesFail_AlertsViaScheduler(t *testing.T) {
+	cfg := config.GitHubConfig{
+		StaleDays: 4,
+		Repositories: []config.RepositoryConfig{
+			{Owner: "owner", Repo: "repo1"},
+			{Owner: "owner", Repo: "repo2"},
+		},
+	}
+
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "owner", mock.Anything).Return(nil, errors.New("dial tcp: connection refused"))
+
+	task := NewPRReviewCheckTask(cfg, &MockNotifier{}, "")
+	task.apiClient = mockAPI
+
+	alerts := &MockNotifier{}
+	alerts.On("SendNotification", mock.Anything, "Task Failing: github-pr-review", mock.MatchedBy(func(msg string) bool {
+		return strings.Contains(msg, "connection refused")
+	})).Return(nil).Once()
+
+	sched := scheduler.NewScheduler()
+	sched.ScheduleTask(task, time.Hour)
+	sched.SetFailureAlerts(scheduler.FailureAlerts{Notifier: alerts, AlertAfter: 2})
+
+	assert.ErrorContains(t, sched.RunOnce(context.Background()), "failed to fetch PRs of all 2 repositories")
+	alerts.AssertNotCalled(t, "SendNotification", mock.Anything, mock.Anything, mock.Anything)
+
+	assert.Error(t, sched.RunOnce(context.Background()))
+	alerts.AssertExpectations(t)
+}
+
+func TestFetchFailure(t *testing.T) {
+	budget := fmt.Errorf("failed to fetch PRs: %w", &api.BudgetExceededError{Limit: 10})
+	outage := errors.New("connection refused")
+
+	tests := []struct {
+		name    string
+		errs    []error
+		wantErr string
+	}{
+		{name: "no repositories", errs: nil},
+		{name: "all succeeded", errs: []error{nil, nil}},
+		{name: "some failed", errs: []error{outage, nil}},
+		{name: "all failed", errs: []error{budget, outage}, wantErr: "failed to fetch PRs of all 2 repositories: connection refused"},
+		{name: "only budget", errs: []error{budget, budget}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fetchFailure(tt.errs)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestPRReviewCheckTask_Run_CleanupOldNotifications(t *testing.T) {
 	cfg := config.GitHubConfig{
 		StaleDays:            4,
@@ -720,7 +2902,7 @@ func TestPRReviewCheckTask_Run_CleanupOldNotifications(t *testing.T) {
 		Repositories:         []config.RepositoryConfig{},
 	}
 
-	task := NewPRReviewCheckTask(cfg, &MockNotifier{})
+	task := NewPRReviewCheckTask(cfg, &MockNotifier{}, "")
 
 	// Add old notification entries
 	task.lastNotificationTime["owner/repo#123"] = time.Now().Add(-10 * 24 * time.Hour)
@@ -728,7 +2910,7 @@ func TestPRReviewCheckTask_Run_CleanupOldNotifications(t *testing.T) {
 
 	require.Len(t, task.lastNotificationTime, 2)
 
-	err := task.Run()
+	err := task.Run(context.Background())
 
 	assert.NoError(t, err)
 	// Old entry should be cleaned up
@@ -760,10 +2942,10 @@ func TestPRReviewCheckTask_Run_ExactlyAtStaleThreshold(t *testing.T) {
 
 	mockNotifier := &MockNotifier{}
 
-	task := NewPRReviewCheckTask(cfg, mockNotifier)
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
 	task.apiClient = mockAPI
 
-	err := task.Run()
+	err := task.Run(context.Background())
 
 	assert.NoError(t, err)
 	// At exactly 4 days, should not trigger (needs to be > 4 days)
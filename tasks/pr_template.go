@@ -0,0 +1,88 @@
+package tasks
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+	"time"
+	"watchdog/internal/config"
+)
+
+// PRTemplateData is the data stale PR notification templates are rendered with
+// (see config.GitHubConfig.SubjectTemplate and BodyTemplate).
+type PRTemplateData struct {
+	Number    int
+	Title     string
+	Author    string
+	URL       string
+	Owner     string
+	Repo      string
+	UpdatedAt time.Time
+	CreatedAt time.Time
+
+	// CIStatus is "failing" if a commit status or check suite failed, otherwise empty
+	CIStatus string
+
+	// CIURL links to the failing CI run (empty unless CIStatus is "failing")
+	CIURL string
+
+	// Reviews summarizes review states, e.g. "alice ✅, bob 🔄" (empty if none)
+	Reviews string
+
+	// WaitingOn lists the requested reviewers, e.g. "alice, bob" (empty if none or disabled)
+	WaitingOn string
+
+	// Draft is true for draft PRs (only alerted on with monitor_drafts)
+	Draft bool
+
+	// HasConflicts is true for PRs with merge conflicts (only detected with merge_conflicts "label")
+	HasConflicts bool
+
+	// Diffstat is the size of the PR's diff, e.g. "+120/-30, 4 files" (empty unless include_diffstat is set)
+	Diffstat string
+}
+
+// PRTemplates holds the parsed notification templates. A nil template keeps the default format.
+type PRTemplates struct {
+	Subject *template.Template
+	Body    *template.Template
+}
+
+// ParsePRTemplates parses the subject and body templates configured in cfg.
+// Each template is also rendered once against sample data, so references to unknown
+// fields are reported now rather than when the first stale PR is found.
+func ParsePRTemplates(cfg config.GitHubConfig) (PRTemplates, error) {
+	var templates PRTemplates
+	var err error
+	if templates.Subject, err = parsePRTemplate("subject_template", cfg.SubjectTemplate); err != nil {
+		return PRTemplates{}, err
+	}
+	if templates.Body, err = parsePRTemplate("body_template", cfg.BodyTemplate); err != nil {
+		return PRTemplates{}, err
+	}
+	return templates, nil
+}
+
+// parsePRTemplate parses and test-renders a single template. An empty text returns nil.
+func parsePRTemplate(name, text string) (*template.Template, error) {
+	if text == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("tasks.github.%s: %v", name, err)
+	}
+	if _, err := renderPRTemplate(tmpl, PRTemplateData{}); err != nil {
+		return nil, fmt.Errorf("tasks.github.%s: %v", name, err)
+	}
+	return tmpl, nil
+}
+
+// renderPRTemplate executes tmpl with data.
+func renderPRTemplate(tmpl *template.Template, data PRTemplateData) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,93 @@
+package tasks
+
+import (
+	"context"
+	"testing"
+	"time"
+	"watchdog/internal/api"
+	"watchdog/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePRTemplates_RendersFixture(t *testing.T) {
+	templates, err := ParsePRTemplates(config.GitHubConfig{
+		SubjectTemplate: "[{{.Owner}}/{{.Repo}}] #{{.Number}} {{.Title}}",
+		BodyTemplate:    "{{.Author}} opened {{.URL}} (last update {{.UpdatedAt.Format \"2006-01-02\"}}){{if .CIStatus}} - CI {{.CIStatus}}{{end}}",
+	})
+	require.NoError(t, err)
+
+	data := PRTemplateData{
+		Number:    42,
+		Title:     "Add retries",
+		Author:    "octocat",
+		URL:       "https://github.com/acme/api/pull/42",
+		Owner:     "acme",
+		Repo:      "api",
+		UpdatedAt: time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC),
+		CIStatus:  "failing",
+	}
+
+	subject, err := renderPRTemplate(templates.Subject, data)
+	require.NoError(t, err)
+	assert.Equal(t, "[acme/api] #42 Add retries", subject)
+
+	body, err := renderPRTemplate(templates.Body, data)
+	require.NoError(t, err)
+	assert.Equal(t, "octocat opened https://github.com/acme/api/pull/42 (last update 2024-03-01) - CI failing", body)
+}
+
+func TestParsePRTemplates_EmptyKeepsDefault(t *testing.T) {
+	templates, err := ParsePRTemplates(config.GitHubConfig{})
+
+	require.NoError(t, err)
+	assert.Nil(t, templates.Subject)
+	assert.Nil(t, templates.Body)
+}
+
+func TestParsePRTemplates_Invalid(t *testing.T) {
+	_, err := ParsePRTemplates(config.GitHubConfig{SubjectTemplate: "{{.Title"})
+	assert.ErrorContains(t, err, "tasks.github.subject_template")
+
+	_, err = ParsePRTemplates(config.GitHubConfig{BodyTemplate: "{{.Nmber}}"})
+	assert.ErrorContains(t, err, "tasks.github.body_template")
+	assert.ErrorContains(t, err, "Nmber")
+}
+
+func TestPRReviewCheckTask_Run_CustomTemplates(t *testing.T) {
+	cfg := config.GitHubConfig{
+		StaleDays:       4,
+		SubjectTemplate: "{{.Repo}}: PR #{{.Number}} is stale",
+		BodyTemplate:    "{{.Title}} by {{.Author}} waiting on {{.WaitingOn}}",
+		Repositories: []config.RepositoryConfig{
+			{Owner: "testowner", Repo: "testrepo"},
+		},
+	}
+
+	stalePR := api.PullRequest{
+		Number:             123,
+		Title:              "Stale PR",
+		User:               api.User{Login: "testuser"},
+		UpdatedAt:          time.Now().Add(-5 * 24 * time.Hour),
+		HTMLURL:            "https://github.com/testowner/testrepo/pull/123",
+		Head:               api.PRHead{SHA: "sha123"},
+		RequestedReviewers: []api.User{{Login: "alice"}},
+	}
+
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetOpenPullRequests", mock.Anything, "testowner", "testrepo").Return([]api.PullRequest{stalePR}, nil)
+	mockAPI.On("GetCommitStatus", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CommitStatus{State: "success"}, nil)
+	mockAPI.On("GetCheckSuites", mock.Anything, "testowner", "testrepo", "sha123").Return(&api.CheckSuitesResponse{TotalCount: 0}, nil)
+	mockAPI.On("GetPullRequestReviews", mock.Anything, "testowner", "testrepo", mock.Anything).Return([]api.Review{}, nil)
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "testrepo: PR #123 is stale", "Stale PR by testuser waiting on alice").Return(nil)
+
+	task := NewPRReviewCheckTask(cfg, mockNotifier, "")
+	task.apiClient = mockAPI
+
+	require.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertExpectations(t)
+}
@@ -2,10 +2,16 @@ package tasks
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
 	"time"
 	"watchdog/internal/api"
+	"watchdog/internal/clock"
+	"watchdog/internal/metrics"
+	"watchdog/internal/money"
 	"watchdog/internal/notifier"
+	"watchdog/internal/state"
 
 	"github.com/rs/zerolog/log"
 )
@@ -17,6 +23,8 @@ import (
 //  1. Fetches the current balance from Telnyx API
 //  2. Compares it against the configured threshold
 //  3. Sends a notification if balance is too low (with cooldown to prevent spam)
+//  4. Sends a one-time recovery notification once the balance is back above the threshold
+//  5. Optionally warns when the balance is dropping fast enough to run out soon
 //
 // This implements the scheduler.Task interface via the Run() method.
 type TelnyxBalanceCheckTask struct {
@@ -32,6 +40,15 @@ type TelnyxBalanceCheckTask struct {
 	// Used to enforce the cooldown period
 	lastNotificationTime time.Time
 
+	// belowThreshold is true once a low balance alert has been sent and the balance
+	// hasn't recovered since. The next run above the threshold sends a recovery notification.
+	belowThreshold bool
+
+	// lastRecoveryTime tracks when we last sent a recovery notification.
+	// Recoveries have their own cooldown so a balance hovering around the threshold
+	// doesn't produce a stream of "recovered" messages.
+	lastRecoveryTime time.Time
+
 	// apiClient is used to fetch balance data from Telnyx
 	apiClient api.TelnyxClient
 
@@ -45,6 +62,118 @@ type TelnyxBalanceCheckTask struct {
 	// hasRunBefore indicates if this task has executed at least once
 	// Used to ensure we always log the balance on the very first run
 	hasRunBefore bool
+
+	// state persists lastNotificationTime across restarts (nil = in-memory only)
+	state state.StateStore
+
+	// MinBalanceChange is how much the balance must have dropped since the last alert
+	// before another alert is sent, on top of the cooldown. 0 disables the check.
+	MinBalanceChange float64
+
+	// MinBalanceChangeIsPercent makes MinBalanceChange a percentage of the last
+	// alerted balance instead of an absolute amount.
+	MinBalanceChangeIsPercent bool
+
+	// Tags routes this task's notifications to the Apprise services with these tags.
+	Tags []string
+
+	// Locale formats amounts in notifications (e.g., "de-DE" gives "1.234,50 €").
+	// Empty keeps the plain "$5.00" / "5.00 EUR" format.
+	Locale string
+
+	// lastAlertedBalance is the balance reported in the most recent low balance alert.
+	// Only meaningful while belowThreshold is true.
+	lastAlertedBalance float64
+
+	// Timeout bounds each run: the balance request including retries, and any alert it
+	// sends. 0 uses defaultTelnyxTimeout.
+	Timeout time.Duration
+
+	// MinRunway alerts when the balance, at the rate it has been dropping, runs out within
+	// this long, even while it is still above the threshold. 0 disables runway alerts.
+	MinRunway time.Duration
+
+	// history holds recent balance readings for the depletion rate (see recordReading)
+	history []balanceReading
+
+	// lastRunwayAlertTime tracks when we last sent a "balance depleting" alert
+	lastRunwayAlertTime time.Time
+
+	// NotifyOnAPIError sends an "API unreachable" notification once this many balance
+	// fetches in a row have failed. 0 disables it.
+	NotifyOnAPIError int
+
+	// apiFailures is how many balance fetches in a row have failed
+	apiFailures int
+
+	// apiErrorNotified is true once the current failure streak has been dealt with
+	// (notified, or skipped due to the cooldown), so it is only notified about once
+	apiErrorNotified bool
+
+	// lastAPIErrorTime tracks when we last sent an "API unreachable" notification.
+	// It has its own cooldown, so an API that keeps flapping isn't reported on every streak.
+	lastAPIErrorTime time.Time
+
+	// Clock tells the time for cooldowns and staleness checks (nil means the system clock)
+	Clock clock.Clock
+}
+
+// defaultTelnyxTimeout bounds a run when TelnyxBalanceCheckTask.Timeout isn't set.
+const defaultTelnyxTimeout = 30 * time.Second
+
+// State file namespace and keys for the low balance alert and recovery cooldowns.
+const (
+	telnyxStateNamespace   = "telnyx"
+	telnyxStateKey         = "low_balance"
+	telnyxRecoveryStateKey = "recovered"
+	telnyxRunwayStateKey   = "runway"
+	telnyxAPIErrorStateKey = "api_error"
+)
+
+// LoadState restores the alert cooldowns saved by a previous process from store,
+// and saves them back whenever a notification is sent. A nil store keeps them in memory only.
+func (t *TelnyxBalanceCheckTask) LoadState(store state.StateStore) {
+	t.state = store
+	if store == nil {
+		return
+	}
+	saved := store.Load(telnyxStateNamespace)
+	if lastTime, ok := saved[telnyxStateKey]; ok {
+		t.lastNotificationTime = lastTime
+	}
+	if lastTime, ok := saved[telnyxRecoveryStateKey]; ok {
+		t.lastRecoveryTime = lastTime
+	}
+	if lastTime, ok := saved[telnyxRunwayStateKey]; ok {
+		t.lastRunwayAlertTime = lastTime
+	}
+	if lastTime, ok := saved[telnyxAPIErrorStateKey]; ok {
+		t.lastAPIErrorTime = lastTime
+	}
+}
+
+// saveState persists the alert cooldowns, logging (not returning) failures.
+func (t *TelnyxBalanceCheckTask) saveState() {
+	if t.state == nil {
+		return
+	}
+
+	entries := map[string]time.Time{}
+	if !t.lastNotificationTime.IsZero() {
+		entries[telnyxStateKey] = t.lastNotificationTime
+	}
+	if !t.lastRecoveryTime.IsZero() {
+		entries[telnyxRecoveryStateKey] = t.lastRecoveryTime
+	}
+	if !t.lastRunwayAlertTime.IsZero() {
+		entries[telnyxRunwayStateKey] = t.lastRunwayAlertTime
+	}
+	if !t.lastAPIErrorTime.IsZero() {
+		entries[telnyxAPIErrorStateKey] = t.lastAPIErrorTime
+	}
+	if err := t.state.Save(telnyxStateNamespace, entries); err != nil {
+		log.Error().Err(err).Msg("Failed to save notification state")
+	}
 }
 
 // NewTelnyxBalanceCheckTask creates a new Telnyx balance monitoring task.
@@ -70,9 +199,15 @@ func NewTelnyxBalanceCheckTask(apiURL, apiKey string, threshold float64, cooldow
 		notificationCooldown: cooldown,
 		apiClient:            api.NewTelnyxAPI(apiURL, apiKey),
 		notifier:             notifier,
+		Clock:                clock.Real{},
 	}
 }
 
+// Name identifies the task in logs and status reports.
+func (t *TelnyxBalanceCheckTask) Name() string {
+	return "telnyx-balance"
+}
+
 // Run executes the balance check logic.
 // This method is called periodically by the scheduler (e.g., every 5 minutes).
 //
@@ -83,6 +218,10 @@ func NewTelnyxBalanceCheckTask(apiURL, apiKey string, threshold float64, cooldow
 //     a. Checks if we're still in the cooldown period
 //     b. If cooldown expired, sends a notification
 //     c. Records the notification time to start a new cooldown
+//  4. If balance >= threshold after a low balance alert, sends a single recovery
+//     notification (subject to its own cooldown)
+//  5. Otherwise, if MinRunway is set and the balance is projected to run out within it
+//     at its recent depletion rate, sends a "balance depleting" alert (with cooldown)
 //
 // Returns:
 //   - An error if the API request fails
@@ -91,21 +230,39 @@ func NewTelnyxBalanceCheckTask(apiURL, apiKey string, threshold float64, cooldow
 //
 // The cooldown mechanism prevents spamming alerts every 5 minutes when balance is low.
 // For example, with a 6-hour cooldown, you'll only get one alert every 6 hours.
-func (t *TelnyxBalanceCheckTask) Run() error {
-	// Create a context with a reasonable timeout for the task
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+func (t *TelnyxBalanceCheckTask) Run(ctx context.Context) error {
+	// Bound the run with the configured timeout
+	timeout := t.Timeout
+	if timeout <= 0 {
+		timeout = defaultTelnyxTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
+	ctx = notifier.WithTags(ctx, t.Tags...)
+
+	defer metrics.ObserveTaskRun(t.Name(), time.Now())
 
 	// Fetch current balance from Telnyx
-	balance, err := t.apiClient.GetBalance(ctx)
+	current, err := t.apiClient.GetBalance(ctx)
+	var budget *api.BudgetExceededError
+	if errors.As(err, &budget) {
+		// Not a failure: the balance is checked again once the budget resets
+		log.Warn().Time("reset", budget.Reset).Msg("API request budget used up, skipping balance check until the budget resets")
+		return nil
+	}
 	if err != nil {
-		return fmt.Errorf("failed to get balance: %v", err)
+		t.apiFailed(ctx, err)
+		return fmt.Errorf("failed to get balance: %w", err)
 	}
+	t.apiFailures, t.apiErrorNotified = 0, false
+	balance := current.Amount
+	metrics.TelnyxBalance.Set(balance)
+	t.recordReading(balance, clock.Now(t.Clock))
 
 	// Log the balance ONLY if it has changed since the last check
 	// This reduces log spam in the console
 	if !t.hasRunBefore || balance != t.lastObservedBalance {
-		log.Info().Float64("balance", balance).Msg("Current Telnyx balance")
+		log.Info().Float64("balance", balance).Str("currency", current.Currency).Msg("Current Telnyx balance")
 		t.lastObservedBalance = balance
 		t.hasRunBefore = true
 	}
@@ -115,7 +272,7 @@ func (t *TelnyxBalanceCheckTask) Run() error {
 		// Check notification cooldown
 		// We don't want to spam notifications every 5 minutes when balance is low
 		// Only send if we haven't notified recently (or if this is the first notification)
-		if !t.lastNotificationTime.IsZero() && time.Since(t.lastNotificationTime) < t.notificationCooldown {
+		if !t.lastNotificationTime.IsZero() && clock.Since(t.Clock, t.lastNotificationTime) < t.notificationCooldown {
 			log.Info().
 				Float64("balance", balance).
 				Time("last_sent", t.lastNotificationTime).
@@ -123,18 +280,121 @@ func (t *TelnyxBalanceCheckTask) Run() error {
 			return nil
 		}
 
+		// After the first alert, only alert again if the balance dropped noticeably
+		if t.belowThreshold && !t.droppedEnough(balance) {
+			log.Info().
+				Float64("balance", balance).
+				Float64("last_alerted_balance", t.lastAlertedBalance).
+				Msg("Balance below threshold, skipping notification as it hasn't dropped enough since the last alert")
+			return nil
+		}
+
 		// Balance is low and cooldown has expired - send notification
 		subject := "Telnyx Balance Alert"
-		message := fmt.Sprintf("Your Telnyx balance ($%.2f) has fallen below the $%.2f threshold.", balance, t.threshold)
-		err = t.notifier.SendNotification(ctx, subject, message)
+		message := fmt.Sprintf("Your Telnyx balance (%s) has fallen below the %s threshold.",
+			t.formatAmount(balance, current.Currency), t.formatAmount(t.threshold, current.Currency))
+		err = t.notifier.SendNotification(notifier.WithSeverity(ctx, notifier.SeverityWarning), subject, message)
 		if err != nil {
 			return fmt.Errorf("failed to send notification: %v", err)
 		}
 
 		// Record that we sent a notification
 		// This starts the cooldown period
-		t.lastNotificationTime = time.Now()
+		t.lastNotificationTime = clock.Now(t.Clock)
+		t.belowThreshold = true
+		t.lastAlertedBalance = balance
+		t.saveState()
+	} else if t.belowThreshold {
+		// Balance is back above the threshold after an alert: let the user know once
+		return t.notifyRecovered(ctx, current)
+	} else {
+		// Still above the threshold, but warn if it is dropping fast
+		return t.checkRunway(ctx, current)
+	}
+
+	return nil
+}
+
+// apiFailed counts a failed balance fetch and, once NotifyOnAPIError fetches in a row have
+// failed, sends the "API unreachable" notification (once per failure streak). Within its
+// cooldown the notification is skipped, and not sent later in the same streak.
+// A failed notification is logged and retried on the next failure.
+func (t *TelnyxBalanceCheckTask) apiFailed(ctx context.Context, err error) {
+	t.apiFailures++
+	if t.NotifyOnAPIError <= 0 || t.apiFailures < t.NotifyOnAPIError || t.apiErrorNotified {
+		return
+	}
+
+	if !t.lastAPIErrorTime.IsZero() && clock.Since(t.Clock, t.lastAPIErrorTime) < t.notificationCooldown {
+		log.Info().
+			Int("failures", t.apiFailures).
+			Time("last_sent", t.lastAPIErrorTime).
+			Msg("Telnyx API unreachable, skipping notification due to cooldown")
+		t.apiErrorNotified = true
+		return
+	}
+
+	// A hung API uses up the run's timeout, which must not also stop the notification
+	if ctx.Err() == context.DeadlineExceeded {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.WithoutCancel(ctx), defaultTelnyxTimeout)
+		defer cancel()
+	}
+
+	subject := "Telnyx API unreachable"
+	message := fmt.Sprintf("The Telnyx balance check has failed %d times in a row, so the balance isn't being monitored.\nLast error: %v",
+		t.apiFailures, err)
+	if err := t.notifier.SendNotification(notifier.WithSeverity(ctx, notifier.SeverityFailure), subject, message); err != nil {
+		log.Error().Err(err).Msg("Failed to send Telnyx API error notification")
+		return
 	}
 
+	t.apiErrorNotified = true
+	t.lastAPIErrorTime = clock.Now(t.Clock)
+	t.saveState()
+}
+
+// droppedEnough reports whether balance is at least MinBalanceChange below the last
+// alerted balance (or that percentage of it). Always true when no minimum is configured.
+func (t *TelnyxBalanceCheckTask) droppedEnough(balance float64) bool {
+	if t.MinBalanceChange <= 0 {
+		return true
+	}
+	minDrop := t.MinBalanceChange
+	if t.MinBalanceChangeIsPercent {
+		minDrop = math.Abs(t.lastAlertedBalance) * t.MinBalanceChange / 100
+	}
+	return t.lastAlertedBalance-balance >= minDrop
+}
+
+// notifyRecovered sends the one-time "balance restored" notification.
+// Within the recovery cooldown the notification is skipped, but the recovery still
+// counts, so it is never sent later for the same top-up.
+func (t *TelnyxBalanceCheckTask) notifyRecovered(ctx context.Context, current api.Balance) error {
+	if !t.lastRecoveryTime.IsZero() && clock.Since(t.Clock, t.lastRecoveryTime) < t.notificationCooldown {
+		log.Info().
+			Float64("balance", current.Amount).
+			Time("last_sent", t.lastRecoveryTime).
+			Msg("Balance recovered, skipping notification due to cooldown")
+		t.belowThreshold = false
+		return nil
+	}
+
+	subject := "Telnyx Balance Recovered"
+	message := fmt.Sprintf("Your Telnyx balance has been restored to %s (threshold: %s).",
+		t.formatAmount(current.Amount, current.Currency), t.formatAmount(t.threshold, current.Currency))
+	if err := t.notifier.SendNotification(notifier.WithSeverity(ctx, notifier.SeveritySuccess), subject, message); err != nil {
+		return fmt.Errorf("failed to send notification: %v", err)
+	}
+
+	t.belowThreshold = false
+	t.lastRecoveryTime = clock.Now(t.Clock)
+	t.saveState()
 	return nil
 }
+
+// formatAmount renders an amount in the given currency for the task's Locale
+// (see money.Format); without a locale, "$5.00" for USD or "5.00 EUR" otherwise.
+func (t *TelnyxBalanceCheckTask) formatAmount(amount float64, currency string) string {
+	return money.Format(amount, currency, t.Locale)
+}
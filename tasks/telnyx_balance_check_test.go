@@ -3,8 +3,19 @@ package tasks
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+	"watchdog/internal/api"
+	"watchdog/internal/clock"
+	"watchdog/internal/metrics"
+	"watchdog/internal/notifier"
+	"watchdog/internal/state"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -16,9 +27,9 @@ type MockTelnyxClient struct {
 	mock.Mock
 }
 
-func (m *MockTelnyxClient) GetBalance(ctx context.Context) (float64, error) {
+func (m *MockTelnyxClient) GetBalance(ctx context.Context) (api.Balance, error) {
 	args := m.Called(ctx)
-	return args.Get(0).(float64), args.Error(1)
+	return args.Get(0).(api.Balance), args.Error(1)
 }
 
 // MockNotifier mocks the notification interface
@@ -48,6 +59,28 @@ func TestNewTelnyxBalanceCheckTask(t *testing.T) {
 	assert.True(t, task.lastNotificationTime.IsZero())
 }
 
+func TestTelnyxBalanceCheckTask_Run_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(200 * time.Millisecond):
+		case <-r.Context().Done():
+			return
+		}
+		_, _ = io.WriteString(w, `{"data": {"balance": "25.00", "currency": "USD"}}`)
+	}))
+	defer server.Close()
+
+	task := NewTelnyxBalanceCheckTask(server.URL, "KEY123", 10.0, 6*time.Hour, &MockNotifier{})
+
+	task.Timeout = 50 * time.Millisecond
+	err := task.Run(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "deadline exceeded")
+
+	task.Timeout = 5 * time.Second
+	assert.NoError(t, task.Run(context.Background()))
+}
+
 func TestTelnyxBalanceCheckTask_Run_BalanceAboveThreshold(t *testing.T) {
 	task := &TelnyxBalanceCheckTask{
 		threshold:            10.0,
@@ -55,13 +88,13 @@ func TestTelnyxBalanceCheckTask_Run_BalanceAboveThreshold(t *testing.T) {
 	}
 
 	mockAPI := &MockTelnyxClient{}
-	mockAPI.On("GetBalance", mock.Anything).Return(25.0, nil)
+	mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{Amount: 25.0}, nil)
 	task.apiClient = mockAPI
 
 	mockNotifier := &MockNotifier{}
 	task.notifier = mockNotifier
 
-	err := task.Run()
+	err := task.Run(context.Background())
 
 	assert.NoError(t, err)
 	mockAPI.AssertExpectations(t)
@@ -76,7 +109,7 @@ func TestTelnyxBalanceCheckTask_Run_BalanceBelowThreshold_SendsNotification(t *t
 	}
 
 	mockAPI := &MockTelnyxClient{}
-	mockAPI.On("GetBalance", mock.Anything).Return(5.0, nil)
+	mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{Amount: 5.0}, nil)
 	task.apiClient = mockAPI
 
 	mockNotifier := &MockNotifier{}
@@ -85,7 +118,7 @@ func TestTelnyxBalanceCheckTask_Run_BalanceBelowThreshold_SendsNotification(t *t
 	})).Return(nil)
 	task.notifier = mockNotifier
 
-	err := task.Run()
+	err := task.Run(context.Background())
 
 	assert.NoError(t, err)
 	mockAPI.AssertExpectations(t)
@@ -93,6 +126,90 @@ func TestTelnyxBalanceCheckTask_Run_BalanceBelowThreshold_SendsNotification(t *t
 	assert.False(t, task.lastNotificationTime.IsZero())
 }
 
+func TestTelnyxBalanceCheckTask_Run_MessagePrefixAndSuffix(t *testing.T) {
+	task := &TelnyxBalanceCheckTask{
+		threshold:            10.0,
+		notificationCooldown: 6 * time.Hour,
+	}
+
+	mockAPI := &MockTelnyxClient{}
+	mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{Amount: 5.0}, nil)
+	task.apiClient = mockAPI
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "Telnyx Balance Alert", mock.MatchedBy(func(msg string) bool {
+		return strings.HasPrefix(msg, "[staging]\n") && strings.HasSuffix(msg, "\n-- watchdog") && strings.Contains(msg, "$5.00")
+	})).Return(nil)
+	task.notifier = notifier.NewPrefixNotifier(mockNotifier, "[staging]", "-- watchdog")
+
+	require.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertExpectations(t)
+}
+
+func TestTelnyxBalanceCheckTask_Run_NotificationUsesReportedCurrency(t *testing.T) {
+	tests := []struct {
+		currency string
+		expected string
+	}{
+		{currency: "EUR", expected: "Your Telnyx balance (5.00 EUR) has fallen below the 10.00 EUR threshold."},
+		{currency: "GBP", expected: "Your Telnyx balance (5.00 GBP) has fallen below the 10.00 GBP threshold."},
+		{currency: "", expected: "Your Telnyx balance ($5.00) has fallen below the $10.00 threshold."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.currency, func(t *testing.T) {
+			task := &TelnyxBalanceCheckTask{
+				threshold:            10.0,
+				notificationCooldown: 6 * time.Hour,
+			}
+
+			mockAPI := &MockTelnyxClient{}
+			mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{Amount: 5.0, Currency: tt.currency}, nil)
+			task.apiClient = mockAPI
+
+			mockNotifier := &MockNotifier{}
+			mockNotifier.On("SendNotification", mock.Anything, "Telnyx Balance Alert", tt.expected).Return(nil)
+			task.notifier = mockNotifier
+
+			assert.NoError(t, task.Run(context.Background()))
+			mockNotifier.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTelnyxBalanceCheckTask_Run_NotificationUsesLocale(t *testing.T) {
+	tests := []struct {
+		locale   string
+		currency string
+		expected string
+	}{
+		{locale: "en-US", currency: "USD", expected: "Your Telnyx balance ($1,234.56) has fallen below the $2,000.00 threshold."},
+		{locale: "de-DE", currency: "EUR", expected: "Your Telnyx balance (1.234,56 €) has fallen below the 2.000,00 € threshold."},
+		{locale: "ja-JP", currency: "JPY", expected: "Your Telnyx balance (¥1,235) has fallen below the ¥2,000 threshold."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			task := &TelnyxBalanceCheckTask{
+				threshold:            2000.0,
+				notificationCooldown: 6 * time.Hour,
+				Locale:               tt.locale,
+			}
+
+			mockAPI := &MockTelnyxClient{}
+			mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{Amount: 1234.56, Currency: tt.currency}, nil)
+			task.apiClient = mockAPI
+
+			mockNotifier := &MockNotifier{}
+			mockNotifier.On("SendNotification", mock.Anything, "Telnyx Balance Alert", tt.expected).Return(nil)
+			task.notifier = mockNotifier
+
+			assert.NoError(t, task.Run(context.Background()))
+			mockNotifier.AssertExpectations(t)
+		})
+	}
+}
+
 func TestTelnyxBalanceCheckTask_Run_BalanceBelowThreshold_RespectsCooldown(t *testing.T) {
 	task := &TelnyxBalanceCheckTask{
 		threshold:            10.0,
@@ -101,13 +218,13 @@ func TestTelnyxBalanceCheckTask_Run_BalanceBelowThreshold_RespectsCooldown(t *te
 	}
 
 	mockAPI := &MockTelnyxClient{}
-	mockAPI.On("GetBalance", mock.Anything).Return(5.0, nil)
+	mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{Amount: 5.0}, nil)
 	task.apiClient = mockAPI
 
 	mockNotifier := &MockNotifier{}
 	task.notifier = mockNotifier
 
-	err := task.Run()
+	err := task.Run(context.Background())
 
 	assert.NoError(t, err)
 	mockAPI.AssertExpectations(t)
@@ -115,6 +232,142 @@ func TestTelnyxBalanceCheckTask_Run_BalanceBelowThreshold_RespectsCooldown(t *te
 	mockNotifier.AssertNotCalled(t, "SendNotification", mock.Anything, mock.Anything, mock.Anything)
 }
 
+func TestTelnyxBalanceCheckTask_Run_CooldownPersistsAcrossRestart(t *testing.T) {
+	store := state.NewFileStore(filepath.Join(t.TempDir(), "state.json"))
+
+	mockAPI := &MockTelnyxClient{}
+	mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{Amount: 5.0}, nil)
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "Telnyx Balance Alert", mock.Anything).Return(nil).Once()
+
+	// First process alerts and persists the cooldown
+	first := NewTelnyxBalanceCheckTask("https://api.telnyx.com/v2/balance", "KEY123", 10.0, time.Hour, mockNotifier)
+	first.apiClient = mockAPI
+	first.LoadState(store)
+	require.NoError(t, first.Run(context.Background()))
+
+	// After a "restart" the new task is still in cooldown
+	restarted := NewTelnyxBalanceCheckTask("https://api.telnyx.com/v2/balance", "KEY123", 10.0, time.Hour, mockNotifier)
+	restarted.apiClient = mockAPI
+	restarted.LoadState(state.NewFileStore(store.Path))
+	assert.WithinDuration(t, first.lastNotificationTime, restarted.lastNotificationTime, time.Second)
+	require.NoError(t, restarted.Run(context.Background()))
+
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 1)
+}
+
+// runTelnyxOutcomes runs task once per outcome (true = the balance fetch succeeds),
+// advancing fake by a minute before each run.
+func runTelnyxOutcomes(t *testing.T, task *TelnyxBalanceCheckTask, fake *clock.Fake, outcomes ...bool) {
+	t.Helper()
+	for _, ok := range outcomes {
+		mockAPI := &MockTelnyxClient{}
+		if ok {
+			mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{Amount: 50, Currency: "USD"}, nil)
+		} else {
+			mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{}, &api.APIError{Service: "telnyx", StatusCode: 401, Body: "Authentication failed"})
+		}
+		task.apiClient = mockAPI
+		fake.Advance(time.Minute)
+		err := task.Run(context.Background())
+		if ok {
+			require.NoError(t, err)
+		} else {
+			require.Error(t, err)
+		}
+	}
+}
+
+func TestTelnyxBalanceCheckTask_Run_NotifyOnAPIError(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	var subjects, messages []string
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			subjects = append(subjects, args.String(1))
+			messages = append(messages, args.String(2))
+		}).
+		Return(nil)
+
+	task := NewTelnyxBalanceCheckTask("https://api.telnyx.com/v2/balance", "KEY123", 10.0, time.Hour, mockNotifier)
+	task.NotifyOnAPIError = 3
+	task.Clock = fake
+
+	// Below the threshold nothing is sent
+	runTelnyxOutcomes(t, task, fake, false, false)
+	assert.Empty(t, subjects)
+
+	// The third failure in a row notifies, once for the streak
+	runTelnyxOutcomes(t, task, fake, false, false, false)
+	require.Equal(t, []string{"Telnyx API unreachable"}, subjects)
+	assert.Contains(t, messages[0], "failed 3 times in a row")
+	assert.Contains(t, messages[0], "Authentication failed")
+
+	// A success resets the count: two more failures aren't a streak of three
+	runTelnyxOutcomes(t, task, fake, true, false, false)
+	assert.Len(t, subjects, 1)
+
+	// A new streak within the cooldown isn't notified, even once the cooldown ends
+	runTelnyxOutcomes(t, task, fake, false)
+	fake.Advance(time.Hour)
+	runTelnyxOutcomes(t, task, fake, false)
+	assert.Len(t, subjects, 1)
+
+	// After the cooldown, the next streak reaching the threshold is notified
+	runTelnyxOutcomes(t, task, fake, true, false, false, false)
+	assert.Equal(t, []string{"Telnyx API unreachable", "Telnyx API unreachable"}, subjects)
+}
+
+func TestTelnyxBalanceCheckTask_Run_NotifyOnAPIError_Disabled(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	mockNotifier := &MockNotifier{}
+
+	task := NewTelnyxBalanceCheckTask("https://api.telnyx.com/v2/balance", "KEY123", 10.0, time.Hour, mockNotifier)
+	task.Clock = fake
+
+	runTelnyxOutcomes(t, task, fake, false, false, false, false, false)
+	mockNotifier.AssertNotCalled(t, "SendNotification", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTelnyxBalanceCheckTask_Run_NotifyOnAPIError_RetriesFailedNotification(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "Telnyx API unreachable", mock.Anything).Return(errors.New("apprise down")).Once()
+	mockNotifier.On("SendNotification", mock.Anything, "Telnyx API unreachable", mock.Anything).Return(nil).Once()
+
+	task := NewTelnyxBalanceCheckTask("https://api.telnyx.com/v2/balance", "KEY123", 10.0, time.Hour, mockNotifier)
+	task.NotifyOnAPIError = 2
+	task.Clock = fake
+
+	runTelnyxOutcomes(t, task, fake, false, false, false, false)
+	mockNotifier.AssertExpectations(t)
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 2)
+}
+
+func TestTelnyxBalanceCheckTask_Run_CooldownPersistsInMemoryStore(t *testing.T) {
+	store := &memoryStateStore{}
+
+	mockAPI := &MockTelnyxClient{}
+	mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{Amount: 5.0}, nil)
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "Telnyx Balance Alert", mock.Anything).Return(nil).Once()
+
+	first := NewTelnyxBalanceCheckTask("https://api.telnyx.com/v2/balance", "KEY123", 10.0, time.Hour, mockNotifier)
+	first.apiClient = mockAPI
+	first.LoadState(store)
+	require.NoError(t, first.Run(context.Background()))
+	assert.Contains(t, store.Load(telnyxStateNamespace), telnyxStateKey)
+
+	restarted := NewTelnyxBalanceCheckTask("https://api.telnyx.com/v2/balance", "KEY123", 10.0, time.Hour, mockNotifier)
+	restarted.apiClient = mockAPI
+	restarted.LoadState(store)
+	require.NoError(t, restarted.Run(context.Background()))
+
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 1)
+}
+
 func TestTelnyxBalanceCheckTask_Run_BalanceBelowThreshold_CooldownExpired(t *testing.T) {
 	task := &TelnyxBalanceCheckTask{
 		threshold:            10.0,
@@ -123,20 +376,50 @@ func TestTelnyxBalanceCheckTask_Run_BalanceBelowThreshold_CooldownExpired(t *tes
 	}
 
 	mockAPI := &MockTelnyxClient{}
-	mockAPI.On("GetBalance", mock.Anything).Return(5.0, nil)
+	mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{Amount: 5.0}, nil)
 	task.apiClient = mockAPI
 
 	mockNotifier := &MockNotifier{}
 	mockNotifier.On("SendNotification", mock.Anything, "Telnyx Balance Alert", mock.Anything).Return(nil)
 	task.notifier = mockNotifier
 
-	err := task.Run()
+	err := task.Run(context.Background())
 
 	assert.NoError(t, err)
 	mockAPI.AssertExpectations(t)
 	mockNotifier.AssertExpectations(t)
 }
 
+func TestTelnyxBalanceCheckTask_Run_CooldownBoundary(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	task := &TelnyxBalanceCheckTask{
+		threshold:            10.0,
+		notificationCooldown: time.Hour,
+		Clock:                fake,
+	}
+
+	mockAPI := &MockTelnyxClient{}
+	mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{Amount: 5.0}, nil)
+	task.apiClient = mockAPI
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "Telnyx Balance Alert", mock.Anything).Return(nil)
+	task.notifier = mockNotifier
+
+	require.NoError(t, task.Run(context.Background()))
+	assert.Equal(t, fake.Now(), task.lastNotificationTime)
+
+	// One second before the cooldown ends: still suppressed
+	fake.Advance(time.Hour - time.Second)
+	require.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 1)
+
+	// Exactly at the end of the cooldown: alerts again
+	fake.Advance(time.Second)
+	require.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 2)
+}
+
 func TestTelnyxBalanceCheckTask_Run_APIError(t *testing.T) {
 	task := &TelnyxBalanceCheckTask{
 		threshold:            10.0,
@@ -144,13 +427,13 @@ func TestTelnyxBalanceCheckTask_Run_APIError(t *testing.T) {
 	}
 
 	mockAPI := &MockTelnyxClient{}
-	mockAPI.On("GetBalance", mock.Anything).Return(0.0, errors.New("API connection failed"))
+	mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{}, errors.New("API connection failed"))
 	task.apiClient = mockAPI
 
 	mockNotifier := &MockNotifier{}
 	task.notifier = mockNotifier
 
-	err := task.Run()
+	err := task.Run(context.Background())
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to get balance")
@@ -158,6 +441,26 @@ func TestTelnyxBalanceCheckTask_Run_APIError(t *testing.T) {
 	mockNotifier.AssertNotCalled(t, "SendNotification", mock.Anything, mock.Anything, mock.Anything)
 }
 
+func TestTelnyxBalanceCheckTask_Run_BudgetExceeded(t *testing.T) {
+	task := &TelnyxBalanceCheckTask{
+		threshold:            10.0,
+		notificationCooldown: 6 * time.Hour,
+	}
+
+	mockAPI := &MockTelnyxClient{}
+	mockAPI.On("GetBalance", mock.Anything).
+		Return(api.Balance{}, fmt.Errorf("failed to fetch balance: %w", &api.BudgetExceededError{Limit: 100, Reset: time.Now().Add(time.Hour)}))
+	task.apiClient = mockAPI
+
+	mockNotifier := &MockNotifier{}
+	task.notifier = mockNotifier
+
+	// A used-up budget defers the check to the next run instead of failing it
+	assert.NoError(t, task.Run(context.Background()))
+	mockAPI.AssertExpectations(t)
+	mockNotifier.AssertNotCalled(t, "SendNotification", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestTelnyxBalanceCheckTask_Run_NotificationError(t *testing.T) {
 	task := &TelnyxBalanceCheckTask{
 		threshold:            10.0,
@@ -165,14 +468,14 @@ func TestTelnyxBalanceCheckTask_Run_NotificationError(t *testing.T) {
 	}
 
 	mockAPI := &MockTelnyxClient{}
-	mockAPI.On("GetBalance", mock.Anything).Return(5.0, nil)
+	mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{Amount: 5.0}, nil)
 	task.apiClient = mockAPI
 
 	mockNotifier := &MockNotifier{}
 	mockNotifier.On("SendNotification", mock.Anything, "Telnyx Balance Alert", mock.Anything).Return(errors.New("notification failed"))
 	task.notifier = mockNotifier
 
-	err := task.Run()
+	err := task.Run(context.Background())
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to send notification")
@@ -189,13 +492,13 @@ func TestTelnyxBalanceCheckTask_Run_BalanceExactlyAtThreshold(t *testing.T) {
 	}
 
 	mockAPI := &MockTelnyxClient{}
-	mockAPI.On("GetBalance", mock.Anything).Return(10.0, nil)
+	mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{Amount: 10.0}, nil)
 	task.apiClient = mockAPI
 
 	mockNotifier := &MockNotifier{}
 	task.notifier = mockNotifier
 
-	err := task.Run()
+	err := task.Run(context.Background())
 
 	assert.NoError(t, err)
 	mockAPI.AssertExpectations(t)
@@ -210,7 +513,7 @@ func TestTelnyxBalanceCheckTask_Run_VeryLowBalance(t *testing.T) {
 	}
 
 	mockAPI := &MockTelnyxClient{}
-	mockAPI.On("GetBalance", mock.Anything).Return(0.01, nil)
+	mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{Amount: 0.01}, nil)
 	task.apiClient = mockAPI
 
 	mockNotifier := &MockNotifier{}
@@ -219,7 +522,7 @@ func TestTelnyxBalanceCheckTask_Run_VeryLowBalance(t *testing.T) {
 	})).Return(nil)
 	task.notifier = mockNotifier
 
-	err := task.Run()
+	err := task.Run(context.Background())
 
 	assert.NoError(t, err)
 	mockAPI.AssertExpectations(t)
@@ -233,7 +536,7 @@ func TestTelnyxBalanceCheckTask_Run_NegativeBalance(t *testing.T) {
 	}
 
 	mockAPI := &MockTelnyxClient{}
-	mockAPI.On("GetBalance", mock.Anything).Return(-5.0, nil)
+	mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{Amount: -5.0}, nil)
 	task.apiClient = mockAPI
 
 	mockNotifier := &MockNotifier{}
@@ -242,13 +545,154 @@ func TestTelnyxBalanceCheckTask_Run_NegativeBalance(t *testing.T) {
 	})).Return(nil)
 	task.notifier = mockNotifier
 
-	err := task.Run()
+	err := task.Run(context.Background())
 
 	assert.NoError(t, err)
 	mockAPI.AssertExpectations(t)
 	mockNotifier.AssertExpectations(t)
 }
 
+func TestTelnyxBalanceCheckTask_Run_MinBalanceChange(t *testing.T) {
+	tests := []struct {
+		name      string
+		minChange float64
+		percent   bool
+		balances  []float64
+		alerts    int
+	}{
+		{name: "flat balance suppressed", minChange: 1.0, balances: []float64{5.0, 4.9, 4.8, 4.5}, alerts: 1},
+		{name: "sharp drop alerts", minChange: 1.0, balances: []float64{5.0, 4.9, 3.5, 3.4}, alerts: 2},
+		{name: "percentage flat suppressed", minChange: 20, percent: true, balances: []float64{5.0, 4.5, 4.1}, alerts: 1},
+		{name: "percentage sharp drop alerts", minChange: 20, percent: true, balances: []float64{5.0, 4.0, 3.9, 3.1}, alerts: 3},
+		{name: "disabled alerts every time", balances: []float64{5.0, 5.0, 6.0}, alerts: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A negligible cooldown so only the minimum change decides
+			task := &TelnyxBalanceCheckTask{
+				threshold:                 10.0,
+				notificationCooldown:      time.Nanosecond,
+				MinBalanceChange:          tt.minChange,
+				MinBalanceChangeIsPercent: tt.percent,
+			}
+
+			mockAPI := &MockTelnyxClient{}
+			for _, balance := range tt.balances {
+				mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{Amount: balance}, nil).Once()
+			}
+			task.apiClient = mockAPI
+
+			mockNotifier := &MockNotifier{}
+			mockNotifier.On("SendNotification", mock.Anything, "Telnyx Balance Alert", mock.Anything).Return(nil)
+			task.notifier = mockNotifier
+
+			for range tt.balances {
+				require.NoError(t, task.Run(context.Background()))
+			}
+
+			mockAPI.AssertExpectations(t)
+			mockNotifier.AssertNumberOfCalls(t, "SendNotification", tt.alerts)
+		})
+	}
+}
+
+func TestTelnyxBalanceCheckTask_Run_NotificationTags(t *testing.T) {
+	task := &TelnyxBalanceCheckTask{
+		threshold:            10.0,
+		notificationCooldown: 6 * time.Hour,
+		Tags:                 []string{"billing"},
+	}
+
+	mockAPI := &MockTelnyxClient{}
+	mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{Amount: 5.0}, nil)
+	task.apiClient = mockAPI
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.MatchedBy(func(ctx context.Context) bool {
+		tags := notifier.TagsFromContext(ctx)
+		return len(tags) == 1 && tags[0] == "billing"
+	}), "Telnyx Balance Alert", mock.Anything).Return(nil)
+	task.notifier = mockNotifier
+
+	require.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertExpectations(t)
+}
+
+func TestTelnyxBalanceCheckTask_Run_SendsOneRecoveryNotification(t *testing.T) {
+	task := &TelnyxBalanceCheckTask{
+		threshold:            10.0,
+		notificationCooldown: 6 * time.Hour,
+	}
+
+	mockAPI := &MockTelnyxClient{}
+	mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{Amount: 5.0}, nil).Once()
+	mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{Amount: 25.0}, nil).Times(3)
+	task.apiClient = mockAPI
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.MatchedBy(func(ctx context.Context) bool {
+		return notifier.SeverityFromContext(ctx) == notifier.SeverityWarning
+	}), "Telnyx Balance Alert", mock.Anything).Return(nil).Once()
+	mockNotifier.On("SendNotification", mock.MatchedBy(func(ctx context.Context) bool {
+		return notifier.SeverityFromContext(ctx) == notifier.SeveritySuccess
+	}), "Telnyx Balance Recovered", "Your Telnyx balance has been restored to $25.00 (threshold: $10.00).").Return(nil).Once()
+	task.notifier = mockNotifier
+
+	// Below, then above three times: one alert, exactly one recovery
+	for i := 0; i < 4; i++ {
+		require.NoError(t, task.Run(context.Background()))
+	}
+
+	mockAPI.AssertExpectations(t)
+	mockNotifier.AssertExpectations(t)
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 2)
+	assert.False(t, task.belowThreshold)
+}
+
+func TestTelnyxBalanceCheckTask_Run_NoRecoveryWithoutAlert(t *testing.T) {
+	task := &TelnyxBalanceCheckTask{
+		threshold:            10.0,
+		notificationCooldown: 6 * time.Hour,
+	}
+
+	mockAPI := &MockTelnyxClient{}
+	mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{Amount: 25.0}, nil)
+	task.apiClient = mockAPI
+
+	mockNotifier := &MockNotifier{}
+	task.notifier = mockNotifier
+
+	require.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertNotCalled(t, "SendNotification", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTelnyxBalanceCheckTask_Run_RecoveryRespectsCooldown(t *testing.T) {
+	task := &TelnyxBalanceCheckTask{
+		threshold:            10.0,
+		notificationCooldown: 1 * time.Hour,
+		lastRecoveryTime:     time.Now().Add(-10 * time.Minute),
+	}
+
+	// Dips below (alert), recovers within the recovery cooldown (no message), stays up
+	mockAPI := &MockTelnyxClient{}
+	mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{Amount: 5.0}, nil).Once()
+	mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{Amount: 25.0}, nil).Twice()
+	task.apiClient = mockAPI
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "Telnyx Balance Alert", mock.Anything).Return(nil).Once()
+	task.notifier = mockNotifier
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, task.Run(context.Background()))
+	}
+
+	mockNotifier.AssertExpectations(t)
+	mockNotifier.AssertNotCalled(t, "SendNotification", mock.Anything, "Telnyx Balance Recovered", mock.Anything)
+	assert.False(t, task.belowThreshold)
+}
+
 func TestTelnyxBalanceCheckTask_Run_MultipleCalls_UpdatesLastNotificationTime(t *testing.T) {
 	task := &TelnyxBalanceCheckTask{
 		threshold:            10.0,
@@ -256,7 +700,7 @@ func TestTelnyxBalanceCheckTask_Run_MultipleCalls_UpdatesLastNotificationTime(t
 	}
 
 	mockAPI := &MockTelnyxClient{}
-	mockAPI.On("GetBalance", mock.Anything).Return(5.0, nil).Times(2)
+	mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{Amount: 5.0}, nil).Times(2)
 	task.apiClient = mockAPI
 
 	mockNotifier := &MockNotifier{}
@@ -264,7 +708,7 @@ func TestTelnyxBalanceCheckTask_Run_MultipleCalls_UpdatesLastNotificationTime(t
 	task.notifier = mockNotifier
 
 	// First call - should send notification
-	err := task.Run()
+	err := task.Run(context.Background())
 	require.NoError(t, err)
 	firstNotificationTime := task.lastNotificationTime
 
@@ -272,7 +716,7 @@ func TestTelnyxBalanceCheckTask_Run_MultipleCalls_UpdatesLastNotificationTime(t
 	time.Sleep(10 * time.Millisecond)
 
 	// Second call - should not send notification due to cooldown
-	err = task.Run()
+	err = task.Run(context.Background())
 	require.NoError(t, err)
 
 	// lastNotificationTime should be unchanged
@@ -289,13 +733,13 @@ func TestTelnyxBalanceCheckTask_Run_ZeroThreshold(t *testing.T) {
 	}
 
 	mockAPI := &MockTelnyxClient{}
-	mockAPI.On("GetBalance", mock.Anything).Return(5.0, nil)
+	mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{Amount: 5.0}, nil)
 	task.apiClient = mockAPI
 
 	mockNotifier := &MockNotifier{}
 	task.notifier = mockNotifier
 
-	err := task.Run()
+	err := task.Run(context.Background())
 
 	assert.NoError(t, err)
 	mockAPI.AssertExpectations(t)
@@ -311,17 +755,150 @@ func TestTelnyxBalanceCheckTask_Run_FirstNotification(t *testing.T) {
 	}
 
 	mockAPI := &MockTelnyxClient{}
-	mockAPI.On("GetBalance", mock.Anything).Return(5.0, nil)
+	mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{Amount: 5.0}, nil)
 	task.apiClient = mockAPI
 
 	mockNotifier := &MockNotifier{}
 	mockNotifier.On("SendNotification", mock.Anything, "Telnyx Balance Alert", mock.Anything).Return(nil)
 	task.notifier = mockNotifier
 
-	err := task.Run()
+	err := task.Run(context.Background())
 
 	assert.NoError(t, err)
 	// First notification should always go through regardless of cooldown
 	mockNotifier.AssertExpectations(t)
 	assert.False(t, task.lastNotificationTime.IsZero())
 }
+
+func TestTelnyxBalanceCheckTask_Run_PassesDeadlineContext(t *testing.T) {
+	task := &TelnyxBalanceCheckTask{
+		threshold:            10.0,
+		notificationCooldown: 6 * time.Hour,
+	}
+
+	mockAPI := &MockTelnyxClient{}
+	mockAPI.On("GetBalance", mock.MatchedBy(func(ctx context.Context) bool {
+		// Each run should bound the API call with its own timeout
+		_, hasDeadline := ctx.Deadline()
+		return hasDeadline
+	})).Return(api.Balance{Amount: 25.0}, nil)
+	task.apiClient = mockAPI
+	task.notifier = &MockNotifier{}
+
+	err := task.Run(context.Background())
+
+	assert.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestTelnyxBalanceCheckTask_Run_ExportsMetrics(t *testing.T) {
+	task := &TelnyxBalanceCheckTask{
+		threshold:            10.0,
+		notificationCooldown: 6 * time.Hour,
+	}
+
+	mockAPI := &MockTelnyxClient{}
+	mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{Amount: 37.25}, nil)
+	task.apiClient = mockAPI
+	task.notifier = &MockNotifier{}
+
+	require.NoError(t, task.Run(context.Background()))
+
+	server := httptest.NewServer(metrics.Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(body), "watchdog_telnyx_balance 37.25")
+	assert.Contains(t, string(body), `watchdog_task_run_duration_seconds_count{task="telnyx-balance"}`)
+}
+
+func TestTelnyxBalanceCheckTask_Run_RunwayAlert(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	mockAPI := &MockTelnyxClient{}
+	mockNotifier := &MockNotifier{}
+	task := &TelnyxBalanceCheckTask{
+		threshold:            10.0,
+		notificationCooldown: 6 * time.Hour,
+		apiClient:            mockAPI,
+		notifier:             mockNotifier,
+		MinRunway:            48 * time.Hour,
+		Clock:                fake,
+	}
+
+	var messages []string
+	mockNotifier.On("SendNotification", mock.Anything, "Telnyx Balance Depleting", mock.Anything).
+		Run(func(args mock.Arguments) { messages = append(messages, args.String(2)) }).
+		Return(nil)
+
+	// Dropping $2/hour: runway is 49h, 48h, then 47h at the fourth reading
+	for i, balance := range []float64{100, 98, 96, 94, 92} {
+		if i > 0 {
+			fake.Advance(time.Hour)
+		}
+		mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{Amount: balance}, nil).Once()
+		require.NoError(t, task.Run(context.Background()))
+
+		if balance > 94 {
+			assert.Empty(t, messages, "no alert expected at $%.0f", balance)
+		}
+	}
+
+	// Alerted once at $94; the $92 reading is within the cooldown
+	require.Len(t, messages, 1)
+	assert.Equal(t, "Your Telnyx balance ($94.00) is dropping by $2.00 per hour and will run out in about 47h (minimum runway: 48h).", messages[0])
+}
+
+func TestTelnyxBalanceCheckTask_Run_RunwayResetsAfterTopUp(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	mockAPI := &MockTelnyxClient{}
+	mockNotifier := &MockNotifier{}
+	task := &TelnyxBalanceCheckTask{
+		threshold:            10.0,
+		notificationCooldown: time.Minute,
+		apiClient:            mockAPI,
+		notifier:             mockNotifier,
+		MinRunway:            48 * time.Hour,
+		Clock:                fake,
+	}
+	mockNotifier.On("SendNotification", mock.Anything, "Telnyx Balance Depleting", mock.Anything).Return(nil).Once()
+
+	// A steep drop alerts; after a top-up the old readings no longer predict anything
+	for _, balance := range []float64{100, 60, 500, 499} {
+		mockAPI.On("GetBalance", mock.Anything).Return(api.Balance{Amount: balance}, nil).Once()
+		require.NoError(t, task.Run(context.Background()))
+		fake.Advance(2 * time.Hour)
+	}
+
+	mockNotifier.AssertExpectations(t)
+	assert.Len(t, task.history, 2)
+	assert.Equal(t, 500.0, task.history[0].amount)
+}
+
+func TestTelnyxBalanceCheckTask_Runway(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	task := &TelnyxBalanceCheckTask{}
+
+	task.recordReading(50, start)
+	_, _, ok := task.runway()
+	assert.False(t, ok, "a single reading has no rate")
+
+	// Readings less than an hour apart aren't trusted yet
+	task.recordReading(40, start.Add(30*time.Minute))
+	_, _, ok = task.runway()
+	assert.False(t, ok)
+
+	task.recordReading(30, start.Add(2*time.Hour))
+	runway, perHour, ok := task.runway()
+	require.True(t, ok)
+	assert.Equal(t, 10.0, perHour)
+	assert.Equal(t, 3*time.Hour, runway)
+
+	// Readings outside the 24h window are dropped
+	task.recordReading(29, start.Add(26*time.Hour))
+	assert.Equal(t, 30.0, task.history[0].amount)
+}
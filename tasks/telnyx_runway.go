@@ -0,0 +1,103 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"watchdog/internal/api"
+	"watchdog/internal/clock"
+	"watchdog/internal/notifier"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// runwayHistoryWindow is how far back balance readings are kept to compute the depletion rate
+	runwayHistoryWindow = 24 * time.Hour
+
+	// runwayMinSpan is how much time the readings must span before a rate is trusted,
+	// so one early reading right after a large charge doesn't trigger an alert
+	runwayMinSpan = time.Hour
+)
+
+// balanceReading is a balance observed at a point in time.
+type balanceReading struct {
+	at     time.Time
+	amount float64
+}
+
+// recordReading adds a balance reading to the history used for the depletion rate.
+// Readings older than runwayHistoryWindow are dropped, and a top-up (a balance higher
+// than the previous reading) starts a new history, since it breaks the trend.
+func (t *TelnyxBalanceCheckTask) recordReading(amount float64, now time.Time) {
+	if n := len(t.history); n > 0 && amount > t.history[n-1].amount {
+		t.history = nil
+	}
+	t.history = append(t.history, balanceReading{at: now, amount: amount})
+
+	keep := 0
+	for keep < len(t.history)-1 && now.Sub(t.history[keep].at) > runwayHistoryWindow {
+		keep++
+	}
+	t.history = t.history[keep:]
+}
+
+// runway projects how long the balance lasts at the rate it dropped over the history.
+// ok is false if there isn't enough history, or the balance isn't dropping.
+func (t *TelnyxBalanceCheckTask) runway() (runway time.Duration, perHour float64, ok bool) {
+	if len(t.history) < 2 {
+		return 0, 0, false
+	}
+	oldest, newest := t.history[0], t.history[len(t.history)-1]
+	span := newest.at.Sub(oldest.at)
+	if span < runwayMinSpan || newest.amount >= oldest.amount {
+		return 0, 0, false
+	}
+
+	perHour = (oldest.amount - newest.amount) / span.Hours()
+	if newest.amount <= 0 {
+		return 0, perHour, true
+	}
+	return time.Duration(newest.amount / perHour * float64(time.Hour)), perHour, true
+}
+
+// checkRunway sends a "balance depleting" alert if, at the current depletion rate, the
+// balance runs out within MinRunway. Alerts share the low balance cooldown length.
+func (t *TelnyxBalanceCheckTask) checkRunway(ctx context.Context, current api.Balance) error {
+	if t.MinRunway <= 0 {
+		return nil
+	}
+	runway, perHour, ok := t.runway()
+	if !ok || runway >= t.MinRunway {
+		return nil
+	}
+
+	if !t.lastRunwayAlertTime.IsZero() && clock.Since(t.Clock, t.lastRunwayAlertTime) < t.notificationCooldown {
+		log.Info().
+			Float64("balance", current.Amount).
+			Dur("runway", runway).
+			Time("last_sent", t.lastRunwayAlertTime).
+			Msg("Balance depleting, skipping notification due to cooldown")
+		return nil
+	}
+
+	subject := "Telnyx Balance Depleting"
+	message := fmt.Sprintf("Your Telnyx balance (%s) is dropping by %s per hour and will run out in about %s (minimum runway: %s).",
+		t.formatAmount(current.Amount, current.Currency), t.formatAmount(perHour, current.Currency),
+		formatRunway(runway), formatRunway(t.MinRunway))
+	if err := t.notifier.SendNotification(notifier.WithSeverity(ctx, notifier.SeverityWarning), subject, message); err != nil {
+		return fmt.Errorf("failed to send notification: %v", err)
+	}
+
+	t.lastRunwayAlertTime = clock.Now(t.Clock)
+	t.saveState()
+	return nil
+}
+
+// formatRunway renders d rounded to whole hours (e.g. "36h"), or minutes below an hour.
+func formatRunway(d time.Duration) string {
+	if d < time.Hour {
+		return d.Round(time.Minute).String()
+	}
+	return fmt.Sprintf("%dh", int(d.Round(time.Hour)/time.Hour))
+}
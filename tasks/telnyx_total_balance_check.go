@@ -0,0 +1,193 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"watchdog/internal/api"
+	"watchdog/internal/clock"
+	"watchdog/internal/metrics"
+	"watchdog/internal/money"
+	"watchdog/internal/notifier"
+	"watchdog/internal/state"
+
+	"github.com/rs/zerolog/log"
+)
+
+// TelnyxDefaultAccount names the account configured by tasks.telnyx.api_key among the
+// accounts whose balances are added up.
+const TelnyxDefaultAccount = "default"
+
+// TelnyxAccount is a Telnyx account whose balance counts towards the total.
+type TelnyxAccount struct {
+	// Name identifies the account in logs and notifications
+	Name string
+
+	// Client fetches the account's balance
+	Client api.TelnyxClient
+}
+
+// TelnyxTotalBalanceCheckTask alerts when the balances of several Telnyx accounts add
+// up to less than a threshold, for users who care about a floor across all accounts
+// rather than (or as well as) each account's own balance.
+//
+// Each run fetches the balance of every account. If any of them can't be fetched, the
+// run fails without alerting, since a partial total would be too low.
+//
+// This implements the scheduler.Task interface via the Run() method.
+type TelnyxTotalBalanceCheckTask struct {
+	// accounts are the accounts whose balances are added up
+	accounts []TelnyxAccount
+
+	// threshold is the minimum acceptable total balance
+	threshold float64
+
+	// notificationCooldown limits how often the low total alert is sent
+	notificationCooldown time.Duration
+
+	// lastNotificationTime tracks when we last sent a low total alert
+	lastNotificationTime time.Time
+
+	// notifier is used to send alerts (via Apprise/Telegram/Discord/etc.)
+	notifier notifier.Notifier
+
+	// state persists lastNotificationTime across restarts (nil = in-memory only)
+	state state.StateStore
+
+	// Tags routes this task's notifications to the Apprise services with these tags.
+	Tags []string
+
+	// Locale formats amounts in notifications (e.g., "de-DE" gives "1.234,50 €").
+	// Empty keeps the plain "$5.00" / "5.00 EUR" format.
+	Locale string
+
+	// Timeout bounds each run: the balance requests including retries, and any alert it
+	// sends. 0 uses defaultTelnyxTimeout.
+	Timeout time.Duration
+
+	// Clock tells the time for the cooldown (nil means the system clock)
+	Clock clock.Clock
+}
+
+// State file namespace and key for the low total alert cooldown.
+const (
+	telnyxTotalStateNamespace = "telnyx_total"
+	telnyxTotalStateKey       = "low_total"
+)
+
+// NewTelnyxTotalBalanceCheckTask creates a task that alerts through notifier when the
+// balances of accounts add up to less than threshold, at most once per cooldown.
+func NewTelnyxTotalBalanceCheckTask(accounts []TelnyxAccount, threshold float64, cooldown time.Duration, notifier notifier.Notifier) *TelnyxTotalBalanceCheckTask {
+	return &TelnyxTotalBalanceCheckTask{
+		accounts:             accounts,
+		threshold:            threshold,
+		notificationCooldown: cooldown,
+		notifier:             notifier,
+		Clock:                clock.Real{},
+	}
+}
+
+// Name identifies the task in logs and status reports.
+func (t *TelnyxTotalBalanceCheckTask) Name() string {
+	return "telnyx-total-balance"
+}
+
+// LoadState restores the alert cooldown saved by a previous process from store, and
+// saves it back whenever an alert is sent. A nil store keeps it in memory only.
+func (t *TelnyxTotalBalanceCheckTask) LoadState(store state.StateStore) {
+	t.state = store
+	if store == nil {
+		return
+	}
+	if lastTime, ok := store.Load(telnyxTotalStateNamespace)[telnyxTotalStateKey]; ok {
+		t.lastNotificationTime = lastTime
+	}
+}
+
+// Run fetches the balance of every account and sends an alert if their total is below
+// the threshold and the cooldown has passed.
+//
+// Returns:
+//   - An error if an account's balance can't be fetched, or the accounts report
+//     different currencies (their balances can't be added up)
+//   - An error if the notification fails to send
+//   - nil otherwise, including when the request budget is used up
+func (t *TelnyxTotalBalanceCheckTask) Run(ctx context.Context) error {
+	timeout := t.Timeout
+	if timeout <= 0 {
+		timeout = defaultTelnyxTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ctx = notifier.WithTags(ctx, t.Tags...)
+
+	defer metrics.ObserveTaskRun(t.Name(), time.Now())
+
+	balances := make([]api.Balance, len(t.accounts))
+	for i, account := range t.accounts {
+		balance, err := account.Client.GetBalance(ctx)
+		var budget *api.BudgetExceededError
+		if errors.As(err, &budget) {
+			log.Warn().Time("reset", budget.Reset).Msg("API request budget used up, skipping total balance check until the budget resets")
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get balance of Telnyx account %q: %w", account.Name, err)
+		}
+		if i > 0 && balance.Currency != balances[0].Currency {
+			return fmt.Errorf("telnyx accounts %q and %q report different currencies (%s and %s), so their balances can't be added up",
+				t.accounts[0].Name, account.Name, balances[0].Currency, balance.Currency)
+		}
+		balances[i] = balance
+	}
+
+	total := 0.0
+	for _, balance := range balances {
+		total += balance.Amount
+	}
+	currency := ""
+	if len(balances) > 0 {
+		currency = balances[0].Currency
+	}
+	log.Debug().Float64("total", total).Int("accounts", len(balances)).Msg("Total Telnyx balance")
+
+	if total >= t.threshold {
+		return nil
+	}
+
+	if !t.lastNotificationTime.IsZero() && clock.Since(t.Clock, t.lastNotificationTime) < t.notificationCooldown {
+		log.Info().
+			Float64("total", total).
+			Time("last_sent", t.lastNotificationTime).
+			Msg("Total balance below threshold, skipping notification due to cooldown")
+		return nil
+	}
+
+	subject := "Telnyx Total Balance Alert"
+	message := t.formatMessage(balances, total, currency)
+	if err := t.notifier.SendNotification(notifier.WithSeverity(ctx, notifier.SeverityWarning), subject, message); err != nil {
+		return fmt.Errorf("failed to send notification: %v", err)
+	}
+
+	t.lastNotificationTime = clock.Now(t.Clock)
+	if t.state != nil {
+		entries := map[string]time.Time{telnyxTotalStateKey: t.lastNotificationTime}
+		if err := t.state.Save(telnyxTotalStateNamespace, entries); err != nil {
+			log.Error().Err(err).Msg("Failed to save notification state")
+		}
+	}
+	return nil
+}
+
+// formatMessage builds the low total alert, listing the balance of each account.
+func (t *TelnyxTotalBalanceCheckTask) formatMessage(balances []api.Balance, total float64, currency string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "The total balance of your %s (%s) has fallen below the %s threshold.",
+		plural(len(balances), "Telnyx account"), money.Format(total, currency, t.Locale), money.Format(t.threshold, currency, t.Locale))
+	for i, balance := range balances {
+		fmt.Fprintf(&b, "\n- %s: %s", t.accounts[i].Name, money.Format(balance.Amount, balance.Currency, t.Locale))
+	}
+	return b.String()
+}
@@ -0,0 +1,125 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"watchdog/internal/api"
+	"watchdog/internal/clock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// telnyxAccounts returns accounts named after the keys of balances (in the order of
+// names) whose clients report those balances in USD.
+func telnyxAccounts(names []string, balances map[string]float64) []TelnyxAccount {
+	accounts := make([]TelnyxAccount, 0, len(names))
+	for _, name := range names {
+		client := &MockTelnyxClient{}
+		client.On("GetBalance", mock.Anything).Return(api.Balance{Amount: balances[name], Currency: "USD"}, nil)
+		accounts = append(accounts, TelnyxAccount{Name: name, Client: client})
+	}
+	return accounts
+}
+
+func TestTelnyxTotalBalanceCheckTask_Run_Threshold(t *testing.T) {
+	names := []string{"default", "eu", "us"}
+	tests := []struct {
+		name     string
+		balances map[string]float64
+		alerted  bool
+	}{
+		{name: "total above threshold", balances: map[string]float64{"default": 40, "eu": 35, "us": 30}},
+		{name: "total at threshold", balances: map[string]float64{"default": 40, "eu": 35, "us": 25}},
+		// Every account is above a per-account floor of 20, but the total is below 100
+		{name: "total below threshold", balances: map[string]float64{"default": 30, "eu": 25, "us": 21.5}, alerted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockNotifier := &MockNotifier{}
+			mockNotifier.On("SendNotification", mock.Anything, "Telnyx Total Balance Alert", mock.Anything).Return(nil)
+
+			task := NewTelnyxTotalBalanceCheckTask(telnyxAccounts(names, tt.balances), 100, 6*time.Hour, mockNotifier)
+			require.NoError(t, task.Run(context.Background()))
+
+			if !tt.alerted {
+				mockNotifier.AssertNotCalled(t, "SendNotification", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			mockNotifier.AssertNumberOfCalls(t, "SendNotification", 1)
+			assert.Equal(t, "The total balance of your 3 Telnyx accounts ($76.50) has fallen below the $100.00 threshold.\n"+
+				"- default: $30.00\n- eu: $25.00\n- us: $21.50", mockNotifier.Calls[0].Arguments.String(2))
+		})
+	}
+}
+
+func TestTelnyxTotalBalanceCheckTask_Run_RespectsCooldown(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "Telnyx Total Balance Alert", mock.Anything).Return(nil)
+
+	store := &memoryStateStore{}
+	accounts := telnyxAccounts([]string{"default", "eu"}, map[string]float64{"default": 5, "eu": 5})
+	task := NewTelnyxTotalBalanceCheckTask(accounts, 20, 6*time.Hour, mockNotifier)
+	task.Clock = fake
+	task.LoadState(store)
+
+	require.NoError(t, task.Run(context.Background()))
+	fake.Advance(time.Hour)
+	require.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 1)
+
+	// The cooldown survives a restart
+	restarted := NewTelnyxTotalBalanceCheckTask(accounts, 20, 6*time.Hour, mockNotifier)
+	restarted.Clock = fake
+	restarted.LoadState(store)
+	require.NoError(t, restarted.Run(context.Background()))
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 1)
+
+	fake.Advance(6 * time.Hour)
+	require.NoError(t, restarted.Run(context.Background()))
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 2)
+}
+
+func TestTelnyxTotalBalanceCheckTask_Run_AccountFailureSkipsAlert(t *testing.T) {
+	accounts := telnyxAccounts([]string{"default"}, map[string]float64{"default": 1})
+	failing := &MockTelnyxClient{}
+	failing.On("GetBalance", mock.Anything).Return(api.Balance{}, errors.New("401 Unauthorized"))
+	accounts = append(accounts, TelnyxAccount{Name: "eu", Client: failing})
+
+	mockNotifier := &MockNotifier{}
+	task := NewTelnyxTotalBalanceCheckTask(accounts, 100, 6*time.Hour, mockNotifier)
+
+	// A partial total would be too low, so nothing is alerted on
+	err := task.Run(context.Background())
+	assert.ErrorContains(t, err, `failed to get balance of Telnyx account "eu": 401 Unauthorized`)
+	mockNotifier.AssertNotCalled(t, "SendNotification", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTelnyxTotalBalanceCheckTask_Run_CurrencyMismatch(t *testing.T) {
+	accounts := telnyxAccounts([]string{"default"}, map[string]float64{"default": 1})
+	euro := &MockTelnyxClient{}
+	euro.On("GetBalance", mock.Anything).Return(api.Balance{Amount: 1, Currency: "EUR"}, nil)
+	accounts = append(accounts, TelnyxAccount{Name: "eu", Client: euro})
+
+	mockNotifier := &MockNotifier{}
+	task := NewTelnyxTotalBalanceCheckTask(accounts, 100, 6*time.Hour, mockNotifier)
+
+	assert.ErrorContains(t, task.Run(context.Background()), "report different currencies (USD and EUR)")
+	mockNotifier.AssertNotCalled(t, "SendNotification", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTelnyxTotalBalanceCheckTask_Run_BudgetExceeded(t *testing.T) {
+	client := &MockTelnyxClient{}
+	client.On("GetBalance", mock.Anything).Return(api.Balance{}, &api.BudgetExceededError{Limit: 10})
+
+	mockNotifier := &MockNotifier{}
+	task := NewTelnyxTotalBalanceCheckTask([]TelnyxAccount{{Name: "default", Client: client}}, 100, 6*time.Hour, mockNotifier)
+
+	assert.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertNotCalled(t, "SendNotification", mock.Anything, mock.Anything, mock.Anything)
+}
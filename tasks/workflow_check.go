@@ -0,0 +1,222 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"sort"
+	"sync"
+	"time"
+	"watchdog/internal/api"
+	"watchdog/internal/config"
+	"watchdog/internal/metrics"
+	"watchdog/internal/notifier"
+	"watchdog/internal/state"
+
+	"github.com/rs/zerolog/log"
+)
+
+// WorkflowCheckTask alerts when GitHub Actions workflow runs fail on a watched branch
+// (configured via tasks.github.workflows), e.g. a scheduled build on main that nobody
+// sees fail because it isn't attached to a PR.
+//
+// For each watched repository and branch the task:
+//  1. Fetches the most recent failed workflow runs
+//  2. Notifies about each failed run newer than the last one it has seen
+//  3. Remembers the newest run it has seen, so a failure is only alerted on once
+//
+// On the first check of a branch the existing failures are only recorded, not alerted on.
+//
+// This implements the scheduler.Task interface via the Run() method.
+type WorkflowCheckTask struct {
+	// config holds the GitHub configuration (workflows to watch, token, tags, etc.)
+	config config.GitHubConfig
+
+	// apiClient is used to fetch workflow runs from GitHub
+	apiClient api.GitHubClient
+
+	// notifier is used to send alerts (via Apprise/Telegram/Discord/etc.)
+	notifier notifier.Notifier
+
+	// format is the notification body format ("text", "markdown" or "html")
+	format string
+
+	// lastSeen is the ID of the newest failed run seen per watched branch
+	// Key format: "owner/repo@branch"
+	lastSeen map[string]int64
+
+	// seenAt is when the newest failed run seen per watched branch was created. It is
+	// persisted, so a restarted process alerts on runs that failed while it was down.
+	seenAt map[string]time.Time
+
+	// mu guards access to lastSeen and seenAt
+	mu sync.Mutex
+
+	// state persists seenAt across restarts (nil = in-memory only)
+	state state.StateStore
+}
+
+// workflowStateNamespace is the key workflow runs seen are stored under in the state file.
+const workflowStateNamespace = "github_workflows"
+
+// NewWorkflowCheckTask creates a new workflow failure monitoring task.
+// Parameters:
+//   - cfg: GitHub configuration (workflows to watch, token, etc.)
+//   - notifier: Where to send notifications (Apprise webhook, Telegram, etc.)
+//   - format: Notification body format ("text", "markdown" or "html"); empty means "text"
+func NewWorkflowCheckTask(cfg config.GitHubConfig, notifier notifier.Notifier, format string) *WorkflowCheckTask {
+	return &WorkflowCheckTask{
+		config:    cfg,
+		apiClient: NewGitHubClient(cfg),
+		notifier:  notifier,
+		format:    format,
+		lastSeen:  make(map[string]int64),
+		seenAt:    make(map[string]time.Time),
+	}
+}
+
+// Name identifies the task in logs and status reports.
+func (t *WorkflowCheckTask) Name() string {
+	return "github-workflows"
+}
+
+// LoadState restores the runs seen by a previous process from store, and saves them
+// back to it after every run. A nil store keeps them in memory only.
+// Entries for branches that are no longer watched are dropped.
+func (t *WorkflowCheckTask) LoadState(store state.StateStore) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.state = store
+	if store == nil {
+		return
+	}
+
+	watched := make(map[string]bool, len(t.config.Workflows))
+	for _, workflow := range t.config.Workflows {
+		watched[workflowKey(workflow)] = true
+	}
+	for key, createdAt := range store.Load(workflowStateNamespace) {
+		if watched[key] {
+			t.seenAt[key] = createdAt
+		}
+	}
+}
+
+// Run checks every watched branch for new failed workflow runs.
+//
+// Returns:
+//   - Always returns nil (errors are logged but don't stop the scheduler)
+//   - Individual repo failures are logged and skipped
+func (t *WorkflowCheckTask) Run(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+	ctx = notifier.WithTags(ctx, t.config.Tags...)
+
+	defer metrics.ObserveTaskRun(t.Name(), time.Now())
+
+	for _, workflow := range t.config.Workflows {
+		t.checkWorkflow(ctx, workflow)
+	}
+
+	t.mu.Lock()
+	saveNotificationTimes(t.state, workflowStateNamespace, t.seenAt)
+	t.mu.Unlock()
+
+	return nil
+}
+
+// checkWorkflow notifies about the failed runs on one watched branch that weren't seen before.
+// If a notification fails, later runs are left for the next check so none is skipped.
+func (t *WorkflowCheckTask) checkWorkflow(ctx context.Context, workflow config.WorkflowConfig) {
+	branch := workflow.GetBranch()
+	key := workflowKey(workflow)
+
+	runs, err := t.apiClient.GetFailedWorkflowRuns(ctx, workflow.Owner, workflow.Repo, branch)
+	var budget *api.BudgetExceededError
+	if errors.As(err, &budget) {
+		log.Warn().Str("workflow", key).Time("reset", budget.Reset).Msg("API request budget used up, skipping workflow until the budget resets")
+		return
+	}
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("owner", workflow.Owner).
+			Str("repo", workflow.Repo).
+			Str("branch", branch).
+			Msg("Failed to fetch workflow runs")
+		return
+	}
+
+	// Oldest first, so alerts go out in order
+	sort.Slice(runs, func(i, j int) bool { return runs[i].ID < runs[j].ID })
+
+	t.mu.Lock()
+	lastID, watching := t.lastSeen[key]
+	savedAt, saved := t.seenAt[key]
+	if !watching && !saved {
+		// First check of this branch: earlier failures are history, not news
+		t.lastSeen[key] = 0
+		if len(runs) > 0 {
+			t.markSeen(key, runs[len(runs)-1])
+		}
+		t.mu.Unlock()
+		log.Info().Str("workflow", key).Int("failed_runs", len(runs)).Msg("Watching workflow runs; earlier failures are not alerted on")
+		return
+	}
+	t.mu.Unlock()
+
+	for _, run := range runs {
+		if (watching && run.ID <= lastID) || (!watching && !run.CreatedAt.After(savedAt)) {
+			continue // Seen before
+		}
+
+		subject := fmt.Sprintf("Workflow failed: %s (%s/%s)", run.Name, workflow.Owner, workflow.Repo)
+		message := t.formatFailureMessage(workflow, run)
+
+		log.Info().Str("workflow", key).Int64("run_id", run.ID).Msg("Sending notification for failed workflow run")
+		if err := t.notifier.SendNotification(notifier.WithSeverity(ctx, notifier.SeverityFailure), subject, message); err != nil {
+			log.Error().Err(err).Str("workflow", key).Int64("run_id", run.ID).Msg("Failed to send notification")
+			return
+		}
+
+		t.mu.Lock()
+		t.markSeen(key, run)
+		t.mu.Unlock()
+	}
+}
+
+// markSeen records run as the newest failed run seen for key. Must be called with mu held.
+func (t *WorkflowCheckTask) markSeen(key string, run api.WorkflowRun) {
+	t.lastSeen[key] = run.ID
+	t.seenAt[key] = run.CreatedAt
+}
+
+// workflowKey identifies a watched branch, e.g. "owner/repo@main".
+func workflowKey(workflow config.WorkflowConfig) string {
+	return fmt.Sprintf("%s/%s@%s", workflow.Owner, workflow.Repo, workflow.GetBranch())
+}
+
+// formatFailureMessage builds the notification body for a failed run in the configured format.
+func (t *WorkflowCheckTask) formatFailureMessage(workflow config.WorkflowConfig, run api.WorkflowRun) string {
+	commit := run.HeadSHA
+	if len(commit) > 7 {
+		commit = commit[:7]
+	}
+
+	switch t.format {
+	case notifier.FormatMarkdown:
+		return fmt.Sprintf("Workflow **%s** (run #%d) failed on %s/%s@%s.\n**Trigger:** %s\n**Commit:** %s\n**Link:** [%s](%s)",
+			run.Name, run.RunNumber, workflow.Owner, workflow.Repo, run.HeadBranch,
+			run.Event, commit, run.HTMLURL, run.HTMLURL)
+	case notifier.FormatHTML:
+		return fmt.Sprintf("Workflow <b>%s</b> (run #%d) failed on %s/%s@%s.<br>\n<b>Trigger:</b> %s<br>\n<b>Commit:</b> %s<br>\n<b>Link:</b> <a href=\"%s\">%s</a>",
+			html.EscapeString(run.Name), run.RunNumber, html.EscapeString(workflow.Owner), html.EscapeString(workflow.Repo), html.EscapeString(run.HeadBranch),
+			html.EscapeString(run.Event), html.EscapeString(commit), html.EscapeString(run.HTMLURL), html.EscapeString(run.HTMLURL))
+	default:
+		return fmt.Sprintf("Workflow %s (run #%d) failed on %s/%s@%s.\nTrigger: %s\nCommit: %s\nLink: %s",
+			run.Name, run.RunNumber, workflow.Owner, workflow.Repo, run.HeadBranch,
+			run.Event, commit, run.HTMLURL)
+	}
+}
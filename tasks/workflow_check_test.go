@@ -0,0 +1,150 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+	"watchdog/internal/api"
+	"watchdog/internal/config"
+	"watchdog/internal/notifier"
+	"watchdog/internal/state"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// failedRun returns a failed workflow run on main with the given ID.
+func failedRun(id int64, name string, createdAt time.Time) api.WorkflowRun {
+	return api.WorkflowRun{
+		ID:         id,
+		Name:       name,
+		RunNumber:  int(id),
+		Event:      "schedule",
+		HeadBranch: "main",
+		HeadSHA:    "0123456789abcdef",
+		Conclusion: "failure",
+		HTMLURL:    "https://github.com/acme/api/actions/runs/" + name,
+		CreatedAt:  createdAt,
+	}
+}
+
+func newTestWorkflowCheckTask(mockAPI *MockGitHubClient, mockNotifier *MockNotifier) *WorkflowCheckTask {
+	cfg := config.GitHubConfig{Workflows: []config.WorkflowConfig{{Owner: "acme", Repo: "api"}}}
+	task := NewWorkflowCheckTask(cfg, mockNotifier, "")
+	task.apiClient = mockAPI
+	return task
+}
+
+func TestWorkflowCheckTask_Run_AlertsOnlyNewFailures(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	old := failedRun(100, "Nightly", base)
+	newer := failedRun(105, "Nightly", base.Add(24*time.Hour))
+	newest := failedRun(110, "Release", base.Add(48*time.Hour))
+
+	mockAPI := &MockGitHubClient{}
+	// Newest first, as GitHub returns them
+	mockAPI.On("GetFailedWorkflowRuns", mock.Anything, "acme", "api", "main").Return([]api.WorkflowRun{old}, nil).Once()
+	mockAPI.On("GetFailedWorkflowRuns", mock.Anything, "acme", "api", "main").Return([]api.WorkflowRun{newest, newer, old}, nil).Once()
+	mockAPI.On("GetFailedWorkflowRuns", mock.Anything, "acme", "api", "main").Return([]api.WorkflowRun{newest, newer, old}, nil).Once()
+
+	var subjects []string
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.MatchedBy(func(ctx context.Context) bool {
+		return notifier.SeverityFromContext(ctx) == notifier.SeverityFailure
+	}), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { subjects = append(subjects, args.String(1)) }).
+		Return(nil)
+
+	task := newTestWorkflowCheckTask(mockAPI, mockNotifier)
+
+	// The first check only records the failures that already happened
+	require.NoError(t, task.Run(context.Background()))
+	assert.Empty(t, subjects)
+
+	// New failures are alerted on, oldest first
+	require.NoError(t, task.Run(context.Background()))
+	assert.Equal(t, []string{"Workflow failed: Nightly (acme/api)", "Workflow failed: Release (acme/api)"}, subjects)
+
+	// Already-seen failures aren't alerted on again
+	require.NoError(t, task.Run(context.Background()))
+	assert.Len(t, subjects, 2)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestWorkflowCheckTask_Run_RetriesFailedNotification(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	first := failedRun(101, "first", base)
+	second := failedRun(102, "second", base.Add(time.Hour))
+
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetFailedWorkflowRuns", mock.Anything, "acme", "api", "main").Return([]api.WorkflowRun{}, nil).Once()
+	mockAPI.On("GetFailedWorkflowRuns", mock.Anything, "acme", "api", "main").Return([]api.WorkflowRun{second, first}, nil)
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "Workflow failed: first (acme/api)", mock.Anything).Return(errors.New("apprise down")).Once()
+	mockNotifier.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	task := newTestWorkflowCheckTask(mockAPI, mockNotifier)
+	require.NoError(t, task.Run(context.Background()))
+
+	// The failed notification stops the check, so the second run isn't skipped past it
+	require.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 1)
+
+	require.NoError(t, task.Run(context.Background()))
+	mockNotifier.AssertNumberOfCalls(t, "SendNotification", 3)
+}
+
+func TestWorkflowCheckTask_Run_FetchError(t *testing.T) {
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetFailedWorkflowRuns", mock.Anything, "acme", "api", "main").Return(nil, errors.New("API error"))
+
+	task := newTestWorkflowCheckTask(mockAPI, &MockNotifier{})
+
+	assert.NoError(t, task.Run(context.Background()))
+	assert.Empty(t, task.lastSeen)
+}
+
+func TestWorkflowCheckTask_LoadState_AlertsFailuresWhileDown(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	seen := failedRun(100, "seen", base)
+	missed := failedRun(105, "missed", base.Add(time.Hour))
+	store := state.NewFileStore(filepath.Join(t.TempDir(), "state.json"))
+
+	mockAPI := &MockGitHubClient{}
+	mockAPI.On("GetFailedWorkflowRuns", mock.Anything, "acme", "api", "main").Return([]api.WorkflowRun{seen}, nil).Once()
+	mockAPI.On("GetFailedWorkflowRuns", mock.Anything, "acme", "api", "main").Return([]api.WorkflowRun{missed, seen}, nil).Once()
+
+	mockNotifier := &MockNotifier{}
+	mockNotifier.On("SendNotification", mock.Anything, "Workflow failed: missed (acme/api)", mock.Anything).Return(nil).Once()
+
+	task := newTestWorkflowCheckTask(mockAPI, mockNotifier)
+	task.LoadState(store)
+	require.NoError(t, task.Run(context.Background()))
+
+	restarted := newTestWorkflowCheckTask(mockAPI, mockNotifier)
+	restarted.LoadState(state.NewFileStore(store.Path))
+	require.NoError(t, restarted.Run(context.Background()))
+
+	mockNotifier.AssertExpectations(t)
+	assert.Equal(t, int64(105), restarted.lastSeen["acme/api@main"])
+}
+
+func TestWorkflowCheckTask_FormatFailureMessage(t *testing.T) {
+	run := failedRun(42, "Nightly", time.Now())
+	workflow := config.WorkflowConfig{Owner: "acme", Repo: "api"}
+
+	text := (&WorkflowCheckTask{}).formatFailureMessage(workflow, run)
+	assert.Equal(t, "Workflow Nightly (run #42) failed on acme/api@main.\nTrigger: schedule\nCommit: 0123456\nLink: https://github.com/acme/api/actions/runs/Nightly", text)
+
+	markdown := (&WorkflowCheckTask{format: notifier.FormatMarkdown}).formatFailureMessage(workflow, run)
+	assert.Contains(t, markdown, "Workflow **Nightly** (run #42)")
+	assert.Contains(t, markdown, "[https://github.com/acme/api/actions/runs/Nightly](https://github.com/acme/api/actions/runs/Nightly)")
+
+	html := (&WorkflowCheckTask{format: notifier.FormatHTML}).formatFailureMessage(workflow, run)
+	assert.Contains(t, html, "Workflow <b>Nightly</b> (run #42)")
+	assert.Contains(t, html, `<a href="https://github.com/acme/api/actions/runs/Nightly">`)
+}